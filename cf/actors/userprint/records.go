@@ -0,0 +1,47 @@
+package userprint
+
+import (
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/records"
+	"github.com/cloudfoundry/cli/cf/terminal"
+)
+
+// RecordPrinter prints one record per user, with all of the user's roles,
+// in the UI's structured output format.
+type RecordPrinter struct {
+	UI         terminal.UI
+	UserLister func(guid string, role models.Role) ([]models.UserFields, error)
+	Roles      []models.Role
+}
+
+func (p *RecordPrinter) PrintUsers(guid string, username string) {
+	var users []models.UserFields
+	roles := map[string][]models.Role{}
+
+	for _, role := range p.Roles {
+		usersInRole, err := p.UserLister(guid, role)
+		if err != nil {
+			p.UI.Failed(T("Failed fetching users.\n{{.Error}}",
+				map[string]interface{}{"Error": err.Error()}))
+			return
+		}
+
+		for _, user := range usersInRole {
+			if _, found := roles[user.Username]; !found {
+				users = append(users, user)
+			}
+			roles[user.Username] = append(roles[user.Username], role)
+		}
+	}
+
+	userRecords := []records.User{}
+	for _, user := range users {
+		userRecords = append(userRecords, records.NewUser(user, roles[user.Username]))
+	}
+
+	err := p.UI.Output(userRecords)
+	if err != nil {
+		p.UI.Failed(err.Error())
+	}
+}
@@ -129,6 +129,8 @@ var _ = Describe("AppSummaryRepository", func() {
 			Expect(app.Memory).To(Equal(int64(128)))
 			Expect(app.PackageUpdatedAt.Format("2006-01-02T15:04:05Z07:00")).To(Equal("2014-10-24T19:54:00Z"))
 			Expect(app.StackGUID).To(Equal("the-stack-guid"))
+			Expect(app.HealthCheckType).To(Equal("port"))
+			Expect(app.DockerImage).To(Equal(""))
 		})
 	})
 
@@ -236,6 +238,8 @@ const getAppSummaryResponseBody string = `
 		"running_instances":1,
 		"name":"app1",
 		"stack_guid":"the-stack-guid",
+		"health_check_type":"port",
+		"docker_image":null,
 		"memory":128,
 		"command": "start_command",
 		"instances":1,
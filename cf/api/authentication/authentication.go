@@ -1,7 +1,6 @@
 package authentication
 
 import (
-	"crypto/tls"
 	"encoding/base64"
 	"fmt"
 	"net/http"
@@ -49,6 +48,16 @@ func NewUAARepository(gateway net.Gateway, config coreconfig.ReadWriter, dumper
 }
 
 func (uaa UAARepository) Authorize(token string) (string, error) {
+	tlsConfig, err := net.NewTLSConfigWithFiles(nil, uaa.config.IsSSLDisabled(), net.TLSFilesFromConfig(uaa.config))
+	if err != nil {
+		return "", err
+	}
+
+	proxy, err := net.ProxyFromConfig(uaa.config)
+	if err != nil {
+		return "", err
+	}
+
 	httpClient := &http.Client{
 		CheckRedirect: func(req *http.Request, _ []*http.Request) error {
 			uaa.DumpRequest(req)
@@ -56,11 +65,9 @@ func (uaa UAARepository) Authorize(token string) (string, error) {
 		},
 		Timeout: 30 * time.Second,
 		Transport: &http.Transport{
-			DisableKeepAlives: true,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: uaa.config.IsSSLDisabled(),
-			},
-			Proxy:               http.ProxyFromEnvironment,
+			DisableKeepAlives:   true,
+			TLSClientConfig:     tlsConfig,
+			Proxy:               proxy.Func(),
 			TLSHandshakeTimeout: 10 * time.Second,
 		},
 	}
@@ -125,9 +132,9 @@ func (uaa UAARepository) Authenticate(credentials map[string]string) error {
 		if ok {
 			switch {
 			case httpError.StatusCode() == http.StatusUnauthorized:
-				return errors.New(T("Credentials were rejected, please try again."))
+				return errors.NewWrappedError(T("Credentials were rejected, please try again."), err)
 			case httpError.StatusCode() >= http.StatusInternalServerError:
-				return errors.New(T("The targeted API endpoint could not be reached."))
+				return errors.NewWrappedError(T("The targeted API endpoint could not be reached."), err)
 			}
 		}
 
@@ -212,6 +219,8 @@ func (uaa UAARepository) getAuthToken(data url.Values) error {
 		return fmt.Errorf("%s: %s", T("Failed to start oauth request"), err.Error())
 	}
 	request.HTTPReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// asking for a token changes nothing, so it is retried like a GET
+	request.SafeToRetry = true
 
 	response := new(AuthenticationResponse)
 	_, err = uaa.gateway.PerformRequestForJSONResponse(request, &response)
@@ -221,9 +230,9 @@ func (uaa UAARepository) getAuthToken(data url.Values) error {
 	case errors.HTTPError:
 		return err
 	case *errors.InvalidTokenError:
-		return errors.New(T("Authentication has expired.  Please log back in to re-authenticate.\n\nTIP: Use `cf login -a <endpoint> -u <user> -o <org> -s <space>` to log back in and re-authenticate."))
+		return errors.NewWrappedError(T("Authentication has expired.  Please log back in to re-authenticate.\n\nTIP: Use `cf login -a <endpoint> -u <user> -o <org> -s <space>` to log back in and re-authenticate."), err)
 	default:
-		return fmt.Errorf("%s: %s", T("auth request failed"), err.Error())
+		return errors.NewWrappedError(fmt.Sprintf("%s: %s", T("auth request failed"), err.Error()), err)
 	}
 
 	// TODO: get the actual status code
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/net"
@@ -111,10 +112,15 @@ var _ = Describe("AuthenticationRepository", func() {
 							Status: http.StatusBadGateway,
 						},
 					}
-					setupTestServer(request)
+
+					testServer, handler = testnet.NewServer([]testnet.TestRequest{request, request, request})
+					config.SetAuthenticationEndpoint(testServer.URL)
+
+					gateway.RetryPolicy.Sleep = func(time.Duration) {}
+					auth = NewUAARepository(gateway, config, dumper)
 				})
 
-				It("returns a failure response", func() {
+				It("retries, then returns a failure response", func() {
 					Expect(handler).To(HaveAllRequestsCalled())
 					Expect(err).To(HaveOccurred())
 					Expect(err.Error()).To(Equal("The targeted API endpoint could not be reached."))
@@ -1,6 +1,9 @@
 package logs
 
-import "time"
+import (
+	"io"
+	"time"
+)
 
 type Loggable interface {
 	ToLog(loc *time.Location) string
@@ -16,6 +19,42 @@ type Repository interface {
 	Close()
 }
 
+// NewFailingRepository returns a repository whose requests fail with err,
+// for when the connection to the logs cannot be set up.
+func NewFailingRepository(err error) Repository {
+	return failingRepository{err: err}
+}
+
+type failingRepository struct {
+	err error
+}
+
+func (repo failingRepository) RecentLogsFor(appGUID string) ([]Loggable, error) {
+	return nil, repo.err
+}
+
+func (repo failingRepository) TailLogsFor(appGUID string, onConnect func(), logChan chan<- Loggable, errChan chan<- error) {
+	errChan <- repo.err
+}
+
+func (repo failingRepository) Close() {}
+
+// NewClosingRepository returns repo, which also closes closer when it is
+// closed, for resources that its connection to the logs needs.
+func NewClosingRepository(repo Repository, closer io.Closer) Repository {
+	return closingRepository{Repository: repo, closer: closer}
+}
+
+type closingRepository struct {
+	Repository
+	closer io.Closer
+}
+
+func (repo closingRepository) Close() {
+	repo.Repository.Close()
+	_ = repo.closer.Close()
+}
+
 const defaultBufferTime time.Duration = 25 * time.Millisecond
 
 func max(a, b int) int {
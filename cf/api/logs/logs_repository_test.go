@@ -0,0 +1,52 @@
+package logs_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/cli/cf/api/logs"
+	"github.com/cloudfoundry/cli/cf/api/logs/logsfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("failing repository", func() {
+	var repo logs.Repository
+
+	BeforeEach(func() {
+		repo = logs.NewFailingRepository(errors.New("Error reading CA certificate"))
+	})
+
+	It("fails to get recent logs", func() {
+		_, err := repo.RecentLogsFor("app-guid")
+		Expect(err).To(MatchError("Error reading CA certificate"))
+	})
+
+	It("fails to tail logs", func() {
+		logChan := make(chan logs.Loggable)
+		errChan := make(chan error, 1)
+		go repo.TailLogsFor("app-guid", func() {}, logChan, errChan)
+
+		Eventually(errChan).Should(Receive(MatchError("Error reading CA certificate")))
+	})
+})
+
+type countingCloser struct {
+	closed int
+}
+
+func (closer *countingCloser) Close() error {
+	closer.closed++
+	return nil
+}
+
+var _ = Describe("closing repository", func() {
+	It("closes the repository and the closer", func() {
+		repo := new(logsfakes.FakeRepository)
+		closer := new(countingCloser)
+
+		logs.NewClosingRepository(repo, closer).Close()
+
+		Expect(repo.CloseCallCount()).To(Equal(1))
+		Expect(closer.closed).To(Equal(1))
+	})
+})
@@ -396,6 +396,7 @@ func createOrganizationRepo(reqs ...testnet.TestRequest) (testserver *httptest.S
 	configRepo := testconfig.NewRepositoryWithDefaults()
 	configRepo.SetAPIEndpoint(testserver.URL)
 	gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+	gateway.RetryPolicy.MaxAttempts = 1
 	repo = NewCloudControllerOrganizationRepository(configRepo, gateway)
 	return
 }
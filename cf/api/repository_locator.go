@@ -2,7 +2,6 @@ package api
 
 import (
 	"crypto/tls"
-	"net/http"
 
 	"github.com/blang/semver"
 	"github.com/cloudfoundry/cli/cf"
@@ -63,6 +62,7 @@ type RepositoryLocator struct {
 	userRepo                        UserRepository
 	passwordRepo                    password.Repository
 	logsRepo                        logs.Repository
+	newLogsRepo                     func() logs.Repository
 	authTokenRepo                   ServiceAuthTokenRepository
 	serviceBrokerRepo               ServiceBrokerRepository
 	servicePlanRepo                 CloudControllerServicePlanRepository
@@ -88,7 +88,11 @@ func NewRepositoryLocator(config coreconfig.ReadWriter, gatewaysByName map[strin
 	cloudControllerGateway := gatewaysByName["cloud-controller"]
 	routingAPIGateway := gatewaysByName["routing-api"]
 	uaaGateway := gatewaysByName["uaa"]
-	loc.authRepo = authentication.NewUAARepository(uaaGateway, config, net.NewRequestDumper(logger))
+	dumper := net.NewRequestDumper(logger)
+	if uaaGateway.Tracer != nil {
+		dumper = uaaGateway.RequestDumper()
+	}
+	loc.authRepo = authentication.NewUAARepository(uaaGateway, config, dumper)
 
 	// ensure gateway refreshers are set before passing them by value to repositories
 	cloudControllerGateway.SetTokenRefresher(loc.authRepo)
@@ -105,19 +109,35 @@ func NewRepositoryLocator(config coreconfig.ReadWriter, gatewaysByName map[strin
 	loc.domainRepo = NewCloudControllerDomainRepository(config, cloudControllerGateway, strategy)
 	loc.endpointRepo = NewEndpointRepository(cloudControllerGateway)
 
-	tlsConfig := net.NewTLSConfig([]tls.Certificate{}, config.IsSSLDisabled())
+	// logs are not requested without the TLS files and proxy of the API
+	tlsConfig, tlsErr := net.NewTLSConfigWithFiles([]tls.Certificate{}, config.IsSSLDisabled(), net.TLSFilesFromConfig(config))
 
 	apiVersion, _ := semver.Make(config.APIVersion())
 
-	if apiVersion.GTE(cf.NoaaMinimumAPIVersion) {
-		consumer := consumer.New(config.DopplerEndpoint(), tlsConfig, http.ProxyFromEnvironment)
-		consumer.SetDebugPrinter(terminal.DebugPrinter{Logger: logger})
-		loc.logsRepo = logs.NewNoaaLogsRepository(config, consumer, loc.authRepo)
-	} else {
-		consumer := loggregator_consumer.New(config.LoggregatorEndpoint(), tlsConfig, http.ProxyFromEnvironment)
+	authRepo := loc.authRepo
+	dialTimeout := cloudControllerGateway.DialTimeout
+	loc.newLogsRepo = func() logs.Repository {
+		if tlsErr != nil {
+			return logs.NewFailingRepository(tlsErr)
+		}
+
+		// each repository has its own proxy, whose bridge is closed with it
+		proxy, err := net.ProxyFromConfig(config)
+		if err != nil {
+			return logs.NewFailingRepository(err)
+		}
+
+		if apiVersion.GTE(cf.NoaaMinimumAPIVersion) {
+			consumer := consumer.New(config.DopplerEndpoint(), tlsConfig, proxy.ConnectFunc(dialTimeout, config.DopplerEndpoint()))
+			consumer.SetDebugPrinter(terminal.DebugPrinter{Logger: logger})
+			return logs.NewClosingRepository(logs.NewNoaaLogsRepository(config, consumer, authRepo), proxy)
+		}
+
+		consumer := loggregator_consumer.New(config.LoggregatorEndpoint(), tlsConfig, proxy.ConnectFunc(dialTimeout, config.LoggregatorEndpoint()))
 		consumer.SetDebugPrinter(terminal.DebugPrinter{Logger: logger})
-		loc.logsRepo = logs.NewLoggregatorLogsRepository(config, consumer, loc.authRepo)
+		return logs.NewClosingRepository(logs.NewLoggregatorLogsRepository(config, consumer, authRepo), proxy)
 	}
+	loc.logsRepo = loc.newLogsRepo()
 
 	loc.organizationRepo = organizations.NewCloudControllerOrganizationRepository(config, cloudControllerGateway)
 	loc.passwordRepo = password.NewCloudControllerRepository(config, uaaGateway)
@@ -356,6 +376,7 @@ func (locator RepositoryLocator) GetPasswordRepository() password.Repository {
 
 func (locator RepositoryLocator) SetLogsRepository(repo logs.Repository) RepositoryLocator {
 	locator.logsRepo = repo
+	locator.newLogsRepo = nil
 	return locator
 }
 
@@ -363,6 +384,16 @@ func (locator RepositoryLocator) GetLogsRepository() logs.Repository {
 	return locator.logsRepo
 }
 
+// NewLogsRepository returns a logs repository with its own connection, for
+// commands that tail the logs of several apps at the same time. It returns
+// the repository set with SetLogsRepository, if any.
+func (locator RepositoryLocator) NewLogsRepository() logs.Repository {
+	if locator.newLogsRepo == nil {
+		return locator.logsRepo
+	}
+	return locator.newLogsRepo()
+}
+
 func (locator RepositoryLocator) SetServiceAuthTokenRepository(repo ServiceAuthTokenRepository) RepositoryLocator {
 	locator.authTokenRepo = repo
 	return locator
@@ -30,6 +30,7 @@ var _ = Describe("ServiceBindingsRepository", func() {
 		configRepo = testconfig.NewRepositoryWithDefaults()
 		configRepo.SetAPIEndpoint(server.URL())
 		gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+		gateway.RetryPolicy.MaxAttempts = 1
 		repo = NewCloudControllerServiceBindingRepository(configRepo, gateway)
 	})
 
@@ -41,6 +41,8 @@ var _ = Describe("UserRepository", func() {
 		config.SetUaaEndpoint(uaaServer.URL())
 		ccGateway = net.NewCloudControllerGateway(config, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
 		uaaGateway = net.NewUAAGateway(config, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+		ccGateway.RetryPolicy.MaxAttempts = 1
+		uaaGateway.RetryPolicy.MaxAttempts = 1
 		client = api.NewCloudControllerUserRepository(config, uaaGateway, ccGateway)
 	})
 
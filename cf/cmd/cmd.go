@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 
 	"path/filepath"
 
@@ -14,6 +17,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/configuration/confighelpers"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/net"
@@ -31,6 +35,58 @@ var cmdRegistry = commandregistry.Commands
 
 func Main(traceEnv string, args []string) {
 
+	//handles `cf --org ORG --space SPACE --output FORMAT [COMMAND]`
+	args, options, optionsErr := handleGlobalOptions(args)
+	orgOverride, spaceOverride := options.org, options.space
+
+	//handles `cf COMMAND ... --output FORMAT` for commands with structured output but without an
+	//--output flag of their own, likewise --format, --jsonpath, --sort-by, --filter and --columns,
+	//and --non-interactive for all commands
+	args, outputErr := handleOutputOptions(args, &options)
+	if optionsErr != nil {
+		outputErr = optionsErr
+	}
+
+	//overrides reach NewDependency (and cf processes started by plugins) through the environment
+	if orgOverride != "" {
+		_ = os.Setenv("CF_ORG", orgOverride)
+	}
+	if spaceOverride != "" {
+		_ = os.Setenv("CF_SPACE", spaceOverride)
+	}
+	//an output option given on the command line replaces the ones from the environment
+	if options.output != "" || options.format != "" || options.jsonPath != "" {
+		_ = os.Setenv("CF_OUTPUT", options.output)
+		_ = os.Setenv("CF_FORMAT", options.format)
+		_ = os.Setenv("CF_JSONPATH", options.jsonPath)
+	}
+	if options.sortBy != "" {
+		_ = os.Setenv("CF_SORT_BY", options.sortBy)
+	}
+	if options.filter != "" {
+		_ = os.Setenv("CF_FILTER", options.filter)
+	}
+	if options.columns != "" {
+		_ = os.Setenv("CF_COLUMNS", options.columns)
+	}
+	if options.nonInteractive {
+		_ = os.Setenv("CF_NON_INTERACTIVE", "true")
+	}
+
+	output, err := terminal.NewOutputOptions(os.Getenv("CF_OUTPUT"), os.Getenv("CF_FORMAT"), os.Getenv("CF_JSONPATH"))
+	if err == nil {
+		output.Table, err = terminal.NewTableOptions(os.Getenv("CF_SORT_BY"), os.Getenv("CF_FILTER"), os.Getenv("CF_COLUMNS"))
+	}
+	if outputErr == nil {
+		outputErr = err
+	}
+
+	//with structured output, stdout is kept for the records
+	messageWriter := io.Writer(Writer)
+	if output.Format.IsStructured() {
+		messageWriter = os.Stderr
+	}
+
 	//handle `cf -v` for cf version
 	if len(args) == 2 && (args[1] == "-v" || args[1] == "--version") {
 		args[1] = "version"
@@ -53,8 +109,8 @@ func Main(traceEnv string, args []string) {
 			ui := terminal.NewUI(
 				os.Stdin,
 				Writer,
-				terminal.NewTeePrinter(Writer),
-				trace.NewLogger(Writer, isVerbose, traceEnv, ""),
+				terminal.NewTeePrinter(messageWriter),
+				trace.NewLogger(messageWriter, isVerbose, traceEnv, ""),
 			)
 			ui.Failed(fmt.Sprintf("Config error: %s", err))
 		}
@@ -71,12 +127,48 @@ func Main(traceEnv string, args []string) {
 	traceConfigVal := config.Trace()
 
 	// Writer is assigned in writer_unix.go/writer_windows.go
-	traceLogger := trace.NewLogger(Writer, isVerbose, traceEnv, traceConfigVal)
+	traceLogger := trace.NewLogger(messageWriter, isVerbose, traceEnv, traceConfigVal)
 
 	deps := commandregistry.NewDependency(Writer, traceLogger, os.Getenv("CF_DIAL_TIMEOUT"))
 	defer handlePanics(args, deps.TeePrinter, deps.Logger)
 	defer deps.Config.Close()
 
+	if outputErr != nil {
+		fail(deps.UI, outputErr, errors.ExitCodeUsage)
+	}
+
+	_, err = net.CassetteFromEnv()
+	if err != nil {
+		fail(deps.UI, err, errors.ExitCodeUsage)
+	}
+
+	_, err = net.NewTracer(os.Getenv("CF_TRACE_FORMAT"), deps.Logger, time.Now)
+	if err != nil {
+		fail(deps.UI, err, errors.ExitCodeUsage)
+	}
+
+	_, err = net.NewRetryPolicy(os.Getenv("CF_RETRY_ATTEMPTS"), os.Getenv("CF_RETRY_BACKOFF"))
+	if err != nil {
+		fail(deps.UI, err, errors.ExitCodeUsage)
+	}
+
+	err = trace.SetRedactedKeys(os.Getenv("CF_TRACE_REDACT"))
+	if err != nil {
+		fail(deps.UI, err, errors.ExitCodeUsage)
+	}
+	if deps.Tracer != nil {
+		// os.Exit skips deferred calls, so successful commands print the
+		// summary themselves
+		defer deps.Tracer.PrintSummary()
+	}
+
+	if orgOverride != "" || spaceOverride != "" {
+		err = overrideTarget(deps, orgOverride, spaceOverride)
+		if err != nil {
+			fail(deps.UI, err, errors.ExitCodeRequirement)
+		}
+	}
+
 	warningProducers := []net.WarningProducer{}
 	for _, warningProducer := range deps.Gateways {
 		warningProducers = append(warningProducers, warningProducer)
@@ -98,30 +190,36 @@ func Main(traceEnv string, args []string) {
 		err = flagContext.Parse(cmdArgs...)
 		if err != nil {
 			usage := cmdRegistry.CommandUsage(cmdName)
-			deps.UI.Failed(T("Incorrect Usage") + "\n\n" + err.Error() + "\n\n" + usage)
+			fail(deps.UI, errors.New(T("Incorrect Usage")+"\n\n"+err.Error()+"\n\n"+usage), errors.ExitCodeUsage)
 		}
 
 		cmd = cmd.SetDependency(deps, false)
 		cmdRegistry.SetCommand(cmd)
 
+		//commands fail in Requirements only when their arguments are wrong
 		requirementsFactory := requirements.NewFactory(deps.Config, deps.RepoLocator)
+		terminal.SetExitCode(errors.ExitCodeUsage)
 		reqs := cmd.Requirements(requirementsFactory, flagContext)
+		terminal.SetExitCode(errors.ExitCodeFailure)
 
 		for _, req := range reqs {
 			err = req.Execute()
 			if err != nil {
-				deps.UI.Failed(err.Error())
+				fail(deps.UI, err, errors.ExitCodeRequirement)
 			}
 		}
 
 		err = cmd.Execute(flagContext)
 		if err != nil {
-			ui := terminal.NewUI(os.Stdin, Writer, terminal.NewTeePrinter(Writer), traceLogger)
-			ui.Failed(err.Error())
+			ui := terminal.NewUI(os.Stdin, Writer, terminal.NewTeePrinter(messageWriter), traceLogger)
+			fail(ui, err, errors.ExitCodeFailure)
 		}
 
 		warningsCollector.PrintWarnings()
 
+		if deps.Tracer != nil {
+			deps.Tracer.PrintSummary()
+		}
 		os.Exit(0)
 	}
 
@@ -147,7 +245,7 @@ func Main(traceEnv string, args []string) {
 	if !ran {
 		deps.UI.Say("'" + args[1] + T("' is not a registered command. See 'cf help'"))
 		suggestCommands(cmdName, deps.UI, append(cmdRegistry.ListCommands(), pluginConfig.ListCommands()...))
-		os.Exit(1)
+		os.Exit(errors.ExitCodeUsage)
 	}
 }
 
@@ -171,11 +269,19 @@ func handlePanics(args []string, printer terminal.Printer, logger trace.Printer)
 	err := recover()
 	panicPrinter.DisplayCrashDialog(err, commandArgs, stackTrace)
 
-	if err != nil {
-		os.Exit(1)
+	if err == terminal.QuietPanic {
+		os.Exit(terminal.ExitCode())
+	} else if err != nil {
+		os.Exit(errors.ExitCodeFailure)
 	}
 }
 
+// fail makes ui fail with the message of err. cf then exits with the exit
+// code of the category of err, or with code if err does not have one.
+func fail(ui terminal.UI, err error, code int) {
+	terminal.FailWithCode(ui, err, code)
+}
+
 func generateBacktrace() string {
 	stackByteCount := 0
 	stackSizeLimit := 1024 * 1024
@@ -210,6 +316,190 @@ func handleHelp(args []string) []string {
 	}
 }
 
+type globalOptions struct {
+	org      string
+	space    string
+	output   string
+	format   string
+	jsonPath string
+	sortBy   string
+	filter   string
+	columns  string
+
+	nonInteractive bool
+}
+
+// outputOptions maps the names of the global options that choose the output
+// to where they are stored.
+func (o *globalOptions) outputOptions() map[string]*string {
+	return map[string]*string{
+		"output":   &o.output,
+		"format":   &o.format,
+		"jsonpath": &o.jsonPath,
+		"sort-by":  &o.sortBy,
+		"filter":   &o.filter,
+		"columns":  &o.columns,
+	}
+}
+
+// set stores the value of a global option. --filter may be given more than
+// once, and its values are joined into one list.
+func (o *globalOptions) set(name string, target *string, value string) {
+	if name == "filter" && *target != "" {
+		*target = *target + "," + value
+		return
+	}
+	*target = value
+}
+
+// handleGlobalOptions removes the global options given before the command
+// name. The org and space default to CF_ORG and CF_SPACE. An option without
+// a value is an error.
+func handleGlobalOptions(args []string) ([]string, globalOptions, error) {
+	options := globalOptions{
+		org:   os.Getenv("CF_ORG"),
+		space: os.Getenv("CF_SPACE"),
+	}
+
+	remaining := []string{args[0]}
+	i := 1
+	for ; i < len(args); i++ {
+		var value *string
+		name := args[i]
+		if idx := strings.Index(name, "="); idx != -1 {
+			name = name[:idx]
+		}
+
+		switch name {
+		case "--non-interactive":
+			options.nonInteractive = nonInteractiveValue(args[i])
+			continue
+		case "--org":
+			value = &options.org
+		case "--space":
+			value = &options.space
+		default:
+			value = options.outputOptions()[strings.TrimPrefix(name, "--")]
+			if value == nil || !strings.HasPrefix(name, "--") {
+				return append(remaining, args[i:]...), options, nil
+			}
+		}
+
+		switch {
+		case strings.Contains(args[i], "="):
+			options.set(strings.TrimPrefix(name, "--"), value, args[i][len(name)+1:])
+		case i+1 < len(args):
+			i++
+			options.set(strings.TrimPrefix(name, "--"), value, args[i])
+		default:
+			return remaining, options, errors.New(T("Incorrect Usage: {{.Option}} requires a value",
+				map[string]interface{}{"Option": name}))
+		}
+	}
+
+	return remaining, options, nil
+}
+
+// handleOutputOptions removes the output and table options given after
+// the name of a core command with structured output, unless the command has
+// a flag of the same name, and --non-interactive after any core command. The
+// output options are an error after other commands. Commands that parse
+// their own arguments and plugin commands keep all of their arguments.
+func handleOutputOptions(args []string, options *globalOptions) ([]string, error) {
+	if len(args) < 2 {
+		return args, nil
+	}
+
+	cmd := cmdRegistry.FindCommand(args[1])
+	if cmd == nil {
+		return args, nil
+	}
+	meta := cmd.MetaData()
+	if meta.SkipFlagParsing {
+		return args, nil
+	}
+
+	values := options.outputOptions()
+	for name := range values {
+		if _, ok := meta.Flags[name]; ok {
+			delete(values, name)
+		}
+	}
+
+	remaining := []string{args[0], args[1]}
+	for i := 2; i < len(args); i++ {
+		name := strings.TrimPrefix(args[i], "--")
+		if idx := strings.Index(name, "="); idx != -1 {
+			name = name[:idx]
+		}
+
+		if _, own := meta.Flags[name]; name == "non-interactive" && !own && strings.HasPrefix(args[i], "--") {
+			options.nonInteractive = nonInteractiveValue(args[i])
+			continue
+		}
+
+		value, ok := values[name]
+		switch {
+		case !ok || !strings.HasPrefix(args[i], "--"):
+			remaining = append(remaining, args[i])
+		case !meta.StructuredOutput:
+			return args, errors.New(T("Incorrect Usage: {{.Command}} does not support the --{{.Option}} option",
+				map[string]interface{}{"Command": meta.Name, "Option": name}))
+		case strings.Contains(args[i], "="):
+			options.set(name, value, args[i][len(name)+3:])
+		case i+1 < len(args):
+			i++
+			options.set(name, value, args[i])
+		default:
+			remaining = append(remaining, args[i])
+		}
+	}
+	return remaining, nil
+}
+
+// nonInteractiveValue reads --non-interactive, which is a boolean option
+// that takes no separate value but may be given as --non-interactive=false.
+func nonInteractiveValue(arg string) bool {
+	if idx := strings.Index(arg, "="); idx != -1 {
+		value, err := strconv.ParseBool(arg[idx+1:])
+		return err != nil || value
+	}
+	return true
+}
+
+// overrideTarget resolves the org and space given with --org/--space or
+// CF_ORG/CF_SPACE and stores them in the in-memory TargetOverlay that
+// NewDependency installs, so the persisted config is left untouched.
+func overrideTarget(deps commandregistry.Dependency, orgName, spaceName string) error {
+	if _, ok := deps.Config.(*coreconfig.TargetOverlay); !ok || !deps.Config.IsLoggedIn() {
+		return nil
+	}
+
+	if orgName != "" {
+		org, err := deps.RepoLocator.GetOrganizationRepository().FindByName(orgName)
+		if err != nil {
+			return errors.New(T("Could not target org.\n{{.APIErr}}",
+				map[string]interface{}{"APIErr": err.Error()}))
+		}
+		deps.Config.SetOrganizationFields(org.OrganizationFields)
+	}
+
+	if spaceName != "" {
+		if !deps.Config.HasOrganization() {
+			return errors.New(T("An org must be targeted before targeting a space"))
+		}
+
+		space, err := deps.RepoLocator.GetSpaceRepository().FindByName(spaceName)
+		if err != nil {
+			return errors.New(T("Unable to access space {{.SpaceName}}.\n{{.APIErr}}",
+				map[string]interface{}{"SpaceName": spaceName, "APIErr": err.Error()}))
+		}
+		deps.Config.SetSpaceFields(space.SpaceFields)
+	}
+
+	return nil
+}
+
 func handleVerbose(args []string) ([]string, bool) {
 	var verbose bool
 	idx := -1
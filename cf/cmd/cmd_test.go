@@ -2,7 +2,10 @@ package cmd_test
 
 import (
 	"bufio"
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -123,6 +126,281 @@ var _ = Describe("main", func() {
 		})
 	})
 
+	Describe("Overriding the targeted org and space", func() {
+		It("accepts --org and --space before the command name", func() {
+			output := Cf("--org", "some-org", "--space=some-space", "version")
+			Consistently(output.Out).ShouldNot(Say("not a registered command"))
+			Eventually(output.Out.Contents).Should(ContainSubstring("cf version"))
+			Eventually(output).Should(Exit(0))
+		})
+
+		It("shows help when only the overrides are given", func() {
+			output := Cf("--org", "some-org")
+			Eventually(output.Out.Contents).Should(ContainSubstring("A command line tool to interact with Cloud Foundry"))
+		})
+
+		It("fails when an override has no value", func() {
+			output := Cf("--org")
+			Eventually(output.Out).Should(Say("Incorrect Usage: --org requires a value"))
+			Eventually(output).Should(Exit(2))
+
+			output = Cf("--org", "some-org", "--space")
+			Eventually(output.Out).Should(Say("Incorrect Usage: --space requires a value"))
+			Eventually(output).Should(Exit(2))
+		})
+	})
+
+	Describe("Structured output", func() {
+		It("accepts --output before the command name and prints messages to stderr", func() {
+			output := Cf("--output", "json", "version")
+			Eventually(output.Err.Contents).Should(ContainSubstring("cf version"))
+			Eventually(output).Should(Exit(0))
+			Expect(output.Out.Contents()).To(BeEmpty())
+		})
+
+		It("accepts --output after the command name", func() {
+			output := Cf("plugins", "--output=yaml")
+			Consistently(output.Out).ShouldNot(Say("Incorrect Usage"))
+			Eventually(output).Should(Exit(0))
+		})
+
+		It("accepts --format and --jsonpath after the command name", func() {
+			output := Cf("plugins", "--format", "{{.plugin_name}}")
+			Consistently(output.Out).ShouldNot(Say("Incorrect Usage"))
+			Eventually(output).Should(Exit(0))
+
+			output = Cf("plugins", "--jsonpath={[0].plugin_name}")
+			Consistently(output.Out).ShouldNot(Say("Incorrect Usage"))
+			Eventually(output).Should(Exit(0))
+		})
+
+		It("fails when more than one output option is given", func() {
+			output := Cf("--output", "json", "--format", "{{.Name}}", "version")
+			Eventually(output.Out).Should(Say("Use only one of --output, --format and --jsonpath"))
+			Eventually(output).Should(Exit(2))
+		})
+
+		It("fails for an unknown format", func() {
+			output := Cf("--output", "xml", "version")
+			Eventually(output.Out).Should(Say("Invalid output format 'xml'"))
+			Eventually(output).Should(Exit(2))
+		})
+
+		It("accepts --sort-by, --filter and --columns before and after the command name", func() {
+			output := Cf("--sort-by", "-plugin_name", "plugins", "--filter", "plugin_name=T*", "--filter=version=N/A", "--columns", "plugin_name")
+			Consistently(output.Out).ShouldNot(Say("Incorrect Usage"))
+			Eventually(output).Should(Exit(0))
+		})
+
+		It("fails for an invalid filter", func() {
+			output := Cf("plugins", "--filter", "started")
+			Eventually(output.Out).Should(Say("Invalid filter 'started'"))
+			Eventually(output).Should(Exit(2))
+		})
+
+		It("fails for output options after a command without structured output", func() {
+			output := Cf("version", "--output", "json")
+			Eventually(output.Out).Should(Say("Incorrect Usage: version does not support the --output option"))
+			Eventually(output).Should(Exit(2))
+
+			output = Cf("version", "--sort-by=name")
+			Eventually(output.Out).Should(Say("Incorrect Usage: version does not support the --sort-by option"))
+			Eventually(output).Should(Exit(2))
+		})
+	})
+
+	Describe("Non-interactive mode", func() {
+		It("accepts --non-interactive before and after the command name", func() {
+			output := Cf("--non-interactive", "version")
+			Eventually(output.Out).Should(Say("cf version"))
+			Eventually(output).Should(Exit(0))
+
+			output = Cf("version", "--non-interactive=true")
+			Consistently(output.Out).ShouldNot(Say("Incorrect Usage"))
+			Eventually(output).Should(Exit(0))
+		})
+	})
+
+	Describe("Exit codes", func() {
+		It("exits 2 when a command is given the wrong arguments", func() {
+			result := Cf("app")
+			Eventually(result).Should(Say("Incorrect Usage"))
+			Eventually(result).Should(Exit(2))
+		})
+
+		It("exits 3 when a command needs a user to be logged in", func() {
+			dir, err := ioutil.TempDir("", "cf-home")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			err = os.MkdirAll(filepath.Join(dir, ".cf"), 0700)
+			Expect(err).NotTo(HaveOccurred())
+			err = ioutil.WriteFile(filepath.Join(dir, ".cf", "config.json"), []byte(`{"ConfigVersion": 3, "Target": "https://api.example.com"}`), 0600)
+			Expect(err).NotTo(HaveOccurred())
+
+			result := CfWith_CF_HOME(dir, "apps")
+			Eventually(result).Should(Say("Not logged in"))
+			Eventually(result).Should(Exit(3))
+		})
+
+		It("exits 2 when a prompt cannot be asked in non-interactive mode", func() {
+			dir, err := ioutil.TempDir("", "cf-home")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			cmd := exec.Command(buildPath, "delete-target", "foo")
+			cmd.Env = []string{"CF_HOME=" + dir, "CF_NON_INTERACTIVE=true"}
+			result, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(result).Should(Say("Cannot prompt for"))
+			Eventually(result).Should(Exit(2))
+		})
+
+		It("exits 4 when there is no API endpoint", func() {
+			dir, err := ioutil.TempDir("", "cf-home")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			result := CfWith_CF_HOME(dir, "apps")
+			Eventually(result).Should(Say("No API endpoint set"))
+			Eventually(result).Should(Exit(4))
+		})
+	})
+
+	Describe("Recording and replaying sessions", func() {
+		var (
+			dir       string
+			cassette  string
+			apiServer *httptest.Server
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "cf-home")
+			Expect(err).NotTo(HaveOccurred())
+			cassette = filepath.Join(dir, "cassette.json")
+
+			apiServer = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				switch request.URL.Path {
+				case "/login":
+					fmt.Fprint(writer, `{"prompts": {"username": ["text", "Email"], "password": ["password", "Password"]}}`)
+				case "/oauth/token":
+					fmt.Fprint(writer, `{"access_token": "my-access-token", "refresh_token": "my-refresh-token", "token_type": "bearer", "expires_in": 600}`)
+				case "/v2/organizations":
+					fmt.Fprint(writer, `{"total_results": 0, "resources": []}`)
+				default:
+					fmt.Fprintf(writer, `{"api_version": "2.54.0", "authorization_endpoint": "http://%s", "token_endpoint": "http://%s"}`, request.Host, request.Host)
+				}
+			}))
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+			os.RemoveAll(dir)
+		})
+
+		cfWithEnv := func(env []string, args ...string) *Session {
+			cmd := exec.Command(buildPath, args...)
+			cmd.Env = append(env, "CF_HOME="+dir)
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			return session
+		}
+
+		It("replays a recorded session without the network", func() {
+			result := cfWithEnv([]string{"CF_RECORD=" + cassette}, "api", apiServer.URL)
+			Eventually(result).Should(Exit(0))
+			Expect(cassette).To(BeARegularFile())
+
+			apiServer.Close()
+
+			result = cfWithEnv([]string{"CF_REPLAY=" + cassette}, "api", apiServer.URL)
+			Eventually(result).Should(Say("API version:"))
+			Eventually(result).Should(Say("2.54.0"))
+			Eventually(result).Should(Exit(0))
+		})
+
+		It("does not change the config when replaying", func() {
+			result := cfWithEnv([]string{"CF_RECORD=" + cassette}, "login", "-a", apiServer.URL, "-u", "user", "-p", "password")
+			Eventually(result).Should(Exit(0))
+
+			configPath := filepath.Join(dir, ".cf", "config.json")
+			recordedConfig, err := ioutil.ReadFile(configPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(recordedConfig)).To(ContainSubstring("my-access-token"))
+
+			apiServer.Close()
+
+			result = cfWithEnv([]string{"CF_REPLAY=" + cassette}, "login", "-a", apiServer.URL, "-u", "user", "-p", "password")
+			Eventually(result).Should(Exit(0))
+
+			replayedConfig, err := ioutil.ReadFile(configPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(replayedConfig)).To(Equal(string(recordedConfig)))
+		})
+
+		It("exits 2 when the cassette to replay cannot be read", func() {
+			result := cfWithEnv([]string{"CF_REPLAY=" + cassette}, "api", apiServer.URL)
+			Eventually(result).Should(Say("no such file or directory"))
+			Eventually(result).Should(Exit(2))
+		})
+	})
+
+	Describe("Tracing as JSON", func() {
+		var (
+			dir       string
+			apiServer *httptest.Server
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "cf-home")
+			Expect(err).NotTo(HaveOccurred())
+
+			apiServer = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				fmt.Fprintf(writer, `{"api_version": "2.54.0", "authorization_endpoint": "http://%s", "token_endpoint": "http://%s"}`, request.Host, request.Host)
+			}))
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+			os.RemoveAll(dir)
+		})
+
+		cfWithEnv := func(env []string, args ...string) *Session {
+			cmd := exec.Command(buildPath, args...)
+			cmd.Env = append(env, "CF_HOME="+dir)
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			return session
+		}
+
+		It("prints a JSON line per request and a summary", func() {
+			result := cfWithEnv([]string{"CF_TRACE=true", "CF_TRACE_FORMAT=json"}, "api", apiServer.URL)
+			Eventually(result).Should(Say(`{"type":"request","time":"[^"]+","method":"GET","url":"%s/v2/info","status":200`, apiServer.URL))
+			Eventually(result).Should(Say(`{"type":"summary","requests":1,`))
+			Eventually(result).Should(Exit(0))
+			Expect(result.Out.Contents()).NotTo(ContainSubstring("REQUEST:"))
+		})
+
+		It("exits 2 for unknown trace formats", func() {
+			result := cfWithEnv([]string{"CF_TRACE_FORMAT=xml"}, "api", apiServer.URL)
+			Eventually(result).Should(Say("Invalid CF_TRACE_FORMAT xml"))
+			Eventually(result).Should(Exit(2))
+		})
+
+		It("exits 2 for retry settings that cannot be parsed", func() {
+			result := cfWithEnv([]string{"CF_RETRY_ATTEMPTS=many"}, "api", apiServer.URL)
+			Eventually(result).Should(Say("Invalid CF_RETRY_ATTEMPTS many"))
+			Eventually(result).Should(Exit(2))
+
+			result = cfWithEnv([]string{"CF_RETRY_BACKOFF=soon"}, "api", apiServer.URL)
+			Eventually(result).Should(Say("Invalid CF_RETRY_BACKOFF soon"))
+			Eventually(result).Should(Exit(2))
+		})
+	})
+
 	Describe("Commands /w new command structure", func() {
 		It("prints usage help for all commands by providing `help` flag", func() {
 			output := Cf("api", "-h")
@@ -148,19 +426,37 @@ var _ = Describe("main", func() {
 
 			Eventually(result.Out).Should(Say("No API endpoint set."))
 		})
+
+		It("refuses to use a config written by a newer CLI", func() {
+			dir, err := os.Getwd()
+			Expect(err).ToNot(HaveOccurred())
+			fullDir := filepath.Join(dir, "..", "..", "fixtures", "config", "versioned-config")
+			configPath := filepath.Join(fullDir, ".cf", "config.json")
+
+			before, err := ioutil.ReadFile(configPath)
+			Expect(err).ToNot(HaveOccurred())
+
+			result := CfWith_CF_HOME(fullDir, "target")
+			Eventually(result.Out).Should(Say("newer version of the CLI"))
+			Eventually(result).Should(Exit(1))
+
+			after, err := ioutil.ReadFile(configPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(after).To(Equal(before))
+		})
 	})
 
 	Describe("exit codes", func() {
-		It("exits non-zero when an unknown command is invoked", func() {
+		It("exits 2 when an unknown command is invoked", func() {
 			result := Cf("some-command-that-should-never-actually-be-a-real-thing-i-can-use")
 
 			Eventually(result, 3*time.Second).Should(Say("not a registered command"))
-			Eventually(result).Should(Exit(1))
+			Eventually(result).Should(Exit(2))
 		})
 
-		It("exits non-zero when known command is invoked with invalid option", func() {
+		It("exits 2 when known command is invoked with invalid option", func() {
 			result := Cf("push", "--crazy")
-			Eventually(result).Should(Exit(1))
+			Eventually(result).Should(Exit(2))
 		})
 	})
 
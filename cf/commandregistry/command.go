@@ -24,4 +24,9 @@ type CommandMetadata struct {
 	TotalArgs       int //Optional: number of required arguments to skip for flag verification
 	Hidden          bool
 	Examples        []string
+
+	// StructuredOutput marks commands that print tables or records, which
+	// the output options --output, --format, --jsonpath, --sort-by, --filter
+	// and --columns apply to.
+	StructuredOutput bool
 }
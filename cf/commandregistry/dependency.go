@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"time"
 
 	"path/filepath"
@@ -52,6 +53,7 @@ type Dependency struct {
 	ChecksumUtil       utils.Sha1Checksum
 	WildcardDependency interface{} //use for injecting fakes
 	Logger             trace.Printer
+	Tracer             *net.JSONTracer
 }
 
 type PluginModels struct {
@@ -70,8 +72,20 @@ type PluginModels struct {
 
 func NewDependency(writer io.Writer, logger trace.Printer, envDialTimeout string) Dependency {
 	deps := Dependency{}
-	deps.TeePrinter = terminal.NewTeePrinter(writer)
-	deps.UI = terminal.NewUI(os.Stdin, writer, deps.TeePrinter, logger)
+
+	// with structured output only the records go to writer, so that they
+	// can be piped to other programs
+	output, _ := terminal.NewOutputOptions(os.Getenv("CF_OUTPUT"), os.Getenv("CF_FORMAT"), os.Getenv("CF_JSONPATH"))
+	output.Table, _ = terminal.NewTableOptions(os.Getenv("CF_SORT_BY"), os.Getenv("CF_FILTER"), os.Getenv("CF_COLUMNS"))
+	if output.Format.IsStructured() {
+		deps.TeePrinter = terminal.NewTeePrinter(os.Stderr)
+	} else {
+		deps.TeePrinter = terminal.NewTeePrinter(writer)
+	}
+	deps.UI = terminal.NewUIWithOutputOptions(os.Stdin, writer, deps.TeePrinter, logger, output)
+	if nonInteractive, _ := strconv.ParseBool(os.Getenv("CF_NON_INTERACTIVE")); nonInteractive {
+		deps.UI = terminal.NewNonInteractiveUI(deps.UI)
+	}
 
 	errorHandler := func(err error) {
 		if err != nil {
@@ -83,7 +97,18 @@ func NewDependency(writer io.Writer, logger trace.Printer, envDialTimeout string
 	if err != nil {
 		errorHandler(err)
 	}
-	deps.Config = coreconfig.NewRepositoryFromFilepath(configPath, errorHandler)
+	// cmd.Main reports cassettes that cannot be read before running commands
+	cassette, _ := net.CassetteFromEnv()
+	if cassette.Replaying() {
+		deps.Config = coreconfig.NewReadOnlyRepositoryFromFilepath(configPath, errorHandler)
+	} else {
+		deps.Config = coreconfig.NewRepositoryFromFilepath(configPath, errorHandler)
+	}
+
+	orgOverride, spaceOverride := os.Getenv("CF_ORG"), os.Getenv("CF_SPACE")
+	if orgOverride != "" || spaceOverride != "" {
+		deps.Config = coreconfig.NewTargetOverlay(deps.Config, orgOverride != "")
+	}
 
 	deps.ManifestRepo = manifest.NewDiskRepository()
 	deps.AppManifest = manifest.NewGenerator()
@@ -103,6 +128,15 @@ func NewDependency(writer io.Writer, logger trace.Printer, envDialTimeout string
 		"uaa":              net.NewUAAGateway(deps.Config, deps.UI, logger, envDialTimeout),
 		"routing-api":      net.NewRoutingAPIGateway(deps.Config, time.Now, deps.UI, logger, envDialTimeout),
 	}
+	// cmd.Main reports retry settings and trace formats that are not valid
+	retryPolicy, _ := net.NewRetryPolicy(os.Getenv("CF_RETRY_ATTEMPTS"), os.Getenv("CF_RETRY_BACKOFF"))
+	deps.Tracer, _ = net.NewTracer(os.Getenv("CF_TRACE_FORMAT"), logger, time.Now)
+	for name, gateway := range deps.Gateways {
+		gateway.RetryPolicy = retryPolicy
+		gateway.Cassette = cassette
+		gateway.Tracer = deps.Tracer
+		deps.Gateways[name] = gateway
+	}
 	deps.RepoLocator = api.NewRepositoryLocator(deps.Config, deps.Gateways, logger)
 
 	deps.PluginModels = &PluginModels{Application: nil}
@@ -0,0 +1,35 @@
+package fakecommand
+
+import (
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/cloudfoundry/cli/cf/requirements"
+)
+
+type FakeCommand4 struct {
+	Data string
+}
+
+func (cmd *FakeCommand4) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "fake-command4",
+		ShortName:   "fc4",
+		Description: "Description for fake-command4",
+		Usage: []string{
+			"CF_NAME Usage of fake-command4",
+		},
+	}
+}
+
+func (cmd *FakeCommand4) Requirements(_ requirements.Factory, _ flags.FlagContext) []requirements.Requirement {
+	return []requirements.Requirement{}
+}
+
+func (cmd *FakeCommand4) SetDependency(deps commandregistry.Dependency, _ bool) commandregistry.Command {
+	cmd.Data, _ = deps.WildcardDependency.(string)
+	return cmd
+}
+
+func (cmd *FakeCommand4) Execute(c flags.FlagContext) error {
+	return nil
+}
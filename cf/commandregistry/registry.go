@@ -3,6 +3,7 @@ package commandregistry
 import (
 	"fmt"
 	"os"
+	"reflect"
 	"strings"
 	"unicode/utf8"
 
@@ -66,6 +67,25 @@ func (r *registry) FindCommand(name string) Command {
 	return nil
 }
 
+// NewCommand returns a copy of the command registered as name, or its alias,
+// with deps set. Commands that run other commands concurrently use copies so
+// that each run has its own dependencies, such as its UI.
+func (r *registry) NewCommand(name string, deps Dependency) Command {
+	cmd := r.FindCommand(name)
+	if cmd == nil {
+		return nil
+	}
+
+	value := reflect.ValueOf(cmd)
+	if value.Kind() == reflect.Ptr {
+		instance := reflect.New(value.Elem().Type())
+		instance.Elem().Set(value.Elem())
+		cmd = instance.Interface().(Command)
+	}
+
+	return cmd.SetDependency(deps, false)
+}
+
 func (r *registry) CommandExists(name string) bool {
 	if strings.TrimSpace(name) == "" {
 		return false
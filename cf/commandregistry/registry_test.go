@@ -98,6 +98,32 @@ var _ = Describe("CommandRegistry", func() {
 		})
 	})
 
+	Describe("NewCommand()", func() {
+		BeforeEach(func() {
+			commandregistry.Register(&FakeCommand4{})
+		})
+
+		AfterEach(func() {
+			commandregistry.Commands.RemoveCommand("fake-command4")
+		})
+
+		It("returns a copy of the command with the dependencies set", func() {
+			deps := commandregistry.Dependency{WildcardDependency: "first"}
+			first := commandregistry.Commands.NewCommand("fake-command4", deps)
+
+			deps.WildcardDependency = "second"
+			second := commandregistry.Commands.NewCommand("fc4", deps)
+
+			Expect(first.(*FakeCommand4).Data).To(Equal("first"))
+			Expect(second.(*FakeCommand4).Data).To(Equal("second"))
+			Expect(commandregistry.Commands.FindCommand("fake-command4").(*FakeCommand4).Data).To(BeEmpty())
+		})
+
+		It("returns nil when the command has not been registered", func() {
+			Expect(commandregistry.Commands.NewCommand("non-exist-cmd", commandregistry.Dependency{})).To(BeNil())
+		})
+	})
+
 	Describe("ShowAllCommands()", func() {
 		BeforeEach(func() {
 			commandregistry.Register(FakeCommand1{})
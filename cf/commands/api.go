@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/cloudfoundry/cli/cf"
@@ -10,6 +11,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/net"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 )
@@ -28,19 +30,46 @@ func (cmd API) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["unset"] = &flags.BoolFlag{Name: "unset", Usage: T("Remove all api endpoint targeting")}
 	fs["skip-ssl-validation"] = &flags.BoolFlag{Name: "skip-ssl-validation", Usage: T("Skip verification of the API endpoint. Not recommended!")}
+	fs["ca-cert"] = &flags.StringFlag{Name: "ca-cert", Usage: T("PEM file of certificate authorities to trust for the API endpoint, in addition to the system's")}
+	fs["client-cert"] = &flags.StringFlag{Name: "client-cert", Usage: T("PEM client certificate to present to the API endpoint, for mutual TLS")}
+	fs["client-key"] = &flags.StringFlag{Name: "client-key", Usage: T("PEM private key of the client certificate")}
+	fs["proxy"] = &flags.StringFlag{Name: "proxy", Usage: T("HTTP or SOCKS5 proxy for all traffic to this target, such as socks5://bastion:1080")}
+	fs["no-proxy"] = &flags.StringFlag{Name: "no-proxy", Usage: T("Comma-separated hosts, domains and CIDR ranges of this target to reach without the proxy")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "api",
 		Description: T("Set or view target api url"),
 		Usage: []string{
-			T("CF_NAME api [URL]"),
+			T("CF_NAME api [URL] [--ca-cert FILE] [--client-cert FILE --client-key FILE] [--proxy URL [--no-proxy HOSTS]]"),
+		},
+		Examples: []string{
+			T("CF_NAME api https://api.example.com --ca-cert internal-ca.pem"),
+			T("CF_NAME api https://api.example.com --client-cert cf.crt --client-key cf.key"),
+			T("CF_NAME api https://api.example.com --proxy socks5://bastion:1080 --no-proxy .internal.example.com"),
 		},
 		Flags: fs,
 	}
 }
 
 func (cmd API) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
-	reqs := []requirements.Requirement{}
+	usageReq := requirements.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+		T("--ca-cert, --client-cert, --client-key and --proxy are given with the URL of the API endpoint"),
+		func() bool {
+			return len(fc.Args()) == 0 && (fc.IsSet("ca-cert") || fc.IsSet("client-cert") || fc.IsSet("client-key") || fc.IsSet("proxy"))
+		},
+	)
+
+	noProxyReq := requirements.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+		T("--no-proxy is given with --proxy"),
+		func() bool {
+			return fc.IsSet("no-proxy") && !fc.IsSet("proxy")
+		},
+	)
+
+	reqs := []requirements.Requirement{
+		usageReq,
+		noProxyReq,
+	}
 	return reqs
 }
 
@@ -55,6 +84,9 @@ func (cmd API) Execute(c flags.FlagContext) error {
 	if c.Bool("unset") {
 		cmd.ui.Say(T("Unsetting api endpoint..."))
 		cmd.config.SetAPIEndpoint("")
+		cmd.config.SetCACertFile("")
+		cmd.config.SetClientCertificate("", "")
+		cmd.config.SetProxy("", "")
 
 		cmd.ui.Ok()
 		cmd.ui.Say(T("\nNo api endpoint set."))
@@ -71,9 +103,22 @@ func (cmd API) Execute(c flags.FlagContext) error {
 	} else {
 		endpoint := c.Args()[0]
 
+		files, err := tlsFiles(c)
+		if err != nil {
+			return err
+		}
+
+		_, err = net.NewProxy(c.String("proxy"), c.String("no-proxy"))
+		if err != nil {
+			return err
+		}
+
 		cmd.ui.Say(T("Setting api endpoint to {{.Endpoint}}...",
 			map[string]interface{}{"Endpoint": terminal.EntityNameColor(endpoint)}))
-		err := cmd.setAPIEndpoint(endpoint, c.Bool("skip-ssl-validation"), cmd.MetaData().Name)
+		cmd.config.SetCACertFile(files.CACert)
+		cmd.config.SetClientCertificate(files.ClientCert, files.ClientKey)
+		cmd.config.SetProxy(c.String("proxy"), c.String("no-proxy"))
+		err = cmd.setAPIEndpoint(endpoint, c.Bool("skip-ssl-validation"), cmd.MetaData().Name)
 		if err != nil {
 			return err
 		}
@@ -102,6 +147,9 @@ func (cmd API) setAPIEndpoint(endpoint string, skipSSL bool, cmdName string) err
 	if err != nil {
 		cmd.config.SetAPIEndpoint("")
 		cmd.config.SetSSLDisabled(false)
+		cmd.config.SetCACertFile("")
+		cmd.config.SetClientCertificate("", "")
+		cmd.config.SetProxy("", "")
 
 		switch typedErr := err.(type) {
 		case *errors.InvalidSSLCert:
@@ -120,3 +168,34 @@ func (cmd API) setAPIEndpoint(endpoint string, skipSSL bool, cmdName string) err
 	}
 	return nil
 }
+
+// tlsFiles returns the absolute paths of the TLS files given to cf api, so
+// that they are found from any directory, once they have been loaded.
+func tlsFiles(c flags.FlagContext) (net.TLSFiles, error) {
+	files := net.TLSFiles{}
+	for _, file := range []struct {
+		flag string
+		path *string
+	}{
+		{"ca-cert", &files.CACert},
+		{"client-cert", &files.ClientCert},
+		{"client-key", &files.ClientKey},
+	} {
+		if c.String(file.flag) == "" {
+			continue
+		}
+
+		path, err := filepath.Abs(c.String(file.flag))
+		if err != nil {
+			return net.TLSFiles{}, err
+		}
+		*file.path = path
+	}
+
+	_, err := net.NewTLSConfigWithFiles(nil, false, files)
+	if err != nil {
+		return net.TLSFiles{}, err
+	}
+
+	return files, nil
+}
@@ -1,13 +1,20 @@
 package commands_test
 
 import (
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 
 	"github.com/cloudfoundry/cli/cf"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/requirements/requirementsfakes"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
 	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
 	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
 	. "github.com/onsi/ginkgo"
@@ -220,4 +227,115 @@ var _ = Describe("Api", func() {
 			})
 		})
 	})
+
+	Describe("TLS files", func() {
+		var (
+			dir        string
+			caCertFile string
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "api-tls-files")
+			Expect(err).NotTo(HaveOccurred())
+
+			server := httptest.NewTLSServer(http.NotFoundHandler())
+			certificate := server.TLS.Certificates[0].Certificate[0]
+			server.Close()
+
+			caCertFile = filepath.Join(dir, "ca.pem")
+			err = ioutil.WriteFile(caCertFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}), 0600)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("stores the absolute path of the CA certificate", func() {
+			wd, err := os.Getwd()
+			Expect(err).NotTo(HaveOccurred())
+			relativePath, err := filepath.Rel(wd, caCertFile)
+			Expect(err).NotTo(HaveOccurred())
+
+			callApi([]string{"https://example.com", "--ca-cert", relativePath})
+			Expect(runCLIErr).NotTo(HaveOccurred())
+			Expect(config.CACertFile()).To(Equal(caCertFile))
+			Expect(config.APIEndpoint()).To(Equal("https://example.com"))
+		})
+
+		It("fails without targeting the endpoint when the CA certificate cannot be read", func() {
+			callApi([]string{"https://example.com", "--ca-cert", filepath.Join(dir, "missing.pem")})
+			Expect(runCLIErr).To(HaveOccurred())
+			Expect(runCLIErr.Error()).To(ContainSubstring("missing.pem"))
+			Expect(endpointRepo.GetCCInfoCallCount()).To(Equal(0))
+			Expect(config.CACertFile()).To(BeEmpty())
+		})
+
+		It("fails when a client certificate is given without its key", func() {
+			callApi([]string{"https://example.com", "--client-cert", caCertFile})
+			Expect(runCLIErr).To(HaveOccurred())
+			Expect(endpointRepo.GetCCInfoCallCount()).To(Equal(0))
+			Expect(config.ClientCertFile()).To(BeEmpty())
+		})
+
+		It("clears the TLS files when the endpoint cannot be targeted", func() {
+			endpointRepo.GetCCInfoReturns(nil, "", errors.New("API endpoint not found"))
+
+			callApi([]string{"https://example.com", "--ca-cert", caCertFile})
+			Expect(runCLIErr).To(HaveOccurred())
+			Expect(config.CACertFile()).To(BeEmpty())
+		})
+
+		It("clears the TLS files with --unset", func() {
+			config.SetCACertFile(caCertFile)
+			config.SetClientCertificate("/path/to/client.crt", "/path/to/client.key")
+
+			callApi([]string{"--unset"})
+			Expect(config.CACertFile()).To(BeEmpty())
+			Expect(config.ClientCertFile()).To(BeEmpty())
+			Expect(config.ClientKeyFile()).To(BeEmpty())
+		})
+
+		It("stores the proxy of the target", func() {
+			callApi([]string{"https://example.com", "--proxy", "socks5://bastion:1080", "--no-proxy", ".internal.example.com"})
+			Expect(runCLIErr).NotTo(HaveOccurred())
+			Expect(config.ProxyURL()).To(Equal("socks5://bastion:1080"))
+			Expect(config.NoProxy()).To(Equal(".internal.example.com"))
+		})
+
+		It("fails without targeting the endpoint when the proxy is invalid", func() {
+			callApi([]string{"https://example.com", "--proxy", "ftp://bastion:21"})
+			Expect(runCLIErr).To(HaveOccurred())
+			Expect(endpointRepo.GetCCInfoCallCount()).To(Equal(0))
+			Expect(config.ProxyURL()).To(BeEmpty())
+		})
+
+		It("clears the proxy with --unset", func() {
+			config.SetProxy("socks5://bastion:1080", "")
+
+			callApi([]string{"--unset"})
+			Expect(config.ProxyURL()).To(BeEmpty())
+		})
+
+		It("requires --proxy with --no-proxy", func() {
+			err := flagContext.Parse("https://example.com", "--no-proxy", "localhost")
+			Expect(err).NotTo(HaveOccurred())
+
+			reqs := cmd.Requirements(requirementsFactory, flagContext)
+			err = testcmd.RunRequirements(reqs)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Incorrect Usage"))
+		})
+
+		It("requires a URL with the TLS flags", func() {
+			err := flagContext.Parse("--ca-cert", caCertFile)
+			Expect(err).NotTo(HaveOccurred())
+
+			reqs := cmd.Requirements(requirementsFactory, flagContext)
+			err = testcmd.RunRequirements(reqs)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Incorrect Usage"))
+		})
+	})
 })
@@ -16,6 +16,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/formatters"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/records"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/cf/uihelpers"
@@ -47,8 +48,9 @@ func (cmd *ShowApp) MetaData() commandregistry.CommandMetadata {
 	fs["guid"] = &flags.BoolFlag{Name: "guid", Usage: T("Retrieve and display the given app's guid.  All other health and status output for the app is suppressed.")}
 
 	return commandregistry.CommandMetadata{
-		Name:        "app",
-		Description: T("Display health and status for app"),
+		Name:             "app",
+		StructuredOutput: true,
+		Description:      T("Display health and status for app"),
 		Usage: []string{
 			T("CF_NAME app APP_NAME"),
 		},
@@ -135,6 +137,23 @@ func (cmd *ShowApp) ShowApp(app models.Application, orgName, spaceName string) e
 	}
 
 	cmd.ui.Ok()
+
+	if cmd.ui.OutputFormat().IsStructured() {
+		var stack string
+		appStack, err := cmd.stackRepo.FindByGUID(application.ApplicationFields.StackGUID)
+		if err == nil {
+			stack = appStack.Name
+		}
+		if appIsStopped {
+			instances = nil
+		}
+
+		// the summary does not include the buildpack
+		application.Buildpack = app.Buildpack
+		application.DetectedBuildpack = app.DetectedBuildpack
+		return cmd.ui.Output(records.NewAppDetail(application, stack, instances))
+	}
+
 	cmd.ui.Say("\n%s %s", terminal.HeaderColor(T("requested state:")), uihelpers.ColoredAppState(application.ApplicationFields))
 	cmd.ui.Say("%s %s", terminal.HeaderColor(T("instances:")), uihelpers.ColoredAppInstances(application.ApplicationFields))
 
@@ -3,19 +3,13 @@ package applicationfakes
 import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/flags"
-	"github.com/cloudfoundry/cli/cf/models"
 	"github.com/cloudfoundry/cli/cf/requirements"
 )
 
+// FakeAppDisplayer is an "app" command that records its calls in the
+// embedded FakeDisplayer, which copies of the command share.
 type FakeAppDisplayer struct {
-	AppToDisplay models.Application
-	OrgName      string
-	SpaceName    string
-}
-
-func (displayer *FakeAppDisplayer) ShowApp(app models.Application, orgName, spaceName string) error {
-	displayer.AppToDisplay = app
-	return nil
+	*FakeDisplayer
 }
 
 func (displayer *FakeAppDisplayer) MetaData() commandregistry.CommandMetadata {
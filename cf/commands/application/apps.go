@@ -13,6 +13,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/api"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/formatters"
+	"github.com/cloudfoundry/cli/cf/records"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/cf/uihelpers"
@@ -33,9 +34,10 @@ func init() {
 
 func (cmd *ListApps) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "apps",
-		ShortName:   "a",
-		Description: T("List all apps in the target space"),
+		Name:             "apps",
+		StructuredOutput: true,
+		ShortName:        "a",
+		Description:      T("List all apps in the target space"),
 		Usage: []string{
 			"CF_NAME apps",
 		},
@@ -84,6 +86,10 @@ func (cmd *ListApps) Execute(c flags.FlagContext) error {
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
+	if cmd.ui.OutputFormat().IsStructured() && !cmd.pluginCall {
+		return cmd.ui.Output(records.NewApps(apps))
+	}
+
 	if len(apps) == 0 {
 		cmd.ui.Say(T("No apps found"))
 		return nil
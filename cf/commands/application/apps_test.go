@@ -7,6 +7,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/models"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/requirements/requirementsfakes"
+	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/cf/trace/tracefakes"
 	"github.com/cloudfoundry/cli/plugin/models"
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
@@ -236,5 +237,62 @@ var _ = Describe("list-apps command", func() {
 				))
 			})
 		})
+
+		Context("when the output format is json", func() {
+			BeforeEach(func() {
+				ui.Format = terminal.JSONOutput
+			})
+
+			It("prints a record for each app instead of the table", func() {
+				runCommand()
+
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"requested state"}))
+				Expect(ui.StructuredOutput()).To(MatchJSON(`[
+					{
+						"name": "Application-1",
+						"guid": "Application-1-guid",
+						"state": "started",
+						"instances": 1,
+						"running_instances": 1,
+						"memory": 512,
+						"disk": 1024,
+						"urls": ["app1.cfapps.io", "app1.example.com"]
+					},
+					{
+						"name": "Application-2",
+						"guid": "Application-2-guid",
+						"state": "started",
+						"instances": 2,
+						"running_instances": 1,
+						"memory": 256,
+						"disk": 1024,
+						"urls": ["app2.cfapps.io"]
+					}
+				]`))
+			})
+
+			It("prints the fields chosen with a template", func() {
+				ui.Format = ""
+				ui.Template = "{{.Name}} {{.State}}"
+				runCommand()
+
+				Expect(ui.StructuredOutput()).To(Equal("Application-1 started\nApplication-2 started\n"))
+			})
+
+			It("prints the fields chosen with a JSONPath template", func() {
+				ui.Format = ""
+				ui.JSONPath = "{[*].urls[0]}"
+				runCommand()
+
+				Expect(ui.StructuredOutput()).To(Equal("app1.cfapps.io app2.cfapps.io\n"))
+			})
+
+			It("prints an empty list when there are no apps", func() {
+				appSummaryRepo.GetSummariesInCurrentSpaceApps = []models.Application{}
+
+				runCommand()
+				Expect(ui.StructuredOutput()).To(MatchJSON(`[]`))
+			})
+		})
 	})
 })
@@ -1,12 +1,12 @@
 package application
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/cloudfoundry/cli/cf/api/applications"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
@@ -72,7 +72,7 @@ func (cmd *EnableSSH) Execute(fc flags.FlagContext) error {
 	enable := true
 	updatedApp, err := cmd.appRepo.Update(app.GUID, models.AppParams{EnableSSH: &enable})
 	if err != nil {
-		return errors.New(T("Error enabling ssh support for ") + app.Name + ": " + err.Error())
+		return errors.NewWrappedError(T("Error enabling ssh support for ")+app.Name+": "+err.Error(), err)
 	}
 
 	if updatedApp.EnableSSH {
@@ -1,11 +1,10 @@
 package application
 
 import (
-	"errors"
-
 	"github.com/cloudfoundry/cli/cf/api/appevents"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/requirements"
@@ -25,8 +24,9 @@ func init() {
 
 func (cmd *Events) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "events",
-		Description: T("Show recent app events"),
+		Name:             "events",
+		StructuredOutput: true,
+		Description:      T("Show recent app events"),
 		Usage: []string{
 			"CF_NAME events ",
 			T("APP_NAME"),
@@ -71,8 +71,8 @@ func (cmd *Events) Execute(c flags.FlagContext) error {
 
 	events, err := cmd.eventsRepo.RecentEvents(app.GUID, 50)
 	if err != nil {
-		return errors.New(T("Failed fetching events.\n{{.APIErr}}",
-			map[string]interface{}{"APIErr": err.Error()}))
+		return errors.NewWrappedError(T("Failed fetching events.\n{{.APIErr}}",
+			map[string]interface{}{"APIErr": err.Error()}), err)
 	}
 
 	for _, event := range events {
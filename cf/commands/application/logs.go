@@ -128,7 +128,7 @@ func (cmd *Logs) handleError(err error) error {
 	switch err.(type) {
 	case nil:
 	case *errors.InvalidSSLCert:
-		return errors.New(err.Error() + T("\nTIP: use 'cf login -a API --skip-ssl-validation' or 'cf api API --skip-ssl-validation' to suppress this error"))
+		return errors.NewWrappedError(err.Error()+T("\nTIP: use 'cf login -a API --skip-ssl-validation' or 'cf api API --skip-ssl-validation' to suppress this error"), err)
 	default:
 		return err
 	}
@@ -7,13 +7,16 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 
 	"github.com/cloudfoundry/cli/cf"
 	"github.com/cloudfoundry/cli/cf/actors"
+	"github.com/cloudfoundry/cli/cf/actors/servicebuilder"
 	"github.com/cloudfoundry/cli/cf/api"
+	"github.com/cloudfoundry/cli/cf/api/appinstances"
 	"github.com/cloudfoundry/cli/cf/api/applications"
 	"github.com/cloudfoundry/cli/cf/api/authentication"
 	"github.com/cloudfoundry/cli/cf/api/stacks"
@@ -31,23 +34,36 @@ import (
 )
 
 type Push struct {
-	ui            terminal.UI
-	config        coreconfig.Reader
-	manifestRepo  manifest.Repository
-	appStarter    Starter
-	appStopper    Stopper
-	serviceBinder service.Binder
-	appRepo       applications.Repository
-	domainRepo    api.DomainRepository
-	routeRepo     api.RouteRepository
-	serviceRepo   api.ServiceRepository
-	stackRepo     stacks.StackRepository
-	authRepo      authentication.Repository
-	wordGenerator generator.WordGenerator
-	actor         actors.PushActor
-	routeActor    actors.RouteActor
-	zipper        appfiles.Zipper
-	appfiles      appfiles.AppFiles
+	ui                              terminal.UI
+	config                          coreconfig.Reader
+	manifestRepo                    manifest.Repository
+	appStarter                      Starter
+	appStopper                      Stopper
+	serviceBinder                   service.Binder
+	appRepo                         applications.Repository
+	appSummaryRepo                  api.AppSummaryRepository
+	appInstancesRepo                appinstances.Repository
+	domainRepo                      api.DomainRepository
+	routeRepo                       api.RouteRepository
+	serviceRepo                     api.ServiceRepository
+	userProvidedServiceInstanceRepo api.UserProvidedServiceInstanceRepository
+	stackRepo                       stacks.StackRepository
+	authRepo                        authentication.Repository
+	wordGenerator                   generator.WordGenerator
+	actor                           actors.PushActor
+	routeActor                      actors.RouteActor
+	serviceBuilder                  servicebuilder.ServiceBuilder
+	zipper                          appfiles.Zipper
+	appfiles                        appfiles.AppFiles
+	deps                            commandregistry.Dependency
+
+	// StartupTimeout bounds how long a blue-green push waits for all
+	// instances of the new app to run, and ServiceInstanceTimeout how long
+	// push waits for the service instances of the manifest to be created.
+	// PingerThrottle is how often push checks on either.
+	StartupTimeout         time.Duration
+	ServiceInstanceTimeout time.Duration
+	PingerThrottle         time.Duration
 }
 
 func init() {
@@ -70,11 +86,17 @@ func (cmd *Push) MetaData() commandregistry.CommandMetadata {
 	fs["docker-image"] = &flags.StringFlag{Name: "docker-image", ShortName: "o", Usage: T("Docker-image to be used (e.g. user/docker-image-name)")}
 	fs["health-check-type"] = &flags.StringFlag{Name: "health-check-type", ShortName: "u", Usage: T("Application health check type (e.g. 'port' or 'none')")}
 	fs["no-hostname"] = &flags.BoolFlag{Name: "no-hostname", Usage: T("Map the root domain to this app")}
+	fs["dry-run"] = &flags.BoolFlag{Name: "dry-run", Usage: T("Show the changes to apps, routes, services and app files that push would make, without making them")}
 	fs["no-manifest"] = &flags.BoolFlag{Name: "no-manifest", Usage: T("Ignore manifest file")}
 	fs["no-route"] = &flags.BoolFlag{Name: "no-route", Usage: T("Do not map a route to this app and remove routes from previous pushes of this app")}
 	fs["no-start"] = &flags.BoolFlag{Name: "no-start", Usage: T("Do not start an app after pushing")}
+	fs["parallel"] = &flags.IntFlag{Name: "parallel", Usage: T("Number of apps in the manifest to push at the same time. Apps wait for the apps in their depends_on list to be pushed first.")}
 	fs["random-route"] = &flags.BoolFlag{Name: "random-route", Usage: T("Create a random route for this app")}
 	fs["route-path"] = &flags.StringFlag{Name: "route-path", Usage: T("Path for the route")}
+	fs["strategy"] = &flags.StringFlag{Name: "strategy", Usage: T("Deployment strategy. blue-green pushes each existing app as a new app, moves its routes to the new app once all of its instances are running and deletes the old app, rolling back if a step fails.")}
+	fs["overlay"] = &flags.StringSliceFlag{Name: "overlay", Usage: T("Path to an overlay file of operations that replace, remove or append values in the manifest. This flag can be defined more than once; overlays are applied in order.")}
+	fs["var"] = &flags.StringSliceFlag{Name: "var", Usage: T("Variable key value pair for variable substitution in the manifest, (e.g., name=app1). This flag can be defined more than once.")}
+	fs["vars-file"] = &flags.StringSliceFlag{Name: "vars-file", Usage: T("Path to a YAML file of variables to substitute in the manifest. This flag can be defined more than once; later files take precedence.")}
 	// Hidden:true to hide app-ports for release #117189491
 	fs["app-ports"] = &flags.StringFlag{Name: "app-ports", Usage: T("Comma delimited list of ports the application may listen on"), Hidden: true}
 
@@ -101,15 +123,22 @@ func (cmd *Push) MetaData() commandregistry.CommandMetadata {
 			fmt.Sprintf("[-t %s] ", T("TIMEOUT")),
 			fmt.Sprintf("[-u %s] ", T("HEALTH_CHECK_TYPE")),
 			fmt.Sprintf("[--route-path %s] ", T("ROUTE_PATH")),
+			"[--strategy blue-green]",
 			"\n   ",
 			// Commented to hide app-ports for release #117189491
 			// fmt.Sprintf("[--app-ports %s] ", T("APP_PORTS")),
-			"[--no-hostname] [--no-manifest] [--no-route] [--no-start] [--random-route]\n",
+			"[--dry-run] [--no-hostname] [--no-manifest] [--no-route] [--no-start] [--random-route]\n",
 			"\n   ",
 			T("Push multiple apps with a manifest"),
 			":\n   ",
 			"CF_NAME push ",
 			fmt.Sprintf("[-f %s] ", T("MANIFEST_PATH")),
+			fmt.Sprintf("[--overlay %s] ", T("OVERLAY_PATH")),
+			fmt.Sprintf("[--vars-file %s] ", T("VARS_FILE_PATH")),
+			fmt.Sprintf("[--var %s=%s] ", T("KEY"), T("VALUE")),
+			"[--dry-run] ",
+			"[--parallel N] ",
+			"[--strategy blue-green]",
 		},
 		Flags: fs,
 	}
@@ -126,6 +155,48 @@ func (cmd *Push) Requirements(requirementsFactory requirements.Factory, fc flags
 
 	reqs = append(reqs, usageReq)
 
+	if len(fc.StringSlice("overlay")) > 0 {
+		reqs = append(reqs, requirementsFactory.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+			T("--overlay cannot be used with --no-manifest"),
+			func() bool {
+				return fc.Bool("no-manifest")
+			},
+		))
+	}
+
+	if usesVariables(fc) {
+		reqs = append(reqs, requirementsFactory.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+			T("--var and --vars-file cannot be used with --no-manifest"),
+			func() bool {
+				return fc.Bool("no-manifest")
+			},
+		))
+	}
+
+	if fc.IsSet("strategy") {
+		reqs = append(reqs, requirementsFactory.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+			T("--strategy must be blue-green"),
+			func() bool {
+				return fc.String("strategy") != BlueGreenStrategy
+			},
+		))
+		reqs = append(reqs, requirementsFactory.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+			T("--strategy cannot be used with --no-start or --dry-run"),
+			func() bool {
+				return fc.Bool("no-start") || fc.Bool("dry-run")
+			},
+		))
+	}
+
+	if fc.IsSet("parallel") {
+		reqs = append(reqs, requirementsFactory.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+			T("--parallel must be at least 1"),
+			func() bool {
+				return fc.Int("parallel") < 1
+			},
+		))
+	}
+
 	if fc.String("route-path") != "" {
 		reqs = append(reqs, requirementsFactory.NewMinAPIVersionRequirement("Option '--route-path'", cf.RoutePathMinimumAPIVersion))
 	}
@@ -146,39 +217,54 @@ func (cmd *Push) SetDependency(deps commandregistry.Dependency, pluginCall bool)
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.manifestRepo = deps.ManifestRepo
+	cmd.deps = deps
 
 	//set appStarter
-	appCommand := commandregistry.Commands.FindCommand("start")
-	appCommand = appCommand.SetDependency(deps, false)
+	appCommand := commandregistry.Commands.NewCommand("start", deps)
 	cmd.appStarter = appCommand.(Starter)
 
 	//set appStopper
-	appCommand = commandregistry.Commands.FindCommand("stop")
-	appCommand = appCommand.SetDependency(deps, false)
+	appCommand = commandregistry.Commands.NewCommand("stop", deps)
 	cmd.appStopper = appCommand.(Stopper)
 
 	//set serviceBinder
-	appCommand = commandregistry.Commands.FindCommand("bind-service")
-	appCommand = appCommand.SetDependency(deps, false)
+	appCommand = commandregistry.Commands.NewCommand("bind-service", deps)
 	cmd.serviceBinder = appCommand.(service.Binder)
 
 	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
+	cmd.appSummaryRepo = deps.RepoLocator.GetAppSummaryRepository()
+	cmd.appInstancesRepo = deps.RepoLocator.GetAppInstancesRepository()
 	cmd.domainRepo = deps.RepoLocator.GetDomainRepository()
 	cmd.routeRepo = deps.RepoLocator.GetRouteRepository()
 	cmd.serviceRepo = deps.RepoLocator.GetServiceRepository()
+	cmd.userProvidedServiceInstanceRepo = deps.RepoLocator.GetUserProvidedServiceInstanceRepository()
 	cmd.stackRepo = deps.RepoLocator.GetStackRepository()
 	cmd.authRepo = deps.RepoLocator.GetAuthenticationRepository()
 	cmd.wordGenerator = deps.WordGenerator
 	cmd.actor = deps.PushActor
 	cmd.routeActor = deps.RouteActor
+	cmd.serviceBuilder = deps.ServiceBuilder
 	cmd.zipper = deps.AppZipper
 	cmd.appfiles = deps.AppFiles
 
+	if cmd.StartupTimeout == 0 {
+		cmd.StartupTimeout = DefaultStartupTimeout
+	}
+	if cmd.ServiceInstanceTimeout == 0 {
+		cmd.ServiceInstanceTimeout = DefaultServiceInstanceTimeout
+		if cmd.config.AsyncTimeout() > 0 {
+			cmd.ServiceInstanceTimeout = time.Duration(cmd.config.AsyncTimeout()) * time.Minute
+		}
+	}
+	if cmd.PingerThrottle == 0 {
+		cmd.PingerThrottle = DefaultPingerThrottle
+	}
+
 	return cmd
 }
 
 func (cmd *Push) Execute(c flags.FlagContext) error {
-	appsFromManifest, err := cmd.getAppParamsFromManifest(c)
+	appsFromManifest, serviceInstances, err := cmd.getAppParamsFromManifest(c)
 	if err != nil {
 		return err
 	}
@@ -204,105 +290,150 @@ func (cmd *Push) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	appSet, err = orderByDependencies(appSet, appsFromManifest)
+	if err != nil {
+		return err
+	}
+
 	_, err = cmd.authRepo.RefreshAuthToken()
 	if err != nil {
 		return err
 	}
 
-	for _, appParams := range appSet {
-		if appParams.Name == nil {
-			return errors.New(T("Error: No name found for app"))
-		}
+	if c.Bool("dry-run") {
+		return cmd.planPush(appSet, appFromContext, serviceInstances, c.Bool("no-start"))
+	}
 
-		err := cmd.fetchStackGUID(&appParams)
+	err = cmd.ensureServiceInstances(serviceInstances)
+	if err != nil {
+		return err
+	}
+
+	if c.Int("parallel") > 1 && len(appSet) > 1 {
+		return cmd.pushInParallel(appSet, appFromContext, c)
+	}
+
+	for _, appParams := range appSet {
+		err = cmd.pushApp(appParams, appFromContext, c)
 		if err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		if c.IsSet("docker-image") {
-			diego := true
-			appParams.Diego = &diego
-		}
+func (cmd *Push) pushApp(appParams models.AppParams, appFromContext models.AppParams, c flags.FlagContext) error {
+	if appParams.Name == nil {
+		return errors.New(T("Error: No name found for app"))
+	}
 
-		var app models.Application
-		existingApp, err := cmd.appRepo.Read(*appParams.Name)
-		switch err.(type) {
-		case nil:
-			cmd.ui.Say(T("Updating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
-				map[string]interface{}{
-					"AppName":   terminal.EntityNameColor(existingApp.Name),
-					"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
-					"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
-					"Username":  terminal.EntityNameColor(cmd.config.Username())}))
-
-			if appParams.EnvironmentVars != nil {
-				for key, val := range existingApp.EnvironmentVars {
-					if _, ok := (*appParams.EnvironmentVars)[key]; !ok {
-						(*appParams.EnvironmentVars)[key] = val
-					}
-				}
-			}
+	if c.String("strategy") == BlueGreenStrategy {
+		return cmd.pushAppBlueGreen(appParams, appFromContext, c)
+	}
 
-			app, err = cmd.appRepo.Update(existingApp.GUID, appParams)
-			if err != nil {
-				return err
-			}
-		case *errors.ModelNotFoundError:
-			spaceGUID := cmd.config.SpaceFields().GUID
-			appParams.SpaceGUID = &spaceGUID
+	app, err := cmd.createOrUpdateApp(appParams)
+	if err != nil {
+		return err
+	}
 
-			cmd.ui.Say(T("Creating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
-				map[string]interface{}{
-					"AppName":   terminal.EntityNameColor(*appParams.Name),
-					"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
-					"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
-					"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+	err = cmd.updateRoutes(app, appParams, appFromContext)
+	if err != nil {
+		return err
+	}
 
-			app, err = cmd.appRepo.Create(appParams)
-			if err != nil {
-				return err
+	return cmd.deployApp(app, appParams, c)
+}
+
+// createOrUpdateApp creates the app, or updates it when it exists, with
+// appParams.
+func (cmd *Push) createOrUpdateApp(appParams models.AppParams) (models.Application, error) {
+	err := cmd.fetchStackGUID(&appParams)
+	if err != nil {
+		return models.Application{}, err
+	}
+
+	if appParams.DockerImage != nil {
+		diego := true
+		appParams.Diego = &diego
+	}
+
+	var app models.Application
+	existingApp, err := cmd.appRepo.Read(*appParams.Name)
+	switch err.(type) {
+	case nil:
+		cmd.ui.Say(T("Updating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+			map[string]interface{}{
+				"AppName":   terminal.EntityNameColor(existingApp.Name),
+				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+		if appParams.EnvironmentVars != nil {
+			for key, val := range existingApp.EnvironmentVars {
+				if _, ok := (*appParams.EnvironmentVars)[key]; !ok {
+					(*appParams.EnvironmentVars)[key] = val
+				}
 			}
-		default:
-			return err
 		}
 
-		cmd.ui.Ok()
-		cmd.ui.Say("")
-
-		err = cmd.updateRoutes(app, appParams, appFromContext)
+		app, err = cmd.appRepo.Update(existingApp.GUID, appParams)
 		if err != nil {
-			return err
+			return models.Application{}, err
 		}
+	case *errors.ModelNotFoundError:
+		spaceGUID := cmd.config.SpaceFields().GUID
+		appParams.SpaceGUID = &spaceGUID
 
-		if c.String("docker-image") == "" {
-			err = cmd.actor.ProcessPath(*appParams.Path, cmd.processPathCallback(*appParams.Path, app))
-			if err != nil {
-				return errors.New(
-					T("Error processing app files: {{.Error}}",
-						map[string]interface{}{
-							"Error": err.Error(),
-						}),
-				)
-			}
-		}
+		cmd.ui.Say(T("Creating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+			map[string]interface{}{
+				"AppName":   terminal.EntityNameColor(*appParams.Name),
+				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"Username":  terminal.EntityNameColor(cmd.config.Username())}))
 
-		if appParams.ServicesToBind != nil {
-			err := cmd.bindAppToServices(appParams.ServicesToBind, app)
-			if err != nil {
-				return err
-			}
+		app, err = cmd.appRepo.Create(appParams)
+		if err != nil {
+			return models.Application{}, err
 		}
+	default:
+		return models.Application{}, err
+	}
 
-		err = cmd.restart(app, appParams, c)
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	return app, nil
+}
+
+// deployApp uploads the files of the app, binds its services and starts it
+// unless --no-start is given.
+func (cmd *Push) deployApp(app models.Application, appParams models.AppParams, c flags.FlagContext) error {
+	if appParams.DockerImage == nil {
+		err := cmd.actor.ProcessPath(*appParams.Path, cmd.processPathCallback(*appParams.Path, app))
 		if err != nil {
-			return errors.New(
-				T("Error restarting application: {{.Error}}",
+			return errors.NewWrappedError(
+				T("Error processing app files: {{.Error}}",
 					map[string]interface{}{
 						"Error": err.Error(),
-					}),
-			)
+					}), err)
+		}
+	}
+
+	if appParams.ServicesToBind != nil {
+		err := cmd.bindAppToServices(appParams.ServicesToBind, app)
+		if err != nil {
+			return err
 		}
 	}
+
+	err := cmd.restart(app, appParams, c)
+	if err != nil {
+		return errors.NewWrappedError(
+			T("Error restarting application: {{.Error}}",
+				map[string]interface{}{
+					"Error": err.Error(),
+				}), err)
+	}
 	return nil
 }
 
@@ -310,12 +441,12 @@ func (cmd *Push) processPathCallback(path string, app models.Application) func(s
 	return func(appDir string) error {
 		localFiles, err := cmd.appfiles.AppFilesInDir(appDir)
 		if err != nil {
-			return errors.New(
+			return errors.NewWrappedError(
 				T("Error processing app files in '{{.Path}}': {{.Error}}",
 					map[string]interface{}{
 						"Path":  path,
 						"Error": err.Error(),
-					}))
+					}), err)
 		}
 
 		if len(localFiles) == 0 {
@@ -331,8 +462,8 @@ func (cmd *Push) processPathCallback(path string, app models.Application) func(s
 
 		err = cmd.uploadApp(app.GUID, appDir, path, localFiles)
 		if err != nil {
-			return errors.New(T("Error uploading application.\n{{.APIErr}}",
-				map[string]interface{}{"APIErr": err.Error()}))
+			return errors.NewWrappedError(T("Error uploading application.\n{{.APIErr}}",
+				map[string]interface{}{"APIErr": err.Error()}), err)
 		}
 		cmd.ui.Ok()
 		return nil
@@ -512,8 +643,8 @@ func (cmd *Push) bindAppToServices(services []string, app models.Application) er
 		}
 
 		if err != nil {
-			return errors.New(T("Could not bind to service {{.ServiceName}}\nError: {{.Err}}",
-				map[string]interface{}{"ServiceName": serviceName, "Err": err.Error()}))
+			return errors.NewWrappedError(T("Could not bind to service {{.ServiceName}}\nError: {{.Err}}",
+				map[string]interface{}{"ServiceName": serviceName, "Err": err.Error()}), err)
 		}
 
 		cmd.ui.Ok()
@@ -540,6 +671,58 @@ func (cmd *Push) fetchStackGUID(appParams *models.AppParams) error {
 	return nil
 }
 
+// waitForAllInstances waits until all instances of app run, since start
+// returns as soon as one of them does, and a blue-green push must not move
+// the routes to an app that serves only part of the traffic.
+func (cmd *Push) waitForAllInstances(app models.Application, params models.AppParams) error {
+	timeout := cmd.StartupTimeout
+	if params.HealthCheckTimeout != nil {
+		timeout = time.Duration(*params.HealthCheckTimeout) * time.Second
+	}
+	instanceCount := app.InstanceCount
+	if params.InstanceCount != nil {
+		instanceCount = *params.InstanceCount
+	}
+
+	cmd.ui.Say(T("Waiting for all instances of {{.AppName}} to run...",
+		map[string]interface{}{"AppName": terminal.EntityNameColor(app.Name)}))
+
+	deadline := time.Now().Add(timeout)
+	for {
+		instances, err := cmd.appInstancesRepo.GetInstances(app.GUID)
+		if err != nil {
+			return err
+		}
+
+		running := 0
+		for _, instance := range instances {
+			switch instance.State {
+			case models.InstanceRunning:
+				running++
+			case models.InstanceCrashed, models.InstanceFlapping:
+				return errors.New(T("An instance of {{.AppName}} crashed", map[string]interface{}{"AppName": app.Name}))
+			}
+		}
+
+		if running >= instanceCount {
+			cmd.ui.Ok()
+			cmd.ui.Say("")
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return errors.New(T("Only {{.RunningCount}} of {{.InstanceCount}} instances of {{.AppName}} were running after {{.Timeout}}",
+				map[string]interface{}{
+					"RunningCount":  running,
+					"InstanceCount": instanceCount,
+					"AppName":       app.Name,
+					"Timeout":       timeout}))
+		}
+
+		time.Sleep(cmd.PingerThrottle)
+	}
+}
+
 func (cmd *Push) restart(app models.Application, params models.AppParams, c flags.FlagContext) error {
 	if app.State != T("stopped") {
 		cmd.ui.Say("")
@@ -564,9 +747,9 @@ func (cmd *Push) restart(app models.Application, params models.AppParams, c flag
 	return nil
 }
 
-func (cmd *Push) getAppParamsFromManifest(c flags.FlagContext) ([]models.AppParams, error) {
+func (cmd *Push) getAppParamsFromManifest(c flags.FlagContext) ([]models.AppParams, []models.ServiceInstanceParams, error) {
 	if c.Bool("no-manifest") {
-		return []models.AppParams{}, nil
+		return []models.AppParams{}, nil, nil
 	}
 
 	var path string
@@ -576,27 +759,91 @@ func (cmd *Push) getAppParamsFromManifest(c flags.FlagContext) ([]models.AppPara
 		var err error
 		path, err = os.Getwd()
 		if err != nil {
-			return nil, errors.New(fmt.Sprint(T("Could not determine the current working directory!"), err))
+			return nil, nil, errors.New(fmt.Sprint(T("Could not determine the current working directory!"), err))
+		}
+	}
+
+	vars, err := manifestVariables(c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var overlays []*manifest.Overlay
+	for _, overlayPath := range c.StringSlice("overlay") {
+		overlay, err := manifest.ReadOverlay(overlayPath)
+		if err != nil {
+			return nil, nil, err
 		}
+		overlays = append(overlays, overlay)
 	}
 
 	m, err := cmd.manifestRepo.ReadManifest(path)
 
 	if err != nil {
-		if m.Path == "" && c.String("f") == "" {
-			return []models.AppParams{}, nil
+		if m.Path == "" && c.String("f") == "" && len(overlays) == 0 && !usesVariables(c) {
+			return []models.AppParams{}, nil, nil
 		}
-		return nil, errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+		return nil, nil, errors.NewWrappedError(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}), err)
+	}
+
+	// push ignores unknown keys, so that manifests can be shared with
+	// newer versions of cf, but warns about them in case they are typos
+	if validationErrs, ok := cmd.manifestRepo.ValidateManifest(m.Path).(manifest.ValidationErrors); ok {
+		cmd.ui.Warn("%s", T("The manifest has problems that push may ignore:\n{{.Errors}}\n",
+			map[string]interface{}{"Errors": validationErrs.Error()}))
+	}
+
+	for _, overlay := range overlays {
+		err = m.ApplyOverlay(overlay)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	err = m.Interpolate(vars)
+	if err != nil {
+		return nil, nil, errors.NewWrappedError(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}), err)
 	}
 
 	apps, err := m.Applications()
 	if err != nil {
-		return nil, errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+		return nil, nil, errors.NewWrappedError(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}), err)
+	}
+
+	serviceInstances, err := m.ServiceInstances()
+	if err != nil {
+		return nil, nil, errors.NewWrappedError(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}), err)
 	}
 
 	cmd.ui.Say(T("Using manifest file {{.Path}}\n",
 		map[string]interface{}{"Path": terminal.EntityNameColor(m.Path)}))
-	return apps, nil
+	return apps, serviceInstances, nil
+}
+
+// manifestVariables reads the --vars-file files in order, then sets the
+// --var variables, which take precedence.
+func usesVariables(c flags.FlagContext) bool {
+	return len(c.StringSlice("var")) > 0 || len(c.StringSlice("vars-file")) > 0
+}
+
+func manifestVariables(c flags.FlagContext) (manifest.Variables, error) {
+	vars := manifest.NewVariables()
+
+	for _, path := range c.StringSlice("vars-file") {
+		err := vars.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, assignment := range c.StringSlice("var") {
+		err := vars.Set(assignment)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return vars, nil
 }
 
 func (cmd *Push) createAppSetFromContextAndManifest(contextApp models.AppParams, manifestApps []models.AppParams) ([]models.AppParams, error) {
@@ -644,7 +891,7 @@ func (cmd *Push) createAppSetFromContextAndManifest(contextApp models.AppParams,
 	}
 
 	if err != nil {
-		return nil, errors.New(T("Error: {{.Err}}", map[string]interface{}{"Err": err.Error()}))
+		return nil, errors.NewWrappedError(T("Error: {{.Err}}", map[string]interface{}{"Err": err.Error()}), err)
 	}
 
 	return apps, nil
@@ -816,7 +1063,7 @@ func (cmd *Push) uploadApp(appGUID, appDir, appDirOrZipFile string, localFiles [
 			if emptyDirErr, ok := err.(*errors.EmptyDirError); ok {
 				return emptyDirErr
 			}
-			return fmt.Errorf("%s: %s", T("Error zipping application"), err.Error())
+			return errors.NewWrappedError(fmt.Sprintf("%s: %s", T("Error zipping application"), err.Error()), err)
 		}
 
 		var zipFileSize int64
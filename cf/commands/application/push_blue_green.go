@@ -0,0 +1,250 @@
+package application
+
+import (
+	"github.com/cloudfoundry/cli/cf/flags"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+
+	"github.com/cloudfoundry/cli/cf/errors"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/terminal"
+)
+
+// BlueGreenStrategy is the --strategy that replaces running apps without
+// downtime.
+const BlueGreenStrategy = "blue-green"
+
+// pushAppBlueGreen pushes the app as a new app next to the running one and,
+// once all of its instances are running, moves the routes of the running
+// app to it, gives it the name of the app and deletes the old app. When a
+// step fails, the steps before it are undone so that the running app keeps
+// serving its routes. Apps that do not exist yet are pushed in place.
+func (cmd *Push) pushAppBlueGreen(appParams models.AppParams, appFromContext models.AppParams, c flags.FlagContext) error {
+	appName := *appParams.Name
+	existingApp, err := cmd.appRepo.Read(appName)
+	switch err.(type) {
+	case nil:
+	case *errors.ModelNotFoundError:
+		app, err := cmd.createOrUpdateApp(appParams)
+		if err != nil {
+			return err
+		}
+
+		err = cmd.updateRoutes(app, appParams, appFromContext)
+		if err != nil {
+			return err
+		}
+
+		return cmd.deployApp(app, appParams, c)
+	default:
+		return err
+	}
+
+	newName := appName + "-new"
+	oldName := appName + "-old"
+	for _, name := range []string{newName, oldName} {
+		_, err = cmd.appRepo.Read(name)
+		if err == nil {
+			return errors.New(T("Cannot push {{.AppName}} with the blue-green strategy because app {{.TempAppName}} exists. Rename or delete it and push again.",
+				map[string]interface{}{"AppName": appName, "TempAppName": name}))
+		}
+		if _, ok := err.(*errors.ModelNotFoundError); !ok {
+			return err
+		}
+	}
+
+	newParams, err := cmd.inheritAppSettings(appParams, existingApp)
+	if err != nil {
+		return err
+	}
+	newParams.Name = &newName
+
+	cmd.ui.Say(T("Pushing app {{.AppName}} as {{.NewAppName}} to replace it without downtime...\n",
+		map[string]interface{}{
+			"AppName":    terminal.EntityNameColor(appName),
+			"NewAppName": terminal.EntityNameColor(newName)}))
+
+	newApp, err := cmd.createOrUpdateApp(newParams)
+	if err != nil {
+		return err
+	}
+
+	var undo []func() error
+	rollBack := func(err error) error {
+		cmd.ui.Say(T("Rolling back the push of {{.AppName}}...",
+			map[string]interface{}{"AppName": terminal.EntityNameColor(appName)}))
+
+		for i := len(undo) - 1; i >= 0; i-- {
+			undoErr := undo[i]()
+			if undoErr != nil {
+				cmd.ui.Warn("%s", T("Could not roll back: {{.Err}}", map[string]interface{}{"Err": undoErr.Error()}))
+			}
+		}
+
+		return errors.NewWrappedError(T("Error pushing {{.AppName}} with the blue-green strategy, the running app was kept: {{.Err}}",
+			map[string]interface{}{"AppName": appName, "Err": err.Error()}), err)
+	}
+
+	undo = append(undo, func() error {
+		return cmd.appRepo.Delete(newApp.GUID)
+	})
+
+	err = cmd.deployApp(newApp, newParams, c)
+	if err != nil {
+		return rollBack(err)
+	}
+
+	err = cmd.waitForAllInstances(newApp, newParams)
+	if err != nil {
+		return rollBack(err)
+	}
+
+	if !appParams.NoRoute {
+		cmd.ui.Say(T("Mapping the routes of {{.AppName}} to {{.NewAppName}}...",
+			map[string]interface{}{
+				"AppName":    terminal.EntityNameColor(appName),
+				"NewAppName": terminal.EntityNameColor(newName)}))
+
+		for _, route := range existingApp.Routes {
+			err = cmd.routeRepo.Bind(route.GUID, newApp.GUID)
+			if err != nil {
+				return rollBack(err)
+			}
+		}
+
+		cmd.ui.Ok()
+		cmd.ui.Say("")
+
+		newApp.Routes = existingApp.Routes
+		err = cmd.updateRoutes(newApp, appParams, appFromContext)
+		if err != nil {
+			return rollBack(err)
+		}
+	}
+
+	cmd.ui.Say(T("Unmapping routes from {{.AppName}}...",
+		map[string]interface{}{"AppName": terminal.EntityNameColor(appName)}))
+
+	for _, route := range existingApp.Routes {
+		err = cmd.routeRepo.Unbind(route.GUID, existingApp.GUID)
+		if err != nil {
+			return rollBack(err)
+		}
+
+		routeGUID := route.GUID
+		undo = append(undo, func() error {
+			return cmd.routeRepo.Bind(routeGUID, existingApp.GUID)
+		})
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	cmd.ui.Say(T("Renaming app {{.AppName}} to {{.OldAppName}} and {{.NewAppName}} to {{.AppName}}...",
+		map[string]interface{}{
+			"AppName":    terminal.EntityNameColor(appName),
+			"OldAppName": terminal.EntityNameColor(oldName),
+			"NewAppName": terminal.EntityNameColor(newName)}))
+
+	_, err = cmd.appRepo.Update(existingApp.GUID, models.AppParams{Name: &oldName})
+	if err != nil {
+		return rollBack(err)
+	}
+
+	undo = append(undo, func() error {
+		_, err := cmd.appRepo.Update(existingApp.GUID, models.AppParams{Name: &appName})
+		return err
+	})
+
+	_, err = cmd.appRepo.Update(newApp.GUID, models.AppParams{Name: &appName})
+	if err != nil {
+		return rollBack(err)
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	cmd.ui.Say(T("Deleting app {{.OldAppName}}...",
+		map[string]interface{}{"OldAppName": terminal.EntityNameColor(oldName)}))
+
+	err = cmd.appRepo.Delete(existingApp.GUID)
+	if err != nil {
+		cmd.ui.Warn("%s", T("Could not delete app {{.OldAppName}}: {{.Err}}\nDelete it with 'cf delete {{.OldAppName}}'.",
+			map[string]interface{}{"OldAppName": oldName, "Err": err.Error()}))
+		return nil
+	}
+
+	cmd.ui.Ok()
+	return nil
+}
+
+// inheritAppSettings returns appParams with the settings of the running
+// app that it does not change, such as its instances, memory, environment
+// variables and services, as a push would keep them when updating the
+// app.
+func (cmd *Push) inheritAppSettings(appParams models.AppParams, app models.Application) (models.AppParams, error) {
+	if appParams.InstanceCount == nil {
+		appParams.InstanceCount = &app.InstanceCount
+	}
+	if appParams.Memory == nil {
+		appParams.Memory = &app.Memory
+	}
+	if appParams.DiskQuota == nil {
+		appParams.DiskQuota = &app.DiskQuota
+	}
+	if appParams.BuildpackURL == nil && app.BuildpackURL != "" {
+		appParams.BuildpackURL = &app.BuildpackURL
+	}
+	if appParams.Command == nil && app.Command != "" {
+		appParams.Command = &app.Command
+	}
+	if appParams.HealthCheckType == nil && app.HealthCheckType != "" {
+		appParams.HealthCheckType = &app.HealthCheckType
+	}
+	if appParams.HealthCheckTimeout == nil && app.HealthCheckTimeout != 0 {
+		appParams.HealthCheckTimeout = &app.HealthCheckTimeout
+	}
+	if appParams.DockerImage == nil && app.DockerImage != "" {
+		appParams.DockerImage = &app.DockerImage
+	}
+	if appParams.StackName == nil && app.Stack != nil {
+		appParams.StackGUID = &app.Stack.GUID
+	}
+	if appParams.Diego == nil {
+		appParams.Diego = &app.Diego
+	}
+	if appParams.EnableSSH == nil {
+		appParams.EnableSSH = &app.EnableSSH
+	}
+
+	envVars := map[string]interface{}{}
+	for key, val := range app.EnvironmentVars {
+		envVars[key] = val
+	}
+	if appParams.EnvironmentVars != nil {
+		for key, val := range *appParams.EnvironmentVars {
+			envVars[key] = val
+		}
+	}
+	appParams.EnvironmentVars = &envVars
+
+	summary, err := cmd.appSummaryRepo.GetSummary(app.GUID)
+	if err != nil {
+		return models.AppParams{}, err
+	}
+
+	services := append([]string{}, appParams.ServicesToBind...)
+	bound := map[string]bool{}
+	for _, service := range services {
+		bound[service] = true
+	}
+	for _, service := range summary.Services {
+		if !bound[service.Name] {
+			services = append(services, service.Name)
+		}
+	}
+	if len(services) > 0 {
+		appParams.ServicesToBind = services
+	}
+
+	return appParams, nil
+}
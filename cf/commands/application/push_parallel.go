@@ -0,0 +1,215 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+
+	"github.com/cloudfoundry/cli/cf/errors"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/terminal"
+)
+
+// orderByDependencies sorts apps so that each app comes after the apps in
+// its depends_on list, keeping the manifest order otherwise. Dependencies
+// must name apps of the manifest; those that are not being pushed are
+// ignored. Apps that appear more than once are all kept, in their order.
+func orderByDependencies(apps []models.AppParams, manifestApps []models.AppParams) ([]models.AppParams, error) {
+	inManifest := map[string]bool{}
+	for _, app := range manifestApps {
+		if app.Name != nil {
+			inManifest[*app.Name] = true
+		}
+	}
+
+	byName := map[string][]int{}
+	for index, app := range apps {
+		if app.Name == nil {
+			return apps, nil
+		}
+		byName[*app.Name] = append(byName[*app.Name], index)
+	}
+
+	var ordered []models.AppParams
+	visited := make([]bool, len(apps))
+	var path []string
+
+	var visit func(index int) error
+	visit = func(index int) error {
+		app := apps[index]
+		name := *app.Name
+		for i, visiting := range path {
+			if visiting == name {
+				return errors.New(T("Apps cannot depend on each other: {{.Cycle}}",
+					map[string]interface{}{"Cycle": strings.Join(append(path[i:], name), " -> ")}))
+			}
+		}
+		if visited[index] {
+			return nil
+		}
+
+		path = append(path, name)
+		for _, dependency := range app.DependsOn {
+			if !inManifest[dependency] {
+				return errors.New(T("App {{.AppName}} depends on {{.Dependency}}, which is not in the manifest",
+					map[string]interface{}{"AppName": name, "Dependency": dependency}))
+			}
+			for _, dependencyIndex := range byName[dependency] {
+				err := visit(dependencyIndex)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+
+		visited[index] = true
+		ordered = append(ordered, app)
+		return nil
+	}
+
+	for index := range apps {
+		err := visit(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+type parallelPushResult struct {
+	status  string
+	details string
+	failed  bool
+}
+
+// pushInParallel pushes up to --parallel apps at a time, each once the apps
+// it depends on have been pushed, and prints a summary of the pushes. The
+// output of each app is printed whole lines at a time, starting with the
+// name of the app.
+func (cmd *Push) pushInParallel(appSet []models.AppParams, appFromContext models.AppParams, c flags.FlagContext) error {
+	cmd.ui.Say(T("Pushing {{.Count}} apps, {{.Parallel}} at a time...\n",
+		map[string]interface{}{"Count": len(appSet), "Parallel": c.Int("parallel")}))
+
+	width := 0
+	for _, appParams := range appSet {
+		if len(*appParams.Name) > width {
+			width = len(*appParams.Name)
+		}
+	}
+
+	lock := &sync.Mutex{}
+	slots := make(chan struct{}, c.Int("parallel"))
+	results := make([]*parallelPushResult, len(appSet))
+	done := make([]chan struct{}, len(appSet))
+	byName := map[string][]int{}
+	for index, appParams := range appSet {
+		results[index] = &parallelPushResult{}
+		done[index] = make(chan struct{})
+		byName[*appParams.Name] = append(byName[*appParams.Name], index)
+	}
+
+	var wg sync.WaitGroup
+	for index, appParams := range appSet {
+		wg.Add(1)
+		go func(index int, appParams models.AppParams) {
+			defer wg.Done()
+
+			name := *appParams.Name
+			result := results[index]
+			defer close(done[index])
+
+			// an app that appears more than once is pushed in manifest
+			// order, after the apps it depends on
+			var waitFor []int
+			for _, earlier := range byName[name] {
+				if earlier < index {
+					waitFor = append(waitFor, earlier)
+				}
+			}
+			for _, dependency := range appParams.DependsOn {
+				waitFor = append(waitFor, byName[dependency]...)
+			}
+
+			for _, other := range waitFor {
+				<-done[other]
+				if results[other].failed {
+					result.status = T("skipped")
+					result.details = T("{{.AppName}} was not pushed", map[string]interface{}{"AppName": *appSet[other].Name})
+					result.failed = true
+					return
+				}
+			}
+
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			prefix := fmt.Sprintf("%-*s ", width+2, "["+name+"]")
+			err := cmd.newParallelPush(terminal.NewPrefixedUI(cmd.ui, prefix, lock)).pushAppCatchingFailure(appParams, appFromContext, c)
+			if err != nil {
+				result.status = T("failed")
+				result.details = strings.Split(err.Error(), "\n")[0]
+				result.failed = true
+				return
+			}
+			result.status = T("pushed")
+		}(index, appParams)
+	}
+	wg.Wait()
+
+	cmd.ui.Say("")
+	table := cmd.ui.Table([]string{T("app"), T("status"), T("details")})
+	notPushed := 0
+	for index, appParams := range appSet {
+		result := results[index]
+		status := terminal.SuccessColor(result.status)
+		if result.failed {
+			status = terminal.FailureColor(result.status)
+			notPushed++
+		}
+		table.Add(*appParams.Name, status, result.details)
+	}
+	table.Print()
+
+	if notPushed > 0 {
+		return errors.New(T("{{.Count}} of {{.Total}} apps were not pushed",
+			map[string]interface{}{"Count": notPushed, "Total": len(appSet)}))
+	}
+	return nil
+}
+
+// newParallelPush returns a push that prints to ui and has its own start,
+// stop and bind-service commands and logs connection, so that it can run
+// alongside others.
+func (cmd *Push) newParallelPush(ui terminal.UI) *Push {
+	deps := cmd.deps
+	deps.UI = ui
+	deps.RepoLocator = deps.RepoLocator.SetLogsRepository(deps.RepoLocator.NewLogsRepository())
+	push := &Push{
+		StartupTimeout:         cmd.StartupTimeout,
+		ServiceInstanceTimeout: cmd.ServiceInstanceTimeout,
+		PingerThrottle:         cmd.PingerThrottle,
+	}
+	return push.SetDependency(deps, false).(*Push)
+}
+
+// pushAppCatchingFailure pushes an app, returning the failures that
+// commands report with ui.Failed as errors rather than exiting.
+func (cmd *Push) pushAppCatchingFailure(appParams models.AppParams, appFromContext models.AppParams, c flags.FlagContext) (err error) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+		if recovered != terminal.QuietPanic {
+			panic(recovered)
+		}
+		err = errors.New(T("Push failed, see the output above"))
+	}()
+
+	return cmd.pushApp(appParams, appFromContext, c)
+}
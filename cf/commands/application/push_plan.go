@@ -0,0 +1,407 @@
+package application
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+
+	"github.com/cloudfoundry/cli/cf/errors"
+	"github.com/cloudfoundry/cli/cf/formatters"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/terminal"
+)
+
+// planPush shows what pushing appSet would change, reading the current
+// state of the apps, their routes and services without changing it. The
+// only requests it sends besides reads are the resource matches, which
+// tell how many of the app files the foundation already has.
+func (cmd *Push) planPush(appSet []models.AppParams, appFromContext models.AppParams, serviceInstances []models.ServiceInstanceParams, noStart bool) error {
+	createdServiceInstances, err := cmd.planServiceInstances(serviceInstances)
+	if err != nil {
+		return err
+	}
+
+	for _, appParams := range appSet {
+		if appParams.Name == nil {
+			return errors.New(T("Error: No name found for app"))
+		}
+
+		err := cmd.fetchStackGUID(&appParams)
+		if err != nil {
+			return err
+		}
+
+		cmd.ui.Say(T("Planning push of app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+			map[string]interface{}{
+				"AppName":   terminal.EntityNameColor(*appParams.Name),
+				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+		cmd.ui.Say("")
+
+		existingApp, err := cmd.appRepo.Read(*appParams.Name)
+		switch err.(type) {
+		case nil:
+			// only the summary has the bound services and the paths and
+			// ports of the mapped routes
+			summary, err := cmd.appSummaryRepo.GetSummary(existingApp.GUID)
+			if err != nil {
+				return err
+			}
+			existingApp.Routes = summary.Routes
+			existingApp.Services = summary.Services
+
+			cmd.ui.Say(changedLine(T("update app {{.AppName}}", map[string]interface{}{"AppName": existingApp.Name})))
+			cmd.planAttributes(appParams, existingApp.ApplicationFields, existingApp.Stack)
+		case *errors.ModelNotFoundError:
+			existingApp = models.Application{ApplicationFields: models.ApplicationFields{Name: *appParams.Name}}
+			cmd.ui.Say(addedLine(T("create app {{.AppName}}", map[string]interface{}{"AppName": *appParams.Name})))
+			cmd.planAttributes(appParams, models.ApplicationFields{}, nil)
+		default:
+			return err
+		}
+
+		err = cmd.planRoutes(existingApp, appParams, appFromContext)
+		if err != nil {
+			return err
+		}
+
+		err = cmd.planServices(existingApp, appParams.ServicesToBind, createdServiceInstances)
+		if err != nil {
+			return err
+		}
+
+		if appParams.DockerImage == nil {
+			err = cmd.planUpload(*appParams.Path)
+			if err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case noStart:
+			cmd.ui.Say(unchangedLine(T("app would not be started")))
+		case existingApp.GUID != "" && existingApp.State != models.ApplicationStateStopped:
+			cmd.ui.Say(changedLine(T("restart app")))
+		default:
+			cmd.ui.Say(changedLine(T("start app")))
+		}
+		cmd.ui.Say("")
+	}
+
+	cmd.ui.Say(T("Dry run: no changes were made."))
+	return nil
+}
+
+func (cmd *Push) planAttributes(params models.AppParams, existing models.ApplicationFields, existingStack *models.Stack) {
+	attribute := func(name string, current, planned interface{}) {
+		switch {
+		case existing.GUID == "":
+			cmd.ui.Say(addedLine(fmt.Sprintf("%s: %v", name, planned)))
+		case !reflect.DeepEqual(current, planned):
+			cmd.ui.Say(changedLine(fmt.Sprintf("%s: %v -> %v", name, current, planned)))
+		}
+	}
+
+	megabytes := func(value int64) string {
+		return formatters.ByteSize(value * formatters.MEGABYTE)
+	}
+
+	if params.InstanceCount != nil {
+		attribute("instances", existing.InstanceCount, *params.InstanceCount)
+	}
+	if params.Memory != nil {
+		attribute("memory", megabytes(existing.Memory), megabytes(*params.Memory))
+	}
+	if params.DiskQuota != nil {
+		attribute("disk_quota", megabytes(existing.DiskQuota), megabytes(*params.DiskQuota))
+	}
+	if params.BuildpackURL != nil {
+		attribute("buildpack", existing.BuildpackURL, *params.BuildpackURL)
+	}
+	if params.Command != nil {
+		attribute("command", existing.Command, *params.Command)
+	}
+	if params.StackGUID != nil && *params.StackGUID != existing.StackGUID {
+		currentStack := existing.StackGUID
+		if existingStack != nil {
+			currentStack = existingStack.Name
+		}
+		attribute("stack", currentStack, *params.StackName)
+	}
+	if params.HealthCheckType != nil {
+		attribute("health-check-type", existing.HealthCheckType, *params.HealthCheckType)
+	}
+	if params.HealthCheckTimeout != nil {
+		attribute("timeout", existing.HealthCheckTimeout, *params.HealthCheckTimeout)
+	}
+	if params.DockerImage != nil {
+		attribute("docker-image", existing.DockerImage, *params.DockerImage)
+	}
+	if params.AppPorts != nil {
+		attribute("app-ports", existing.AppPorts, *params.AppPorts)
+	}
+
+	if params.EnvironmentVars == nil {
+		return
+	}
+
+	// values are left out, as they are often credentials
+	names := []string{}
+	for name := range *params.EnvironmentVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		current, found := existing.EnvironmentVars[name]
+		switch {
+		case !found:
+			cmd.ui.Say(addedLine(T("env {{.Name}}", map[string]interface{}{"Name": name})))
+		case !reflect.DeepEqual(current, (*params.EnvironmentVars)[name]):
+			cmd.ui.Say(changedLine(T("env {{.Name}}", map[string]interface{}{"Name": name})))
+		}
+	}
+}
+
+// plannedRoute is a route that push would map to an app, described by its
+// URL or, for random routes, by its domain.
+type plannedRoute struct {
+	host        string
+	domain      models.DomainFields
+	path        string
+	port        int
+	description string
+}
+
+func (cmd *Push) planRoutes(app models.Application, appParams models.AppParams, appFromContext models.AppParams) error {
+	if appParams.NoRoute {
+		for _, route := range app.Routes {
+			cmd.ui.Say(removedLine(T("unmap route {{.URL}}", map[string]interface{}{"URL": route.URL()})))
+		}
+		return nil
+	}
+
+	routes, err := cmd.plannedRoutes(app, appParams, appFromContext)
+	if err != nil {
+		return err
+	}
+
+	for _, route := range routes {
+		if route.description != "" {
+			cmd.ui.Say(addedLine(T("create and map {{.Route}}", map[string]interface{}{"Route": route.description})))
+			continue
+		}
+
+		url := (&models.RoutePresenter{Host: route.host, Domain: route.domain.Name, Path: route.path, Port: route.port}).URL()
+		if isMapped(app, route) {
+			cmd.ui.Say(unchangedLine(T("route {{.URL}} is mapped", map[string]interface{}{"URL": url})))
+			continue
+		}
+
+		_, err := cmd.routeRepo.Find(route.host, route.domain, route.path, route.port)
+		switch err.(type) {
+		case nil:
+		case *errors.ModelNotFoundError:
+			cmd.ui.Say(addedLine(T("create route {{.URL}}", map[string]interface{}{"URL": url})))
+		default:
+			return err
+		}
+		cmd.ui.Say(addedLine(T("map route {{.URL}}", map[string]interface{}{"URL": url})))
+	}
+	return nil
+}
+
+// plannedRoutes follows updateRoutes to find the routes push would map.
+func (cmd *Push) plannedRoutes(app models.Application, appParams models.AppParams, appFromContext models.AppParams) ([]plannedRoute, error) {
+	routes := []plannedRoute{}
+
+	if len(appParams.Routes) > 0 {
+		for _, manifestRoute := range appParams.Routes {
+			routeWithoutPath, path := cmd.routeActor.FindPath(manifestRoute.Route)
+			routeWithoutPathAndPort, port, err := cmd.routeActor.FindPort(routeWithoutPath)
+			if err != nil {
+				return nil, err
+			}
+
+			host, domain, err := cmd.routeActor.FindDomain(routeWithoutPathAndPort)
+			if err != nil {
+				return nil, err
+			}
+
+			if appFromContext.RoutePath != nil && *appFromContext.RoutePath != "" && !isTCP(domain) {
+				path = *appFromContext.RoutePath
+			}
+			routes = append(routes, plannedRoute{host: host, domain: domain, path: path, port: port})
+		}
+		return routes, nil
+	}
+
+	routeDefined := appParams.Domains != nil || !appParams.IsHostEmpty() || appParams.IsNoHostnameTrue()
+	if !routeDefined && len(app.Routes) > 0 {
+		return routes, nil
+	}
+
+	var domainNames []*string
+	if appParams.Domains == nil {
+		domainNames = []*string{nil}
+	}
+	for i := range appParams.Domains {
+		domainNames = append(domainNames, &appParams.Domains[i])
+	}
+
+	for _, domainName := range domainNames {
+		domain, err := cmd.findDomain(domainName)
+		if err != nil {
+			return nil, err
+		}
+
+		hosts := []*string{nil}
+		if !appParams.IsHostEmpty() {
+			hosts = []*string{}
+			for i := range appParams.Hosts {
+				hosts = append(hosts, &appParams.Hosts[i])
+			}
+		}
+
+		for _, host := range hosts {
+			route := plannedRoute{domain: domain}
+			if appParams.RoutePath != nil {
+				route.path = *appParams.RoutePath
+			}
+
+			switch {
+			case appParams.IsNoHostnameTrue():
+			case host != nil:
+				route.host = *host
+			case isTCP(domain):
+				route.description = T("a route with a random port on {{.Domain}}", map[string]interface{}{"Domain": domain.Name})
+			case appParams.UseRandomRoute:
+				route.description = T("a random route on {{.Domain}}", map[string]interface{}{"Domain": domain.Name})
+			default:
+				route.host = hostNameForString(app.Name)
+			}
+			routes = append(routes, route)
+		}
+	}
+	return routes, nil
+}
+
+func isMapped(app models.Application, route plannedRoute) bool {
+	for _, mapped := range app.Routes {
+		if mapped.Host == route.host && mapped.Domain.GUID == route.domain.GUID && mapped.Path == route.path && mapped.Port == route.port {
+			return true
+		}
+	}
+	return false
+}
+
+// planServiceInstances shows which service instances of the manifest would
+// be created and returns their names.
+func (cmd *Push) planServiceInstances(serviceInstances []models.ServiceInstanceParams) (map[string]bool, error) {
+	created := map[string]bool{}
+	for _, params := range serviceInstances {
+		serviceInstance, err := cmd.serviceRepo.FindInstanceByName(params.Name)
+		switch err.(type) {
+		case nil:
+			cmd.ui.Say(unchangedLine(T("service instance {{.ServiceName}} exists", map[string]interface{}{"ServiceName": params.Name})))
+			cmd.warnIfPlanDiffers(serviceInstance, params)
+		case *errors.ModelNotFoundError:
+			if params.IsUserProvided() {
+				cmd.ui.Say(addedLine(T("create user provided service {{.ServiceName}}", map[string]interface{}{"ServiceName": params.Name})))
+			} else {
+				cmd.ui.Say(addedLine(T("create service instance {{.ServiceName}} ({{.Service}} {{.Plan}})",
+					map[string]interface{}{"ServiceName": params.Name, "Service": params.Offering, "Plan": params.Plan})))
+			}
+			created[params.Name] = true
+		default:
+			return nil, err
+		}
+	}
+
+	if len(serviceInstances) > 0 {
+		cmd.ui.Say("")
+	}
+	return created, nil
+}
+
+func (cmd *Push) planServices(app models.Application, services []string, createdServiceInstances map[string]bool) error {
+	for _, serviceName := range services {
+		bound := false
+		for _, service := range app.Services {
+			bound = bound || service.Name == serviceName
+		}
+
+		if bound {
+			cmd.ui.Say(unchangedLine(T("service {{.ServiceName}} is bound", map[string]interface{}{"ServiceName": serviceName})))
+			continue
+		}
+
+		if !createdServiceInstances[serviceName] {
+			_, err := cmd.serviceRepo.FindInstanceByName(serviceName)
+			if err != nil {
+				return errors.New(T("Could not find service {{.ServiceName}} to bind to {{.AppName}}",
+					map[string]interface{}{"ServiceName": serviceName, "AppName": app.Name}))
+			}
+		}
+		cmd.ui.Say(addedLine(T("bind service {{.ServiceName}}", map[string]interface{}{"ServiceName": serviceName})))
+	}
+	return nil
+}
+
+// planUpload counts the app files and asks the foundation which of them
+// it has in its resource cache, as uploadApp does, without zipping or
+// uploading them.
+func (cmd *Push) planUpload(path string) error {
+	return cmd.actor.ProcessPath(path, func(appDir string) error {
+		localFiles, err := cmd.appfiles.AppFilesInDir(appDir)
+		if err != nil {
+			return errors.NewWrappedError(
+				T("Error processing app files in '{{.Path}}': {{.Error}}",
+					map[string]interface{}{
+						"Path":  path,
+						"Error": err.Error(),
+					}), err)
+		}
+
+		uploadDir, err := ioutil.TempDir("", "apps")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(uploadDir)
+
+		cachedFiles, _, err := cmd.actor.GatherFiles(localFiles, appDir, uploadDir)
+		if err != nil {
+			return err
+		}
+
+		cmd.ui.Say(changedLine(T("upload {{.UploadCount}} of {{.FileCount}} files from {{.Path}} ({{.CachedCount}} in the resource cache)",
+			map[string]interface{}{
+				"UploadCount": len(localFiles) - len(cachedFiles),
+				"FileCount":   len(localFiles),
+				"Path":        appDir,
+				"CachedCount": len(cachedFiles),
+			})))
+		return nil
+	})
+}
+
+func addedLine(change string) string {
+	return terminal.SuccessColor("+ " + change)
+}
+
+func changedLine(change string) string {
+	return terminal.AdvisoryColor("~ " + change)
+}
+
+func removedLine(change string) string {
+	return terminal.FailureColor("- " + change)
+}
+
+func unchangedLine(change string) string {
+	return "  " + change
+}
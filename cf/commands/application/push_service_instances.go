@@ -0,0 +1,162 @@
+package application
+
+import (
+	"time"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+
+	"github.com/cloudfoundry/cli/cf/errors"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/terminal"
+)
+
+// DefaultServiceInstanceTimeout is how long push waits for a service
+// instance to be created when no async timeout is configured.
+const DefaultServiceInstanceTimeout = 30 * time.Minute
+
+// ensureServiceInstances creates the service instances of the manifest that
+// do not exist yet, so that apps can be bound to them. Existing instances are
+// left as they are, with a warning when their offering or plan differs from
+// the manifest.
+func (cmd *Push) ensureServiceInstances(serviceInstances []models.ServiceInstanceParams) error {
+	for _, params := range serviceInstances {
+		serviceInstance, err := cmd.serviceRepo.FindInstanceByName(params.Name)
+		switch err.(type) {
+		case nil:
+			cmd.warnIfPlanDiffers(serviceInstance, params)
+			continue
+		case *errors.ModelNotFoundError:
+		default:
+			return err
+		}
+
+		if params.IsUserProvided() {
+			err = cmd.createUserProvidedServiceInstance(params)
+		} else {
+			err = cmd.createServiceInstance(params)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cmd *Push) createUserProvidedServiceInstance(params models.ServiceInstanceParams) error {
+	cmd.ui.Say(T("Creating user provided service {{.ServiceName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"ServiceName": terminal.EntityNameColor(params.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	err := cmd.userProvidedServiceInstanceRepo.Create(params.Name, "", "", params.Credentials)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	return nil
+}
+
+func (cmd *Push) createServiceInstance(params models.ServiceInstanceParams) error {
+	cmd.ui.Say(T("Creating service instance {{.ServiceName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"ServiceName": terminal.EntityNameColor(params.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	plan, err := cmd.findServicePlan(params)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.serviceRepo.CreateServiceInstance(params.Name, plan.GUID, params.Parameters, params.Tags)
+	switch err.(type) {
+	case nil:
+	case *errors.ModelAlreadyExistsError:
+		cmd.ui.Ok()
+		cmd.ui.Warn(err.Error())
+		cmd.ui.Say("")
+		return nil
+	default:
+		return err
+	}
+
+	err = cmd.waitForServiceInstance(params.Name)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	return nil
+}
+
+func (cmd *Push) findServicePlan(params models.ServiceInstanceParams) (models.ServicePlanFields, error) {
+	offerings, err := cmd.serviceBuilder.GetServicesByNameForSpaceWithPlans(cmd.config.SpaceFields().GUID, params.Offering)
+	if err != nil {
+		return models.ServicePlanFields{}, err
+	}
+
+	for _, offering := range offerings {
+		for _, plan := range offering.Plans {
+			if plan.Name == params.Plan {
+				return plan, nil
+			}
+		}
+	}
+
+	return models.ServicePlanFields{}, errors.New(T("Could not find plan {{.PlanName}} of service {{.ServiceName}}",
+		map[string]interface{}{"PlanName": params.Plan, "ServiceName": params.Offering}))
+}
+
+// waitForServiceInstance waits until the service broker has finished
+// creating the instance, as apps cannot be bound to it before.
+func (cmd *Push) waitForServiceInstance(name string) error {
+	deadline := time.Now().Add(cmd.ServiceInstanceTimeout)
+	for {
+		serviceInstance, err := cmd.serviceRepo.FindInstanceByName(name)
+		if err != nil {
+			return err
+		}
+
+		switch serviceInstance.LastOperation.State {
+		case "in progress":
+			if time.Now().After(deadline) {
+				return errors.New(T("Timed out after {{.Timeout}} waiting for service instance {{.ServiceName}} to be created",
+					map[string]interface{}{"Timeout": cmd.ServiceInstanceTimeout, "ServiceName": name}))
+			}
+			time.Sleep(cmd.PingerThrottle)
+		case "failed":
+			return errors.New(T("Could not create service instance {{.ServiceName}}: {{.Description}}",
+				map[string]interface{}{"ServiceName": name, "Description": serviceInstance.LastOperation.Description}))
+		default:
+			return nil
+		}
+	}
+}
+
+func (cmd *Push) warnIfPlanDiffers(serviceInstance models.ServiceInstance, params models.ServiceInstanceParams) {
+	if params.IsUserProvided() || serviceInstance.IsUserProvided() {
+		return
+	}
+
+	if serviceInstance.ServiceOffering.Label == params.Offering && serviceInstance.ServicePlan.Name == params.Plan {
+		return
+	}
+
+	cmd.ui.Warn(T("Service instance {{.ServiceName}} uses plan {{.ExistingPlan}} of service {{.ExistingService}}, but the manifest declares plan {{.Plan}} of service {{.Service}}. Use 'cf update-service' to change it.",
+		map[string]interface{}{
+			"ServiceName":     params.Name,
+			"ExistingPlan":    serviceInstance.ServicePlan.Name,
+			"ExistingService": serviceInstance.ServiceOffering.Label,
+			"Plan":            params.Plan,
+			"Service":         params.Offering,
+		}))
+	cmd.ui.Say("")
+}
@@ -1,13 +1,17 @@
 package application_test
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/cloudfoundry/cli/cf"
 	"github.com/cloudfoundry/cli/cf/actors/actorsfakes"
+	"github.com/cloudfoundry/cli/cf/actors/servicebuilder/servicebuilderfakes"
 	"github.com/cloudfoundry/cli/cf/api/apifakes"
+	"github.com/cloudfoundry/cli/cf/api/appinstances/appinstancesfakes"
 	"github.com/cloudfoundry/cli/cf/api/applications/applicationsfakes"
 	"github.com/cloudfoundry/cli/cf/api/authentication/authenticationfakes"
 	"github.com/cloudfoundry/cli/cf/api/resources"
@@ -135,7 +139,9 @@ var _ = Describe("Push Command", func() {
 
 		//inject fake commands dependencies into registry
 		serviceBinder = new(servicefakes.OldFakeAppBinder)
-		commandregistry.Register(serviceBinder)
+		// the copies that NewCommand makes of the registered command share
+		// the embedded fake
+		commandregistry.Register(struct{ *servicefakes.OldFakeAppBinder }{serviceBinder})
 
 		cmd = application.Push{}
 		cmd.SetDependency(deps, false)
@@ -210,6 +216,74 @@ var _ = Describe("Push Command", func() {
 				Expect(reqs).To(ContainElement(minVersionReq))
 			})
 		})
+
+		Context("when --strategy is passed in", func() {
+			BeforeEach(func() {
+				err := flagContext.Parse("app-name", "--strategy", "rolling", "--no-start")
+				Expect(err).NotTo(HaveOccurred())
+
+				reqs = cmd.Requirements(requirementsFactory, flagContext)
+			})
+
+			It("checks that it is blue-green and not used with --no-start or --dry-run", func() {
+				count := requirementsFactory.NewUsageRequirementCallCount()
+				_, message, invalid := requirementsFactory.NewUsageRequirementArgsForCall(count - 2)
+				Expect(message).To(Equal("--strategy must be blue-green"))
+				Expect(invalid()).To(BeTrue())
+
+				_, message, invalid = requirementsFactory.NewUsageRequirementArgsForCall(count - 1)
+				Expect(message).To(Equal("--strategy cannot be used with --no-start or --dry-run"))
+				Expect(invalid()).To(BeTrue())
+			})
+		})
+
+		Context("when --parallel is passed in", func() {
+			BeforeEach(func() {
+				err := flagContext.Parse("app-name", "--parallel", "0")
+				Expect(err).NotTo(HaveOccurred())
+
+				reqs = cmd.Requirements(requirementsFactory, flagContext)
+			})
+
+			It("checks that it is at least 1", func() {
+				lastCall := requirementsFactory.NewUsageRequirementCallCount() - 1
+				_, message, tooSmall := requirementsFactory.NewUsageRequirementArgsForCall(lastCall)
+				Expect(message).To(Equal("--parallel must be at least 1"))
+				Expect(tooSmall()).To(BeTrue())
+			})
+		})
+
+		Context("when --overlay is passed in", func() {
+			BeforeEach(func() {
+				err := flagContext.Parse("app-name", "--overlay", "prod.yml", "--no-manifest")
+				Expect(err).NotTo(HaveOccurred())
+
+				reqs = cmd.Requirements(requirementsFactory, flagContext)
+			})
+
+			It("checks that --no-manifest is not passed in", func() {
+				lastCall := requirementsFactory.NewUsageRequirementCallCount() - 1
+				_, message, noManifest := requirementsFactory.NewUsageRequirementArgsForCall(lastCall)
+				Expect(message).To(Equal("--overlay cannot be used with --no-manifest"))
+				Expect(noManifest()).To(BeTrue())
+			})
+		})
+
+		Context("when --var is passed in", func() {
+			BeforeEach(func() {
+				err := flagContext.Parse("app-name", "--var", "instances=4", "--no-manifest")
+				Expect(err).NotTo(HaveOccurred())
+
+				reqs = cmd.Requirements(requirementsFactory, flagContext)
+			})
+
+			It("checks that --no-manifest is not passed in", func() {
+				lastCall := requirementsFactory.NewUsageRequirementCallCount() - 1
+				_, message, noManifest := requirementsFactory.NewUsageRequirementArgsForCall(lastCall)
+				Expect(message).To(Equal("--var and --vars-file cannot be used with --no-manifest"))
+				Expect(noManifest()).To(BeTrue())
+			})
+		})
 	})
 
 	Describe("Execute", func() {
@@ -283,6 +357,324 @@ var _ = Describe("Push Command", func() {
 			executeErr = cmd.Execute(flagContext)
 		})
 
+		Context("when --dry-run is passed", func() {
+			var appSummaryRepo *apifakes.FakeAppSummaryRepository
+
+			BeforeEach(func() {
+				deps.UI = uiWithContents
+
+				m := &manifest.Manifest{
+					Path: "manifest.yml",
+					Data: generic.NewMap(map[interface{}]interface{}{
+						"applications": []interface{}{
+							generic.NewMap(map[interface{}]interface{}{
+								"name":      "existing-app",
+								"instances": 3,
+								"memory":    "512M",
+								"host":      "new-host",
+								"services":  []interface{}{"logs", "db"},
+								"path":      filepath.Clean("some/path/from/manifest"),
+								"env": generic.NewMap(map[interface{}]interface{}{
+									"CHANGED": "new",
+									"ADDED":   "secret-value",
+									"KEPT":    "same",
+								}),
+							}),
+						},
+					}),
+				}
+				manifestRepo.ReadManifestReturns(m, nil)
+
+				existingApp := models.Application{
+					ApplicationFields: models.ApplicationFields{
+						GUID:            "existing-app-guid",
+						Name:            "existing-app",
+						InstanceCount:   1,
+						Memory:          256,
+						State:           "started",
+						EnvironmentVars: map[string]interface{}{"CHANGED": "old", "KEPT": "same"},
+					},
+					Routes: []models.RouteSummary{
+						{Host: "existing-app", Domain: models.DomainFields{Name: "foo.cf-app.com", GUID: "foo-domain-guid"}},
+						{Host: "api", Domain: models.DomainFields{Name: "foo.cf-app.com", GUID: "foo-domain-guid"}},
+					},
+				}
+				appRepo.ReadReturns(existingApp, nil)
+
+				appSummaryRepo = new(apifakes.FakeAppSummaryRepository)
+				appSummaryRepo.GetSummaryReturns(models.Application{
+					ApplicationFields: existingApp.ApplicationFields,
+					Routes: []models.RouteSummary{
+						{Host: "existing-app", Domain: models.DomainFields{Name: "foo.cf-app.com", GUID: "foo-domain-guid"}},
+						{Host: "api", Domain: models.DomainFields{Name: "foo.cf-app.com", GUID: "foo-domain-guid"}, Path: "/v2"},
+					},
+					Services: []models.ServicePlanSummary{{Name: "logs"}},
+				}, nil)
+				deps.RepoLocator = deps.RepoLocator.SetAppSummaryRepository(appSummaryRepo)
+				routeRepo.FindReturns(models.Route{}, errors.NewModelNotFoundError("Route", "new-host.foo.cf-app.com"))
+
+				appfiles.AppFilesInDirReturns([]models.AppFileFields{{Path: "app.rb"}, {Path: "Gemfile"}, {Path: "vendor/big.gem"}}, nil)
+				actor.GatherFilesReturns([]resources.AppFileResource{{Path: "vendor/big.gem"}}, true, nil)
+
+				args = []string{"--dry-run"}
+			})
+
+			It("prints the plan of changes", func() {
+				Expect(executeErr).NotTo(HaveOccurred())
+
+				Expect(output).To(gbytes.Say("Planning push of app existing-app"))
+				Expect(output).To(gbytes.Say(`~ update app existing-app`))
+				Expect(output).To(gbytes.Say(`~ instances: 1 -> 3`))
+				Expect(output).To(gbytes.Say(`~ memory: 256M -> 512M`))
+				Expect(output).To(gbytes.Say(`\+ env ADDED`))
+				Expect(output).To(gbytes.Say(`~ env CHANGED`))
+				Expect(output).To(gbytes.Say(`\+ create route new-host.foo.cf-app.com`))
+				Expect(output).To(gbytes.Say(`\+ map route new-host.foo.cf-app.com`))
+				Expect(output).To(gbytes.Say(`service logs is bound`))
+				Expect(output).To(gbytes.Say(`\+ bind service db`))
+				Expect(output).To(gbytes.Say(`~ upload 2 of 3 files from .* \(1 in the resource cache\)`))
+				Expect(output).To(gbytes.Say(`~ restart app`))
+				Expect(output).To(gbytes.Say("Dry run: no changes were made."))
+
+				Expect(output.Contents()).NotTo(ContainSubstring("secret-value"))
+				Expect(output.Contents()).NotTo(ContainSubstring("env KEPT"))
+			})
+
+			It("does not change anything", func() {
+				Expect(executeErr).NotTo(HaveOccurred())
+
+				Expect(appRepo.CreateCallCount()).To(BeZero())
+				Expect(appRepo.UpdateCallCount()).To(BeZero())
+				Expect(routeRepo.CreateCallCount()).To(BeZero())
+				Expect(routeActor.FindOrCreateRouteCallCount()).To(BeZero())
+				Expect(routeActor.BindRouteCallCount()).To(BeZero())
+				Expect(routeActor.UnbindAllCallCount()).To(BeZero())
+				Expect(serviceBinder.AppsToBind).To(BeEmpty())
+				Expect(actor.UploadAppCallCount()).To(BeZero())
+				Expect(stopper.ApplicationStopCallCount()).To(BeZero())
+				Expect(starter.ApplicationStartCallCount()).To(BeZero())
+			})
+
+			Context("when the app does not exist and has no route", func() {
+				BeforeEach(func() {
+					appRepo.ReadReturns(models.Application{}, errors.NewModelNotFoundError("App", "existing-app"))
+					args = []string{"--dry-run", "--no-route", "--no-start"}
+				})
+
+				It("plans to create the app without routes", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					Expect(output).To(gbytes.Say(`\+ create app existing-app`))
+					Expect(output).To(gbytes.Say(`\+ instances: 3`))
+					Expect(output).To(gbytes.Say(`app would not be started`))
+					Expect(output.Contents()).NotTo(ContainSubstring("route"))
+				})
+			})
+
+			Context("when a route with a path is already mapped", func() {
+				BeforeEach(func() {
+					args = []string{"--dry-run", "--hostname", "api", "--route-path", "/v2"}
+				})
+
+				It("does not plan to map it again", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(appSummaryRepo.GetSummaryArgsForCall(0)).To(Equal("existing-app-guid"))
+					Expect(output).To(gbytes.Say(`route api.foo.cf-app.com/v2 is mapped`))
+					Expect(output.Contents()).NotTo(ContainSubstring("map route"))
+				})
+			})
+
+			Context("when the app is no longer routed", func() {
+				BeforeEach(func() {
+					args = []string{"--dry-run", "--no-route"}
+				})
+
+				It("plans to unmap its routes", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(output).To(gbytes.Say(`- unmap route existing-app.foo.cf-app.com`))
+				})
+			})
+		})
+
+		Context("when the manifest declares service instances", func() {
+			var (
+				serviceBuilder   *servicebuilderfakes.FakeServiceBuilder
+				userProvidedRepo *apifakes.FakeUserProvidedServiceInstanceRepository
+				created          map[string]bool
+			)
+
+			BeforeEach(func() {
+				deps.UI = uiWithContents
+
+				serviceBuilder = new(servicebuilderfakes.FakeServiceBuilder)
+				serviceBuilder.GetServicesByNameForSpaceWithPlansReturns(models.ServiceOfferings{
+					{Plans: []models.ServicePlanFields{{Name: "large", GUID: "large-guid"}, {Name: "small", GUID: "small-guid"}}},
+				}, nil)
+				deps.ServiceBuilder = serviceBuilder
+
+				userProvidedRepo = new(apifakes.FakeUserProvidedServiceInstanceRepository)
+				deps.RepoLocator = deps.RepoLocator.SetUserProvidedServiceInstanceRepository(userProvidedRepo)
+
+				m := &manifest.Manifest{
+					Path: "manifest.yml",
+					Data: generic.NewMap(map[interface{}]interface{}{
+						"service_instances": []interface{}{
+							map[interface{}]interface{}{
+								"name":       "db",
+								"offering":   "postgres",
+								"plan":       "small",
+								"parameters": map[interface{}]interface{}{"backups": true},
+								"tags":       []interface{}{"sql"},
+							},
+							map[interface{}]interface{}{
+								"name":        "smtp",
+								"credentials": map[interface{}]interface{}{"host": "smtp.example.com"},
+							},
+							map[interface{}]interface{}{
+								"name":     "cache",
+								"offering": "redis",
+								"plan":     "small",
+							},
+						},
+						"applications": []interface{}{
+							generic.NewMap(map[interface{}]interface{}{
+								"name":     "manifest-app-name",
+								"services": []interface{}{"db", "smtp", "cache"},
+							}),
+						},
+					}),
+				}
+				manifestRepo.ReadManifestReturns(m, nil)
+				appRepo.ReadReturns(models.Application{}, errors.NewModelNotFoundError("App", "manifest-app-name"))
+				appRepo.CreateStub = func(params models.AppParams) (models.Application, error) {
+					return models.Application{ApplicationFields: models.ApplicationFields{Name: *params.Name, GUID: "app-guid"}}, nil
+				}
+
+				created = map[string]bool{}
+				serviceRepo.CreateServiceInstanceStub = func(name, _ string, _ map[string]interface{}, _ []string) error {
+					created[name] = true
+					return nil
+				}
+				userProvidedRepo.CreateStub = func(name, _, _ string, _ map[string]interface{}) error {
+					created[name] = true
+					return nil
+				}
+				serviceRepo.FindInstanceByNameStub = func(name string) (models.ServiceInstance, error) {
+					if name == "cache" {
+						serviceInstance := models.ServiceInstance{ServiceOffering: models.ServiceOfferingFields{Label: "redis"}}
+						serviceInstance.Name = "cache"
+						serviceInstance.ServicePlan = models.ServicePlanFields{Name: "large", GUID: "large-guid"}
+						return serviceInstance, nil
+					}
+					if !created[name] {
+						return models.ServiceInstance{}, errors.NewModelNotFoundError("Service instance", name)
+					}
+					serviceInstance := models.ServiceInstance{}
+					serviceInstance.Name = name
+					return serviceInstance, nil
+				}
+
+				args = []string{}
+			})
+
+			It("creates the missing instances before binding them", func() {
+				Expect(executeErr).NotTo(HaveOccurred())
+
+				Expect(serviceRepo.CreateServiceInstanceCallCount()).To(Equal(1))
+				name, planGUID, params, tags := serviceRepo.CreateServiceInstanceArgsForCall(0)
+				Expect(name).To(Equal("db"))
+				Expect(planGUID).To(Equal("small-guid"))
+				Expect(params).To(Equal(map[string]interface{}{"backups": true}))
+				Expect(tags).To(Equal([]string{"sql"}))
+
+				spaceGUID, offering := serviceBuilder.GetServicesByNameForSpaceWithPlansArgsForCall(0)
+				Expect(spaceGUID).To(Equal(configRepo.SpaceFields().GUID))
+				Expect(offering).To(Equal("postgres"))
+
+				Expect(userProvidedRepo.CreateCallCount()).To(Equal(1))
+				name, _, _, credentials := userProvidedRepo.CreateArgsForCall(0)
+				Expect(name).To(Equal("smtp"))
+				Expect(credentials).To(Equal(map[string]interface{}{"host": "smtp.example.com"}))
+
+				Expect(serviceBinder.InstancesToBindTo).To(HaveLen(3))
+				Expect(output).To(gbytes.Say("Creating service instance db"))
+				Expect(output).To(gbytes.Say("Creating user provided service smtp"))
+				Expect(output).To(gbytes.Say("Binding service db"))
+			})
+
+			It("warns when an existing instance has a different plan", func() {
+				Expect(executeErr).NotTo(HaveOccurred())
+				Expect(output.Contents()).To(ContainSubstring("Service instance cache uses plan large of service redis, but the manifest declares plan small of service redis."))
+			})
+
+			Context("when the plan cannot be found", func() {
+				BeforeEach(func() {
+					serviceBuilder.GetServicesByNameForSpaceWithPlansReturns(models.ServiceOfferings{}, nil)
+				})
+
+				It("returns an error without pushing the app", func() {
+					Expect(executeErr).To(MatchError("Could not find plan small of service postgres"))
+					Expect(appRepo.CreateCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the service broker fails to create an instance", func() {
+				BeforeEach(func() {
+					serviceRepo.FindInstanceByNameStub = func(name string) (models.ServiceInstance, error) {
+						if !created[name] {
+							return models.ServiceInstance{}, errors.NewModelNotFoundError("Service instance", name)
+						}
+						serviceInstance := models.ServiceInstance{}
+						serviceInstance.LastOperation = models.LastOperationFields{State: "failed", Description: "out of capacity"}
+						return serviceInstance, nil
+					}
+				})
+
+				It("returns the error", func() {
+					Expect(executeErr).To(MatchError("Could not create service instance db: out of capacity"))
+				})
+			})
+
+			Context("when the service broker does not finish creating an instance", func() {
+				BeforeEach(func() {
+					serviceRepo.FindInstanceByNameStub = func(name string) (models.ServiceInstance, error) {
+						if !created[name] {
+							return models.ServiceInstance{}, errors.NewModelNotFoundError("Service instance", name)
+						}
+						serviceInstance := models.ServiceInstance{}
+						serviceInstance.LastOperation = models.LastOperationFields{State: "in progress"}
+						return serviceInstance, nil
+					}
+					cmd.ServiceInstanceTimeout = 20 * time.Millisecond
+					cmd.PingerThrottle = time.Millisecond
+				})
+
+				It("stops waiting and names the instance", func() {
+					Expect(executeErr).To(MatchError("Timed out after 20ms waiting for service instance db to be created"))
+					Expect(serviceRepo.FindInstanceByNameCallCount()).To(BeNumerically(">", 3))
+					Expect(appRepo.CreateCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when --dry-run is passed", func() {
+				BeforeEach(func() {
+					args = []string{"--dry-run"}
+				})
+
+				It("plans to create the missing instances without creating them", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					Expect(output).To(gbytes.Say(`\+ create service instance db \(postgres small\)`))
+					Expect(output).To(gbytes.Say(`\+ create user provided service smtp`))
+					Expect(output).To(gbytes.Say(`service instance cache exists`))
+					Expect(output).To(gbytes.Say(`\+ bind service db`))
+
+					Expect(serviceRepo.CreateServiceInstanceCallCount()).To(BeZero())
+					Expect(userProvidedRepo.CreateCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when pushing a new app", func() {
 			BeforeEach(func() {
 				m := &manifest.Manifest{
@@ -336,6 +728,23 @@ var _ = Describe("Push Command", func() {
 				})
 			})
 
+			Context("when the manifest has unknown keys", func() {
+				BeforeEach(func() {
+					deps.UI = uiWithContents
+					manifestRepo.ValidateManifestReturns(manifest.ValidationErrors{
+						{File: "manifest.yml", Line: 4, Column: 3, Message: "Unknown key applications[0].instance"},
+					})
+				})
+
+				It("warns about them and pushes the app", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(manifestRepo.ValidateManifestArgsForCall(0)).To(Equal("manifest.yml"))
+					Expect(output).To(gbytes.Say("The manifest has problems that push may ignore:"))
+					Expect(output).To(gbytes.Say(`manifest.yml:4:3: Unknown key applications\[0\].instance`))
+					Expect(appRepo.CreateCallCount()).To(Equal(1))
+				})
+			})
+
 			Context("when given a bad path", func() {
 				BeforeEach(func() {
 					actor.ProcessPathStub = func(dirOrZipFile string, f func(string) error) error {
@@ -634,6 +1043,27 @@ var _ = Describe("Push Command", func() {
 						Expect(spaceName).To(Equal(configRepo.SpaceFields().Name))
 						Expect(starter.SetStartTimeoutInSecondsArgsForCall(0)).To(Equal(111))
 					})
+
+					Context("when the app fails to stage", func() {
+						BeforeEach(func() {
+							starter.ApplicationStartReturns(models.Application{}, errors.NewStagingFailedError("staging failed"))
+						})
+
+						It("keeps the exit code of the staging failure", func() {
+							Expect(executeErr).To(MatchError(ContainSubstring("Error restarting application: staging failed")))
+							Expect(errors.ExitCode(executeErr)).To(Equal(errors.ExitCodeStagingFailed))
+						})
+					})
+
+					Context("when the app fails to start", func() {
+						BeforeEach(func() {
+							starter.ApplicationStartReturns(models.Application{}, errors.NewStartFailedError("instances crashed"))
+						})
+
+						It("keeps the exit code of the start failure", func() {
+							Expect(errors.ExitCode(executeErr)).To(Equal(errors.ExitCodeStartFailed))
+						})
+					})
 				})
 
 				Context("when there are special characters in the app name", func() {
@@ -815,6 +1245,33 @@ var _ = Describe("Push Command", func() {
 					})
 				})
 
+				Context("when the manifest sets a docker image", func() {
+					BeforeEach(func() {
+						m := &manifest.Manifest{
+							Path: "manifest.yml",
+							Data: generic.NewMap(map[interface{}]interface{}{
+								"applications": []interface{}{
+									generic.NewMap(map[interface{}]interface{}{
+										"name":         "testApp",
+										"docker-image": "sample/dockerImage",
+									}),
+								},
+							}),
+						}
+						manifestRepo.ReadManifestReturns(m, nil)
+						args = []string{}
+					})
+
+					It("pushes the docker image without uploading app bits", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						params := appRepo.CreateArgsForCall(0)
+						Expect(*params.DockerImage).To(Equal("sample/dockerImage"))
+						Expect(*params.Diego).To(BeTrue())
+						Expect(actor.ProcessPathCallCount()).To(BeZero())
+					})
+				})
+
 				Context("when health-check-type '-u' or '--health-check-type' is set", func() {
 					Context("when the value is not 'port' or 'none'", func() {
 						BeforeEach(func() {
@@ -1090,6 +1547,18 @@ var _ = Describe("Push Command", func() {
 						Expect(fullOutput).To(ContainSubstring("Creating app app-name in org my-org / space my-space as my-user...\nOK"))
 						Expect(fullOutput).To(ContainSubstring("Uploading app-name...\nOK"))
 					})
+
+					Context("when variables are given", func() {
+						BeforeEach(func() {
+							args = []string{"--no-route", "--var", "instances=4", "app-name"}
+						})
+
+						It("fails instead of ignoring them", func() {
+							Expect(executeErr).To(HaveOccurred())
+							Expect(executeErr.Error()).To(ContainSubstring("Error reading manifest file:"))
+							Expect(appRepo.CreateCallCount()).To(BeZero())
+						})
+					})
 				})
 
 				Context("when the current directory does contain a manifest", func() {
@@ -1168,6 +1637,116 @@ var _ = Describe("Push Command", func() {
 					})
 				})
 
+				Context("when the manifest has variables", func() {
+					BeforeEach(func() {
+						m := &manifest.Manifest{
+							Path: "manifest.yml",
+							Data: generic.NewMap(map[interface{}]interface{}{
+								"applications": []interface{}{
+									generic.NewMap(map[interface{}]interface{}{
+										"name":      "((name))",
+										"instances": "((instances))",
+										"host":      "((name))-((space))",
+									}),
+								},
+							}),
+						}
+						manifestRepo.ReadManifestReturns(m, nil)
+					})
+
+					Context("when the variables are given", func() {
+						BeforeEach(func() {
+							args = []string{"--var", "name=manifest-app-name", "--var", "space=staging", "--var", "instances=3"}
+						})
+
+						It("substitutes them in the manifest", func() {
+							Expect(executeErr).NotTo(HaveOccurred())
+
+							params := appRepo.CreateArgsForCall(0)
+							Expect(*params.Name).To(Equal("manifest-app-name"))
+							Expect(*params.InstanceCount).To(Equal(3))
+
+							hostname, _, _, _, _ := routeActor.FindOrCreateRouteArgsForCall(0)
+							Expect(hostname).To(Equal("manifest-app-name-staging"))
+						})
+					})
+
+					Context("when variables are missing", func() {
+						BeforeEach(func() {
+							args = []string{"--var", "space=staging"}
+						})
+
+						It("lists the missing variables", func() {
+							Expect(executeErr).To(HaveOccurred())
+							Expect(executeErr.Error()).To(ContainSubstring("Error reading manifest file:"))
+							Expect(executeErr.Error()).To(ContainSubstring("instances, name"))
+						})
+					})
+
+					Context("when a variable is not given as NAME=VALUE", func() {
+						BeforeEach(func() {
+							args = []string{"--var", "name"}
+						})
+
+						It("fails", func() {
+							Expect(executeErr).To(HaveOccurred())
+							Expect(executeErr.Error()).To(ContainSubstring("Invalid variable name"))
+						})
+					})
+
+					Context("when the vars file does not exist", func() {
+						BeforeEach(func() {
+							args = []string{"--vars-file", "does-not-exist.yml"}
+						})
+
+						It("fails", func() {
+							Expect(executeErr).To(HaveOccurred())
+							Expect(executeErr.Error()).To(ContainSubstring("does-not-exist.yml"))
+						})
+					})
+				})
+
+				Context("when overlays are given", func() {
+					var overlayDir string
+
+					BeforeEach(func() {
+						var err error
+						overlayDir, err = ioutil.TempDir("", "push-overlays")
+						Expect(err).NotTo(HaveOccurred())
+
+						m := &manifest.Manifest{
+							Path: "manifest.yml",
+							Data: generic.NewMap(map[interface{}]interface{}{
+								"applications": []interface{}{
+									map[interface{}]interface{}{
+										"name":      "manifest-app-name",
+										"instances": 1,
+									},
+								},
+							}),
+						}
+						manifestRepo.ReadManifestReturns(m, nil)
+
+						overlayPath := filepath.Join(overlayDir, "prod.yml")
+						err = ioutil.WriteFile(overlayPath, []byte("- type: replace\n  path: /applications/name=manifest-app-name/instances\n  value: ((instances))\n"), 0600)
+						Expect(err).NotTo(HaveOccurred())
+
+						args = []string{"--overlay", overlayPath, "--var", "instances=4"}
+					})
+
+					AfterEach(func() {
+						os.RemoveAll(overlayDir)
+					})
+
+					It("applies them to the manifest before its variables", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						params := appRepo.CreateArgsForCall(0)
+						Expect(*params.Name).To(Equal("manifest-app-name"))
+						Expect(*params.InstanceCount).To(Equal(4))
+					})
+				})
+
 				Context("when the no-route option is set", func() {
 					Context("when provided the --no-route-flag", func() {
 						BeforeEach(func() {
@@ -1263,6 +1842,179 @@ var _ = Describe("Push Command", func() {
 					})
 				})
 
+				Context("when --parallel is passed", func() {
+					BeforeEach(func() {
+						deps.UI = uiWithContents
+						m := &manifest.Manifest{
+							Data: generic.NewMap(map[interface{}]interface{}{
+								"applications": []interface{}{
+									generic.NewMap(map[interface{}]interface{}{
+										"name":       "web",
+										"depends_on": []interface{}{"api"},
+									}),
+									generic.NewMap(map[interface{}]interface{}{
+										"name": "api",
+									}),
+									generic.NewMap(map[interface{}]interface{}{
+										"name": "worker",
+									}),
+								},
+							}),
+						}
+						manifestRepo.ReadManifestReturns(m, nil)
+						appRepo.ReadReturns(models.Application{}, errors.NewModelNotFoundError("App", "app"))
+						appRepo.CreateStub = func(params models.AppParams) (models.Application, error) {
+							return models.Application{ApplicationFields: models.ApplicationFields{Name: *params.Name, GUID: *params.Name + "-guid"}}, nil
+						}
+						args = []string{"--parallel", "2"}
+					})
+
+					createdApps := func() []string {
+						var names []string
+						for i := 0; i < appRepo.CreateCallCount(); i++ {
+							names = append(names, *appRepo.CreateArgsForCall(i).Name)
+						}
+						return names
+					}
+
+					It("pushes every app, prefixing its output with its name", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+						Expect(createdApps()).To(ConsistOf("web", "api", "worker"))
+						Expect(starter.ApplicationStartCallCount()).To(Equal(3))
+
+						totalOutput := terminal.Decolorize(string(output.Contents()))
+						Expect(totalOutput).To(ContainSubstring("Pushing 3 apps, 2 at a time..."))
+						Expect(totalOutput).To(MatchRegexp(`(?m)^\[web\]    Creating app web`))
+						Expect(totalOutput).To(MatchRegexp(`(?m)^\[api\]    Creating app api`))
+						Expect(totalOutput).To(MatchRegexp(`(?m)^\[worker\] Creating app worker`))
+						Expect(totalOutput).To(MatchRegexp(`(?m)^web\s+pushed`))
+						Expect(totalOutput).To(MatchRegexp(`(?m)^api\s+pushed`))
+						Expect(totalOutput).To(MatchRegexp(`(?m)^worker\s+pushed`))
+					})
+
+					It("pushes apps after the apps they depend on", func() {
+						Expect(createdApps()).To(HaveLen(3))
+						Expect(createdApps()[0:2]).To(ContainElement("api"))
+						Expect(createdApps()[2]).NotTo(Equal("api"))
+
+						var apiIndex, webIndex int
+						for i, name := range createdApps() {
+							switch name {
+							case "api":
+								apiIndex = i
+							case "web":
+								webIndex = i
+							}
+						}
+						Expect(apiIndex).To(BeNumerically("<", webIndex))
+					})
+
+					Context("when an app fails to push", func() {
+						BeforeEach(func() {
+							appRepo.CreateStub = func(params models.AppParams) (models.Application, error) {
+								if *params.Name == "api" {
+									return models.Application{}, errors.New("quota exceeded")
+								}
+								return models.Application{ApplicationFields: models.ApplicationFields{Name: *params.Name, GUID: *params.Name + "-guid"}}, nil
+							}
+						})
+
+						It("skips the apps that depend on it and summarizes the pushes", func() {
+							Expect(executeErr).To(MatchError("2 of 3 apps were not pushed"))
+							Expect(createdApps()).To(ConsistOf("api", "worker"))
+
+							totalOutput := terminal.Decolorize(string(output.Contents()))
+							Expect(totalOutput).To(MatchRegexp(`(?m)^web\s+skipped\s+api was not pushed`))
+							Expect(totalOutput).To(MatchRegexp(`(?m)^api\s+failed\s+quota exceeded`))
+							Expect(totalOutput).To(MatchRegexp(`(?m)^worker\s+pushed`))
+						})
+					})
+
+					Context("when apps depend on each other", func() {
+						BeforeEach(func() {
+							manifestRepo.ReadManifestReturns(&manifest.Manifest{
+								Data: generic.NewMap(map[interface{}]interface{}{
+									"applications": []interface{}{
+										generic.NewMap(map[interface{}]interface{}{
+											"name":       "web",
+											"depends_on": []interface{}{"api"},
+										}),
+										generic.NewMap(map[interface{}]interface{}{
+											"name":       "api",
+											"depends_on": []interface{}{"web"},
+										}),
+									},
+								}),
+							}, nil)
+						})
+
+						It("returns an error without pushing", func() {
+							Expect(executeErr).To(MatchError("Apps cannot depend on each other: web -> api -> web"))
+							Expect(appRepo.CreateCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when an app depends on an app that is not in the manifest", func() {
+						BeforeEach(func() {
+							manifestRepo.ReadManifestReturns(&manifest.Manifest{
+								Data: generic.NewMap(map[interface{}]interface{}{
+									"applications": []interface{}{
+										generic.NewMap(map[interface{}]interface{}{
+											"name":       "web",
+											"depends_on": []interface{}{"api"},
+										}),
+										generic.NewMap(map[interface{}]interface{}{
+											"name": "worker",
+										}),
+									},
+								}),
+							}, nil)
+						})
+
+						It("returns an error without pushing", func() {
+							Expect(executeErr).To(MatchError("App web depends on api, which is not in the manifest"))
+							Expect(appRepo.CreateCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when an app appears more than once", func() {
+						BeforeEach(func() {
+							manifestRepo.ReadManifestReturns(&manifest.Manifest{
+								Data: generic.NewMap(map[interface{}]interface{}{
+									"applications": []interface{}{
+										generic.NewMap(map[interface{}]interface{}{
+											"name":       "web",
+											"depends_on": []interface{}{"api"},
+										}),
+										generic.NewMap(map[interface{}]interface{}{
+											"name": "api",
+										}),
+										generic.NewMap(map[interface{}]interface{}{
+											"name": "api",
+										}),
+									},
+								}),
+							}, nil)
+						})
+
+						It("pushes every entry, in order", func() {
+							Expect(executeErr).NotTo(HaveOccurred())
+							Expect(createdApps()).To(Equal([]string{"api", "api", "web"}))
+						})
+
+						Context("without --parallel", func() {
+							BeforeEach(func() {
+								args = []string{}
+							})
+
+							It("pushes every entry, in order", func() {
+								Expect(executeErr).NotTo(HaveOccurred())
+								Expect(createdApps()).To(Equal([]string{"api", "api", "web"}))
+							})
+						})
+					})
+				})
+
 				Context("when a manifest has many apps", func() {
 					BeforeEach(func() {
 						deps.UI = uiWithContents
@@ -1749,6 +2501,295 @@ var _ = Describe("Push Command", func() {
 			})
 		})
 
+		Context("when --strategy blue-green is passed", func() {
+			var (
+				existingApp      models.Application
+				appSummaryRepo   *apifakes.FakeAppSummaryRepository
+				appInstancesRepo *appinstancesfakes.FakeRepository
+			)
+
+			instances := func(states ...models.InstanceState) []models.AppInstanceFields {
+				fields := []models.AppInstanceFields{}
+				for _, state := range states {
+					fields = append(fields, models.AppInstanceFields{State: state})
+				}
+				return fields
+			}
+
+			BeforeEach(func() {
+				deps.UI = uiWithContents
+				existingApp = models.Application{
+					ApplicationFields: models.ApplicationFields{
+						Name:            "my-app",
+						GUID:            "my-app-guid",
+						State:           "started",
+						InstanceCount:   3,
+						Memory:          512,
+						EnvironmentVars: map[string]interface{}{"FOO": "bar"},
+					},
+					Routes: []models.RouteSummary{
+						{GUID: "route-1-guid", Host: "my-app", Domain: models.DomainFields{Name: "example.com"}},
+						{GUID: "route-2-guid", Host: "www", Domain: models.DomainFields{Name: "example.com"}},
+					},
+				}
+
+				manifestRepo.ReadManifestReturns(manifest.NewEmptyManifest(), nil)
+				appRepo.ReadStub = func(name string) (models.Application, error) {
+					if name == "my-app" {
+						return existingApp, nil
+					}
+					return models.Application{}, errors.NewModelNotFoundError("App", name)
+				}
+				appRepo.CreateStub = func(params models.AppParams) (models.Application, error) {
+					return models.Application{ApplicationFields: models.ApplicationFields{Name: *params.Name, GUID: *params.Name + "-guid", State: "stopped"}}, nil
+				}
+
+				appSummaryRepo = new(apifakes.FakeAppSummaryRepository)
+				appSummaryRepo.GetSummaryReturns(models.Application{Services: []models.ServicePlanSummary{{Name: "db"}}}, nil)
+				deps.RepoLocator = deps.RepoLocator.SetAppSummaryRepository(appSummaryRepo)
+				serviceRepo.FindInstanceByNameReturns(models.ServiceInstance{ServiceInstanceFields: models.ServiceInstanceFields{Name: "db"}}, nil)
+
+				appInstancesRepo = new(appinstancesfakes.FakeRepository)
+				appInstancesRepo.GetInstancesReturns(instances(models.InstanceRunning, models.InstanceRunning, models.InstanceRunning), nil)
+				deps.RepoLocator = deps.RepoLocator.SetAppInstancesRepository(appInstancesRepo)
+				cmd.StartupTimeout = 50 * time.Millisecond
+				cmd.PingerThrottle = time.Millisecond
+
+				args = []string{"my-app", "--strategy", "blue-green"}
+			})
+
+			renames := func() map[string]string {
+				names := map[string]string{}
+				for i := 0; i < appRepo.UpdateCallCount(); i++ {
+					guid, params := appRepo.UpdateArgsForCall(i)
+					if params.Name != nil {
+						names[guid] = *params.Name
+					}
+				}
+				return names
+			}
+
+			It("pushes a new app with the settings of the running one and replaces it", func() {
+				Expect(executeErr).NotTo(HaveOccurred())
+
+				Expect(appRepo.CreateCallCount()).To(Equal(1))
+				params := appRepo.CreateArgsForCall(0)
+				Expect(*params.Name).To(Equal("my-app-new"))
+				Expect(*params.InstanceCount).To(Equal(3))
+				Expect(*params.Memory).To(Equal(int64(512)))
+				Expect(*params.EnvironmentVars).To(Equal(map[string]interface{}{"FOO": "bar"}))
+
+				Expect(serviceBinder.AppsToBind[0].Name).To(Equal("my-app-new"))
+				Expect(serviceBinder.InstancesToBindTo[0].Name).To(Equal("db"))
+
+				Expect(starter.ApplicationStartCallCount()).To(Equal(1))
+				startedApp, _, _ := starter.ApplicationStartArgsForCall(0)
+				Expect(startedApp.GUID).To(Equal("my-app-new-guid"))
+
+				Expect(routeRepo.BindCallCount()).To(Equal(2))
+				routeGUID, appGUID := routeRepo.BindArgsForCall(0)
+				Expect([]string{routeGUID, appGUID}).To(Equal([]string{"route-1-guid", "my-app-new-guid"}))
+				routeGUID, appGUID = routeRepo.BindArgsForCall(1)
+				Expect([]string{routeGUID, appGUID}).To(Equal([]string{"route-2-guid", "my-app-new-guid"}))
+
+				Expect(routeRepo.UnbindCallCount()).To(Equal(2))
+				routeGUID, appGUID = routeRepo.UnbindArgsForCall(0)
+				Expect([]string{routeGUID, appGUID}).To(Equal([]string{"route-1-guid", "my-app-guid"}))
+
+				Expect(renames()).To(Equal(map[string]string{
+					"my-app-guid":     "my-app-old",
+					"my-app-new-guid": "my-app",
+				}))
+
+				Expect(appRepo.DeleteCallCount()).To(Equal(1))
+				Expect(appRepo.DeleteArgsForCall(0)).To(Equal("my-app-guid"))
+
+				totalOutput := terminal.Decolorize(string(output.Contents()))
+				Expect(totalOutput).To(ContainSubstring("Pushing app my-app as my-app-new to replace it without downtime..."))
+				Expect(totalOutput).To(ContainSubstring("Deleting app my-app-old..."))
+			})
+
+			Context("when the new app fails to start", func() {
+				BeforeEach(func() {
+					starter.ApplicationStartReturns(models.Application{}, errors.New("instances crashed"))
+				})
+
+				It("deletes it and leaves the running app alone", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("the running app was kept"))
+					Expect(executeErr.Error()).To(ContainSubstring("instances crashed"))
+
+					Expect(appRepo.DeleteCallCount()).To(Equal(1))
+					Expect(appRepo.DeleteArgsForCall(0)).To(Equal("my-app-new-guid"))
+					Expect(routeRepo.BindCallCount()).To(BeZero())
+					Expect(routeRepo.UnbindCallCount()).To(BeZero())
+					Expect(renames()).To(BeEmpty())
+				})
+			})
+
+			Context("when the instances of the new app start one after another", func() {
+				BeforeEach(func() {
+					appInstancesRepo.GetInstancesStub = func(appGUID string) ([]models.AppInstanceFields, error) {
+						if appInstancesRepo.GetInstancesCallCount() == 1 {
+							return instances(models.InstanceRunning, models.InstanceStarting, models.InstanceStarting), nil
+						}
+						return instances(models.InstanceRunning, models.InstanceRunning, models.InstanceRunning), nil
+					}
+				})
+
+				It("maps the routes once all of them run", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(appInstancesRepo.GetInstancesCallCount()).To(Equal(2))
+					Expect(appInstancesRepo.GetInstancesArgsForCall(0)).To(Equal("my-app-new-guid"))
+					Expect(routeRepo.BindCallCount()).To(Equal(2))
+				})
+			})
+
+			Context("when only some instances of the new app run", func() {
+				BeforeEach(func() {
+					appInstancesRepo.GetInstancesReturns(instances(models.InstanceRunning, models.InstanceStarting, models.InstanceStarting), nil)
+				})
+
+				It("deletes it without moving the routes", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("the running app was kept"))
+					Expect(executeErr.Error()).To(ContainSubstring("Only 1 of 3 instances of my-app-new were running"))
+
+					Expect(appRepo.DeleteCallCount()).To(Equal(1))
+					Expect(appRepo.DeleteArgsForCall(0)).To(Equal("my-app-new-guid"))
+					Expect(routeRepo.BindCallCount()).To(BeZero())
+					Expect(routeRepo.UnbindCallCount()).To(BeZero())
+					Expect(renames()).To(BeEmpty())
+				})
+			})
+
+			Context("when an instance of the new app crashes", func() {
+				BeforeEach(func() {
+					appInstancesRepo.GetInstancesReturns(instances(models.InstanceRunning, models.InstanceCrashed, models.InstanceRunning), nil)
+				})
+
+				It("deletes it without moving the routes", func() {
+					Expect(executeErr).To(MatchError(ContainSubstring("An instance of my-app-new crashed")))
+					Expect(appRepo.DeleteArgsForCall(0)).To(Equal("my-app-new-guid"))
+					Expect(routeRepo.BindCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when a route cannot be unmapped from the running app", func() {
+				BeforeEach(func() {
+					routeRepo.UnbindStub = func(routeGUID, appGUID string) error {
+						if routeGUID == "route-2-guid" {
+							return errors.New("unbind failed")
+						}
+						return nil
+					}
+				})
+
+				It("maps the routes back to the running app and deletes the new app", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("unbind failed"))
+
+					Expect(routeRepo.BindCallCount()).To(Equal(3))
+					routeGUID, appGUID := routeRepo.BindArgsForCall(2)
+					Expect([]string{routeGUID, appGUID}).To(Equal([]string{"route-1-guid", "my-app-guid"}))
+
+					Expect(appRepo.DeleteCallCount()).To(Equal(1))
+					Expect(appRepo.DeleteArgsForCall(0)).To(Equal("my-app-new-guid"))
+					Expect(renames()).To(BeEmpty())
+				})
+			})
+
+			Context("when the new app cannot be renamed", func() {
+				BeforeEach(func() {
+					appRepo.UpdateStub = func(guid string, params models.AppParams) (models.Application, error) {
+						if guid == "my-app-new-guid" && params.Name != nil {
+							return models.Application{}, errors.New("name taken")
+						}
+						return models.Application{}, nil
+					}
+				})
+
+				It("renames the running app back and maps its routes to it again", func() {
+					Expect(executeErr).To(HaveOccurred())
+
+					lastUpdate := appRepo.UpdateCallCount() - 1
+					guid, params := appRepo.UpdateArgsForCall(lastUpdate)
+					Expect(guid).To(Equal("my-app-guid"))
+					Expect(*params.Name).To(Equal("my-app"))
+
+					Expect(routeRepo.BindCallCount()).To(Equal(4))
+					Expect(appRepo.DeleteArgsForCall(0)).To(Equal("my-app-new-guid"))
+				})
+			})
+
+			Context("when the old app cannot be deleted", func() {
+				BeforeEach(func() {
+					appRepo.DeleteReturns(errors.New("delete failed"))
+				})
+
+				It("warns and keeps the new app", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(renames()).To(HaveKeyWithValue("my-app-new-guid", "my-app"))
+
+					totalOutput := terminal.Decolorize(string(output.Contents()))
+					Expect(totalOutput).To(ContainSubstring("Could not delete app my-app-old: delete failed"))
+				})
+			})
+
+			Context("when an app from an earlier blue-green push is left over", func() {
+				BeforeEach(func() {
+					appRepo.ReadStub = func(name string) (models.Application, error) {
+						if name == "my-app" || name == "my-app-new" {
+							return existingApp, nil
+						}
+						return models.Application{}, errors.NewModelNotFoundError("App", name)
+					}
+				})
+
+				It("returns an error without pushing", func() {
+					Expect(executeErr).To(MatchError(ContainSubstring("because app my-app-new exists")))
+					Expect(appRepo.CreateCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the manifest has several apps", func() {
+				BeforeEach(func() {
+					manifestRepo.ReadManifestReturns(&manifest.Manifest{
+						Path: "manifest.yml",
+						Data: generic.NewMap(map[interface{}]interface{}{
+							"applications": []interface{}{
+								generic.NewMap(map[interface{}]interface{}{"name": "my-app"}),
+								generic.NewMap(map[interface{}]interface{}{"name": "my-worker"}),
+							},
+						}),
+					}, nil)
+					args = []string{"--strategy", "blue-green"}
+				})
+
+				It("replaces the running apps and pushes the new ones in place", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(appRepo.CreateCallCount()).To(Equal(2))
+					Expect(*appRepo.CreateArgsForCall(0).Name).To(Equal("my-app-new"))
+					Expect(*appRepo.CreateArgsForCall(1).Name).To(Equal("my-worker"))
+					Expect(renames()).To(HaveKeyWithValue("my-app-new-guid", "my-app"))
+				})
+			})
+
+			Context("when the app does not exist yet", func() {
+				BeforeEach(func() {
+					appRepo.ReadReturns(models.Application{}, errors.NewModelNotFoundError("App", "my-app"))
+					appRepo.ReadStub = nil
+				})
+
+				It("pushes it in place", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(appRepo.CreateCallCount()).To(Equal(1))
+					Expect(*appRepo.CreateArgsForCall(0).Name).To(Equal("my-app"))
+					Expect(appRepo.DeleteCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when routes are specified in the manifest", func() {
 			Context("and the manifest has more than one app", func() {
 				BeforeEach(func() {
@@ -1,10 +1,10 @@
 package application
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/cloudfoundry/cli/cf/api/applications"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
@@ -78,7 +78,7 @@ func (cmd *SetHealthCheck) Execute(fc flags.FlagContext) error {
 
 	updatedApp, err := cmd.appRepo.Update(app.GUID, models.AppParams{HealthCheckType: &healthCheckType})
 	if err != nil {
-		return errors.New(T("Error updating health_check_type for ") + app.Name + ": " + err.Error())
+		return errors.NewWrappedError(T("Error updating health_check_type for ")+app.Name+": "+err.Error(), err)
 	}
 
 	if updatedApp.HealthCheckType == healthCheckType {
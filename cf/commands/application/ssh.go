@@ -1,7 +1,6 @@
 package application
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -11,6 +10,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/commands"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/net"
@@ -109,18 +109,23 @@ func (cmd *SSH) Execute(fc flags.FlagContext) error {
 	app := cmd.appReq.GetApplication()
 	info, err := cmd.getSSHEndpointInfo()
 	if err != nil {
-		return errors.New(T("Error getting SSH info:") + err.Error())
+		return errors.NewWrappedError(T("Error getting SSH info:")+err.Error(), err)
 	}
 
 	sshAuthCode, err := cmd.sshCodeGetter.Get()
 	if err != nil {
-		return errors.New(T("Error getting one time auth code: ") + err.Error())
+		return errors.NewWrappedError(T("Error getting one time auth code: ")+err.Error(), err)
 	}
 
 	//init secureShell if it is not already set by SetDependency() with fakes
 	if cmd.secureShell == nil {
+		proxy, err := net.ProxyFromConfig(cmd.config)
+		if err != nil {
+			return err
+		}
+
 		cmd.secureShell = sshCmd.NewSecureShell(
-			sshCmd.DefaultSecureDialer(),
+			sshCmd.NewSecureDialer(proxy.Dialer(cmd.gateway.DialTimeout)),
 			sshTerminal.DefaultHelper(),
 			sshCmd.DefaultListenerFactory(),
 			30*time.Second,
@@ -133,13 +138,13 @@ func (cmd *SSH) Execute(fc flags.FlagContext) error {
 
 	err = cmd.secureShell.Connect(cmd.opts)
 	if err != nil {
-		return errors.New(T("Error opening SSH connection: ") + err.Error())
+		return errors.NewWrappedError(T("Error opening SSH connection: ")+err.Error(), err)
 	}
 	defer cmd.secureShell.Close()
 
 	err = cmd.secureShell.LocalPortForward()
 	if err != nil {
-		return errors.New(T("Error forwarding port: ") + err.Error())
+		return errors.NewWrappedError(T("Error forwarding port: ")+err.Error(), err)
 	}
 
 	if cmd.opts.SkipRemoteExecution {
@@ -156,7 +161,7 @@ func (cmd *SSH) Execute(fc flags.FlagContext) error {
 			}
 			os.Exit(exitStatus)
 		} else {
-			return errors.New(T("Error: ") + err.Error())
+			return errors.NewWrappedError(T("Error: ")+err.Error(), err)
 		}
 	}
 	return nil
@@ -1,7 +1,6 @@
 package application
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"sort"
@@ -17,6 +16,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/api/logs"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
@@ -124,8 +124,7 @@ func (cmd *Start) SetDependency(deps commandregistry.Dependency, pluginCall bool
 		cmd.StartupTimeout = DefaultStartupTimeout
 	}
 
-	appCommand := commandregistry.Commands.FindCommand("app")
-	appCommand = appCommand.SetDependency(deps, false)
+	appCommand := commandregistry.Commands.NewCommand("app", deps)
 	cmd.appDisplayer = appCommand.(Displayer)
 
 	return cmd
@@ -185,7 +184,7 @@ func (cmd *Start) WatchStaging(app models.Application, orgName, spaceName string
 	cmd.ui.Say("")
 
 	if !isStaged {
-		return models.Application{}, fmt.Errorf("%s failed to stage within %f minutes", app.Name, cmd.StagingTimeout.Minutes())
+		return models.Application{}, errors.NewStagingFailedError(fmt.Sprintf("%s failed to stage within %f minutes", app.Name, cmd.StagingTimeout.Minutes()))
 	}
 
 	err = cmd.waitForOneRunningInstance(updatedApp)
@@ -320,7 +319,7 @@ func (cmd *Start) waitForInstancesToStage(app models.Application) (bool, error)
 	if app.PackageState == "FAILED" {
 		cmd.ui.Say("")
 		if app.StagingFailedReason == "NoAppDetectedError" {
-			return false, errors.New(T(`{{.Err}}
+			return false, errors.NewStagingFailedError(T(`{{.Err}}
 			
 TIP: Buildpacks are detected when the "{{.PushCommand}}" is executed from within the directory that contains the app source code.
 
@@ -333,7 +332,7 @@ Use '{{.Command}}' for more in depth log information.`,
 					"BuildpackCommand": terminal.CommandColor(fmt.Sprintf("%s buildpacks", cf.Name)),
 					"Command":          terminal.CommandColor(fmt.Sprintf("%s logs %s --recent", cf.Name, app.Name))}))
 		}
-		return false, errors.New(T("{{.Err}}\n\nTIP: use '{{.Command}}' for more information",
+		return false, errors.NewStagingFailedError(T("{{.Err}}\n\nTIP: use '{{.Command}}' for more information",
 			map[string]interface{}{
 				"Err":     app.StagingFailedReason,
 				"Command": terminal.CommandColor(fmt.Sprintf("%s logs %s --recent", cf.Name, app.Name))}))
@@ -355,7 +354,7 @@ func (cmd *Start) waitForOneRunningInstance(app models.Application) error {
 			tipMsg := T("Start app timeout\n\nTIP: Application must be listening on the right port. Instead of hard coding the port, use the $PORT environment variable.") + "\n\n"
 			tipMsg += T("Use '{{.Command}}' for more information", map[string]interface{}{"Command": terminal.CommandColor(fmt.Sprintf("%s logs %s --recent", cf.Name, app.Name))})
 
-			return errors.New(tipMsg)
+			return errors.NewStartFailedError(tipMsg)
 
 		default:
 			count, err := cmd.fetchInstanceCount(app.GUID)
@@ -372,7 +371,7 @@ func (cmd *Start) waitForOneRunningInstance(app models.Application) error {
 			}
 
 			if count.flapping > 0 || count.crashed > 0 {
-				return fmt.Errorf(T("Start unsuccessful\n\nTIP: use '{{.Command}}' for more information",
+				return errors.NewStartFailedError(T("Start unsuccessful\n\nTIP: use '{{.Command}}' for more information",
 					map[string]interface{}{"Command": terminal.CommandColor(fmt.Sprintf("%s logs %s --recent", cf.Name, app.Name))}))
 			}
 
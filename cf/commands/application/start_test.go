@@ -99,7 +99,7 @@ var _ = Describe("start command", func() {
 		appInstancesRepo = new(appinstancesfakes.FakeAppInstancesRepository)
 		appRepo = new(applicationsfakes.FakeRepository)
 
-		displayApp = new(applicationfakes.FakeAppDisplayer)
+		displayApp = &applicationfakes.FakeAppDisplayer{FakeDisplayer: new(applicationfakes.FakeDisplayer)}
 
 		//save original command dependency and restore later
 		originalAppCommand = commandregistry.Commands.FindCommand("app")
@@ -359,7 +359,9 @@ var _ = Describe("start command", func() {
 
 			appGUID, _ := appRepo.UpdateArgsForCall(0)
 			Expect(appGUID).To(Equal("my-app-guid"))
-			Expect(displayApp.AppToDisplay).To(Equal(defaultAppForStart))
+			Expect(displayApp.ShowAppCallCount()).To(Equal(1))
+			app, _, _ := displayApp.ShowAppArgsForCall(0)
+			Expect(app).To(Equal(defaultAppForStart))
 		})
 
 		It("displays the command start command instead of the detected start command when set", func() {
@@ -487,6 +489,21 @@ var _ = Describe("start command", func() {
 			))
 		})
 
+		It("returns a StagingFailedError when staging fails", func() {
+			defaultAppForStart.PackageState = "FAILED"
+			defaultAppForStart.StagingFailedReason = "AWWW, FAILED"
+			appRepo.UpdateReturns(defaultAppForStart, nil)
+			appRepo.GetAppReturns(defaultAppForStart, nil)
+
+			updateCommandDependency(logRepo)
+			cmd := commandregistry.Commands.FindCommand("start").(*Start)
+			cmd.PingerThrottle = 10 * time.Millisecond
+
+			_, err := cmd.ApplicationStart(defaultAppForStart, "some-org", "some-space")
+			Expect(err).To(BeAssignableToTypeOf(&errors.StagingFailedError{}))
+			Expect(errors.ExitCode(err)).To(Equal(errors.ExitCodeStagingFailed))
+		})
+
 		It("displays an TIP about needing to push from source directory when staging fails with NoAppDetectedError", func() {
 			defaultAppForStart.PackageState = "FAILED"
 			defaultAppForStart.StagingFailedReason = "NoAppDetectedError"
@@ -1,9 +1,9 @@
 package buildpack
 
 import (
-	"errors"
 	"strconv"
 
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 
@@ -25,8 +25,9 @@ func init() {
 
 func (cmd *ListBuildpacks) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "buildpacks",
-		Description: T("List all buildpacks"),
+		Name:             "buildpacks",
+		StructuredOutput: true,
+		Description:      T("List all buildpacks"),
 		Usage: []string{
 			T("CF_NAME buildpacks"),
 		},
@@ -87,7 +88,7 @@ func (cmd *ListBuildpacks) Execute(c flags.FlagContext) error {
 	table.Print()
 
 	if apiErr != nil {
-		return errors.New(T("Failed fetching buildpacks.\n{{.Error}}", map[string]interface{}{"Error": apiErr.Error()}))
+		return errors.NewWrappedError(T("Failed fetching buildpacks.\n{{.Error}}", map[string]interface{}{"Error": apiErr.Error()}), apiErr)
 	}
 
 	if noBuildpacks {
@@ -84,10 +84,10 @@ func (cmd *DeleteBuildpack) Execute(c flags.FlagContext) error {
 
 	err = cmd.buildpackRepo.Delete(buildpack.GUID)
 	if err != nil {
-		return errors.New(T("Error deleting buildpack {{.Name}}\n{{.Error}}", map[string]interface{}{
+		return errors.NewWrappedError(T("Error deleting buildpack {{.Name}}\n{{.Error}}", map[string]interface{}{
 			"Name":  terminal.EntityNameColor(buildpack.Name),
 			"Error": err.Error(),
-		}))
+		}), err)
 	}
 
 	cmd.ui.Ok()
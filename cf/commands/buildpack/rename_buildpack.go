@@ -1,10 +1,9 @@
 package buildpack
 
 import (
-	"errors"
-
 	"github.com/cloudfoundry/cli/cf/api"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/requirements"
@@ -63,10 +62,10 @@ func (cmd *RenameBuildpack) Execute(c flags.FlagContext) error {
 	buildpack.Name = newBuildpackName
 	buildpack, err = cmd.buildpackRepo.Update(buildpack)
 	if err != nil {
-		return errors.New(T("Error renaming buildpack {{.Name}}\n{{.Error}}", map[string]interface{}{
+		return errors.NewWrappedError(T("Error renaming buildpack {{.Name}}\n{{.Error}}", map[string]interface{}{
 			"Name":  terminal.EntityNameColor(buildpack.Name),
 			"Error": err.Error(),
-		}))
+		}), err)
 	}
 
 	cmd.ui.Ok()
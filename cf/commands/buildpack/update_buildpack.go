@@ -1,11 +1,11 @@
 package buildpack
 
 import (
-	"errors"
 	"path/filepath"
 
 	"github.com/cloudfoundry/cli/cf/api"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/requirements"
@@ -133,10 +133,10 @@ func (cmd *UpdateBuildpack) Execute(c flags.FlagContext) error {
 	if updateBuildpack {
 		newBuildpack, err := cmd.buildpackRepo.Update(buildpack)
 		if err != nil {
-			return errors.New(T("Error updating buildpack {{.Name}}\n{{.Error}}", map[string]interface{}{
+			return errors.NewWrappedError(T("Error updating buildpack {{.Name}}\n{{.Error}}", map[string]interface{}{
 				"Name":  terminal.EntityNameColor(buildpack.Name),
 				"Error": err.Error(),
-			}))
+			}), err)
 		}
 		buildpack = newBuildpack
 	}
@@ -144,10 +144,10 @@ func (cmd *UpdateBuildpack) Execute(c flags.FlagContext) error {
 	if dir != "" {
 		err := cmd.buildpackBitsRepo.UploadBuildpack(buildpack, dir)
 		if err != nil {
-			return errors.New(T("Error uploading buildpack {{.Name}}\n{{.Error}}", map[string]interface{}{
+			return errors.NewWrappedError(T("Error uploading buildpack {{.Name}}\n{{.Error}}", map[string]interface{}{
 				"Name":  terminal.EntityNameColor(buildpack.Name),
 				"Error": err.Error(),
-			}))
+			}), err)
 		}
 	}
 	cmd.ui.Ok()
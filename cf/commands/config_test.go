@@ -125,4 +125,19 @@ var _ = Describe("config command", func() {
 			})
 		})
 	})
+
+	Context("--credential-store flag", func() {
+		It("moves the session tokens into the given store", func() {
+			runCommand("--credential-store", "file")
+			Expect(ui.Outputs()).To(BeEmpty())
+			Expect(configRepo.AccessToken()).NotTo(BeEmpty())
+		})
+
+		It("fails when the store is unknown", func() {
+			runCommand("--credential-store", "keyring")
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Unknown credential store 'keyring'"},
+			))
+		})
+	})
 })
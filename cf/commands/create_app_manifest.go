@@ -1,11 +1,13 @@
 package commands
 
 import (
-	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"sort"
+	"strings"
 
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 
@@ -25,9 +27,11 @@ type CreateAppManifest struct {
 	config           coreconfig.Reader
 	appSummaryRepo   api.AppSummaryRepository
 	stackRepo        stacks.StackRepository
+	serviceRepo      api.ServiceRepository
 	appInstancesRepo appinstances.Repository
 	appReq           requirements.ApplicationRequirement
 	manifest         manifest.App
+	placeholders     placeholders
 }
 
 func init() {
@@ -37,18 +41,32 @@ func init() {
 func (cmd *CreateAppManifest) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["p"] = &flags.StringFlag{ShortName: "p", Usage: T("Specify a path for file creation. If path not specified, manifest file is created in current working directory.")}
+	fs["all"] = &flags.BoolFlag{Name: "all", Usage: T("Create one manifest for all apps in the targeted space and the service instances they are bound to")}
+	fs["redact-env"] = &flags.BoolFlag{Name: "redact-env", Usage: T("Replace the values of environment variables with ((APP_NAME.VARIABLE_NAME)) placeholders, to be given with --var or --vars-file on push")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "create-app-manifest",
 		Description: T("Create an app manifest for an app that has been pushed successfully"),
 		Usage: []string{
-			T("CF_NAME create-app-manifest APP_NAME [-p /path/to/<app-name>-manifest.yml ]"),
+			T("CF_NAME create-app-manifest APP_NAME [-p /path/to/<app-name>-manifest.yml ] [--redact-env]\n"),
+			T("   CF_NAME create-app-manifest --all [-p /path/to/<space-name>-manifest.yml ] [--redact-env]"),
 		},
 		Flags: fs,
 	}
 }
 
 func (cmd *CreateAppManifest) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if fc.Bool("all") {
+		if len(fc.Args()) != 0 {
+			cmd.ui.Failed(T("Incorrect Usage. No argument required with --all\n\n") + commandregistry.Commands.CommandUsage("create-app-manifest"))
+		}
+
+		return []requirements.Requirement{
+			requirementsFactory.NewLoginRequirement(),
+			requirementsFactory.NewTargetedSpaceRequirement(),
+		}
+	}
+
 	if len(fc.Args()) != 1 {
 		cmd.ui.Failed(T("Incorrect Usage. Requires APP_NAME as argument\n\n") + commandregistry.Commands.CommandUsage("create-app-manifest"))
 	}
@@ -69,19 +87,26 @@ func (cmd *CreateAppManifest) SetDependency(deps commandregistry.Dependency, plu
 	cmd.config = deps.Config
 	cmd.appSummaryRepo = deps.RepoLocator.GetAppSummaryRepository()
 	cmd.stackRepo = deps.RepoLocator.GetStackRepository()
+	cmd.serviceRepo = deps.RepoLocator.GetServiceRepository()
 	cmd.manifest = deps.AppManifest
 	return cmd
 }
 
 func (cmd *CreateAppManifest) Execute(c flags.FlagContext) error {
+	cmd.placeholders = placeholders{}
+
+	if c.Bool("all") {
+		return cmd.createSpaceManifest(c)
+	}
+
 	application, apiErr := cmd.appSummaryRepo.GetSummary(cmd.appReq.GetApplication().GUID)
 	if apiErr != nil {
-		return errors.New(T("Error getting application summary: ") + apiErr.Error())
+		return errors.NewWrappedError(T("Error getting application summary: ")+apiErr.Error(), apiErr)
 	}
 
 	stack, err := cmd.stackRepo.FindByGUID(application.StackGUID)
 	if err != nil {
-		return errors.New(T("Error retrieving stack: ") + err.Error())
+		return errors.NewWrappedError(T("Error retrieving stack: ")+err.Error(), err)
 	}
 
 	application.Stack = &stack
@@ -97,17 +122,106 @@ func (cmd *CreateAppManifest) Execute(c flags.FlagContext) error {
 
 	f, err := os.Create(savePath)
 	if err != nil {
-		return errors.New(T("Error creating manifest file: ") + err.Error())
+		return errors.NewWrappedError(T("Error creating manifest file: ")+err.Error(), err)
 	}
 	defer f.Close()
 
-	err = cmd.createManifest(application)
+	err = cmd.createManifest(application, c.Bool("redact-env"))
 	if err != nil {
 		return err
 	}
-	err = cmd.manifest.Save(f)
+
+	return cmd.saveManifest(f, savePath)
+}
+
+// createSpaceManifest creates one manifest for all apps in the targeted
+// space and for the service instances bound to them. Service instance
+// parameters and user-provided credentials cannot be read back, so they are
+// written as ((SERVICE_NAME.parameters)) and ((SERVICE_NAME.credentials))
+// placeholders.
+func (cmd *CreateAppManifest) createSpaceManifest(c flags.FlagContext) error {
+	spaceName := cmd.config.SpaceFields().Name
+	cmd.ui.Say(T("Creating a manifest from current settings of all apps in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(spaceName),
+			"Username":  terminal.EntityNameColor(cmd.config.Username()),
+		}))
+	cmd.ui.Say("")
+
+	summaries, err := cmd.appSummaryRepo.GetSummariesInCurrentSpace()
+	if err != nil {
+		return errors.NewWrappedError(T("Error getting application summaries: ")+err.Error(), err)
+	}
+
+	if len(summaries) == 0 {
+		return errors.New(T("No apps found in space {{.SpaceName}}", map[string]interface{}{"SpaceName": spaceName}))
+	}
+
+	sort.Sort(appsByName(summaries))
+
+	stacksByGUID := map[string]models.Stack{}
+	var serviceNames []string
+	for _, summary := range summaries {
+		application, err := cmd.appSummaryRepo.GetSummary(summary.GUID)
+		if err != nil {
+			return errors.NewWrappedError(T("Error getting application summary: ")+err.Error(), err)
+		}
+
+		stack, found := stacksByGUID[application.StackGUID]
+		if !found {
+			stack, err = cmd.stackRepo.FindByGUID(application.StackGUID)
+			if err != nil {
+				return errors.NewWrappedError(T("Error retrieving stack: ")+err.Error(), err)
+			}
+			stacksByGUID[application.StackGUID] = stack
+		}
+		application.Stack = &stack
+
+		err = cmd.createManifest(application, c.Bool("redact-env"))
+		if err != nil {
+			return err
+		}
+
+		for _, service := range application.Services {
+			serviceNames = append(serviceNames, service.Name)
+		}
+	}
+
+	for _, serviceName := range removeDuplicates(serviceNames) {
+		serviceInstance, err := cmd.serviceRepo.FindInstanceByName(serviceName)
+		if err != nil {
+			return errors.NewWrappedError(T("Error getting service instance {{.ServiceName}}: {{.Err}}",
+				map[string]interface{}{"ServiceName": serviceName, "Err": err.Error()}), err)
+		}
+
+		if serviceInstance.IsUserProvided() {
+			cmd.manifest.UserProvidedServiceInstance(serviceName, cmd.placeholders.placeholder(serviceName, "credentials"))
+		} else {
+			cmd.manifest.ServiceInstance(serviceName, serviceInstance.ServiceOffering.Label, serviceInstance.ServicePlan.Name, serviceInstance.Tags,
+				cmd.placeholders.placeholder(serviceName, "parameters"))
+		}
+	}
+
+	savePath := "./" + spaceName + "_manifest.yml"
+
+	if c.String("p") != "" {
+		savePath = c.String("p")
+	}
+
+	f, err := os.Create(savePath)
 	if err != nil {
-		return errors.New(T("Error creating manifest file: ") + err.Error())
+		return errors.NewWrappedError(T("Error creating manifest file: ")+err.Error(), err)
+	}
+	defer f.Close()
+
+	return cmd.saveManifest(f, savePath)
+}
+
+func (cmd *CreateAppManifest) saveManifest(f *os.File, savePath string) error {
+	err := cmd.manifest.Save(f)
+	if err != nil {
+		return errors.NewWrappedError(T("Error creating manifest file: ")+err.Error(), err)
 	}
 
 	cmd.ui.Ok()
@@ -116,7 +230,7 @@ func (cmd *CreateAppManifest) Execute(c flags.FlagContext) error {
 	return nil
 }
 
-func (cmd *CreateAppManifest) createManifest(app models.Application) error {
+func (cmd *CreateAppManifest) createManifest(app models.Application, redactEnv bool) error {
 	cmd.manifest.Memory(app.Name, app.Memory)
 	cmd.manifest.Instances(app.Name, app.InstanceCount)
 	cmd.manifest.Stack(app.Name, app.Stack.Name)
@@ -133,12 +247,20 @@ func (cmd *CreateAppManifest) createManifest(app models.Application) error {
 		cmd.manifest.BuildpackURL(app.Name, app.BuildpackURL)
 	}
 
+	if app.DockerImage != "" {
+		cmd.manifest.DockerImage(app.Name, app.DockerImage)
+	}
+
 	if len(app.Services) > 0 {
 		for _, service := range app.Services {
 			cmd.manifest.Service(app.Name, service.Name)
 		}
 	}
 
+	if app.HealthCheckType != "" {
+		cmd.manifest.HealthCheckType(app.Name, app.HealthCheckType)
+	}
+
 	if app.HealthCheckTimeout > 0 {
 		cmd.manifest.HealthCheckTimeout(app.Name, app.HealthCheckTimeout)
 	}
@@ -146,6 +268,11 @@ func (cmd *CreateAppManifest) createManifest(app models.Application) error {
 	if len(app.EnvironmentVars) > 0 {
 		sorted := sortEnvVar(app.EnvironmentVars)
 		for _, envVarKey := range sorted {
+			if redactEnv {
+				cmd.manifest.EnvironmentVars(app.Name, envVarKey, cmd.placeholders.placeholder(app.Name, envVarKey))
+				continue
+			}
+
 			switch app.EnvironmentVars[envVarKey].(type) {
 			default:
 				return errors.New(T("Failed to create manifest, unable to parse environment variable: ") + envVarKey)
@@ -174,6 +301,48 @@ func (cmd *CreateAppManifest) createManifest(app models.Application) error {
 	return nil
 }
 
+var invalidVariableCharsRegex = regexp.MustCompile(`[^\w-]`)
+
+// placeholders holds the variables of the placeholders in a manifest.
+type placeholders map[string]bool
+
+// placeholder returns a manifest variable placeholder for the names, such
+// as ((my-app.DATABASE_URL)), that push fills in from --var or --vars-file.
+// Names that only differ in characters variables cannot hold, such as
+// my.app and my_app, are numbered so that they get variables of their own.
+func (used placeholders) placeholder(names ...string) string {
+	for i, name := range names {
+		names[i] = invalidVariableCharsRegex.ReplaceAllString(name, "_")
+	}
+
+	variable := strings.Join(names, ".")
+	unique := variable
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", variable, i)
+	}
+	used[unique] = true
+
+	return fmt.Sprintf("((%s))", unique)
+}
+
+func removeDuplicates(names []string) []string {
+	seen := map[string]bool{}
+	var unique []string
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+	return unique
+}
+
+type appsByName []models.Application
+
+func (apps appsByName) Len() int           { return len(apps) }
+func (apps appsByName) Swap(i, j int)      { apps[i], apps[j] = apps[j], apps[i] }
+func (apps appsByName) Less(i, j int) bool { return apps[i].Name < apps[j].Name }
+
 func sortEnvVar(vars map[string]interface{}) []string {
 	var varsAry []string
 	for k := range vars {
@@ -94,6 +94,32 @@ var _ = Describe("CreateAppManifest", func() {
 			})
 		})
 
+		Context("when --all is passed", func() {
+			BeforeEach(func() {
+				flagContext.Parse("--all")
+			})
+
+			It("returns a LoginRequirement and a TargetedSpaceRequirement without an ApplicationRequirement", func() {
+				actualRequirements := cmd.Requirements(factory, flagContext)
+				Expect(actualRequirements).To(Equal([]requirements.Requirement{loginRequirement, targetedSpaceRequirement}))
+				Expect(factory.NewApplicationRequirementCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when --all is passed with an app name", func() {
+			BeforeEach(func() {
+				flagContext.Parse("--all", "app-name")
+			})
+
+			It("fails with usage", func() {
+				Expect(func() { cmd.Requirements(factory, flagContext) }).To(Panic())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Incorrect Usage. No argument required with --all"},
+				))
+			})
+		})
+
 		Context("when provided exactly one arg", func() {
 			BeforeEach(func() {
 				flagContext.Parse("app-name")
@@ -370,6 +396,91 @@ var _ = Describe("CreateAppManifest", func() {
 				})
 			})
 
+			Context("when --redact-env is passed", func() {
+				BeforeEach(func() {
+					application.Name = "my.app"
+					application.EnvironmentVars = map[string]interface{}{
+						"DATABASE_URL": "postgres://user:secret@db",
+						"WORKERS":      float64(5),
+					}
+					appSummaryRepo.GetSummaryReturns(application, nil)
+
+					err := flagContext.Parse("app-name", "--redact-env")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				AfterEach(func() {
+					os.Remove("my.app_manifest.yml")
+				})
+
+				It("replaces the values with variable placeholders", func() {
+					Expect(runCLIErr).NotTo(HaveOccurred())
+					Expect(fakeManifest.EnvironmentVarsCallCount()).To(Equal(2))
+
+					name, key, value := fakeManifest.EnvironmentVarsArgsForCall(0)
+					Expect(name).To(Equal("my.app"))
+					Expect(key).To(Equal("DATABASE_URL"))
+					Expect(value).To(Equal("((my_app.DATABASE_URL))"))
+
+					_, key, value = fakeManifest.EnvironmentVarsArgsForCall(1)
+					Expect(key).To(Equal("WORKERS"))
+					Expect(value).To(Equal("((my_app.WORKERS))"))
+				})
+
+				Context("when names only differ in characters variables cannot hold", func() {
+					BeforeEach(func() {
+						application.EnvironmentVars = map[string]interface{}{
+							"CACHE.URL": "redis://cache-1",
+							"CACHE_URL": "redis://cache-2",
+						}
+						appSummaryRepo.GetSummaryReturns(application, nil)
+					})
+
+					It("gives each value a variable of its own", func() {
+						Expect(runCLIErr).NotTo(HaveOccurred())
+						Expect(fakeManifest.EnvironmentVarsCallCount()).To(Equal(2))
+
+						_, key, value := fakeManifest.EnvironmentVarsArgsForCall(0)
+						Expect(key).To(Equal("CACHE.URL"))
+						Expect(value).To(Equal("((my_app.CACHE_URL))"))
+
+						_, key, value = fakeManifest.EnvironmentVarsArgsForCall(1)
+						Expect(key).To(Equal("CACHE_URL"))
+						Expect(value).To(Equal("((my_app.CACHE_URL_2))"))
+					})
+				})
+			})
+
+			Context("when the app has a health check type", func() {
+				BeforeEach(func() {
+					application.HealthCheckType = "none"
+					appSummaryRepo.GetSummaryReturns(application, nil)
+				})
+
+				It("sets the health check type", func() {
+					Expect(runCLIErr).NotTo(HaveOccurred())
+					Expect(fakeManifest.HealthCheckTypeCallCount()).To(Equal(1))
+					name, healthCheckType := fakeManifest.HealthCheckTypeArgsForCall(0)
+					Expect(name).To(Equal("app-name"))
+					Expect(healthCheckType).To(Equal("none"))
+				})
+			})
+
+			Context("when the app has a docker image", func() {
+				BeforeEach(func() {
+					application.DockerImage = "cloudfoundry/lattice-app"
+					appSummaryRepo.GetSummaryReturns(application, nil)
+				})
+
+				It("sets the docker image", func() {
+					Expect(runCLIErr).NotTo(HaveOccurred())
+					Expect(fakeManifest.DockerImageCallCount()).To(Equal(1))
+					name, image := fakeManifest.DockerImageArgsForCall(0)
+					Expect(name).To(Equal("app-name"))
+					Expect(image).To(Equal("cloudfoundry/lattice-app"))
+				})
+			})
+
 			Context("when the app has an environment var of an unsupported type", func() {
 				BeforeEach(func() {
 					application.EnvironmentVars = map[string]interface{}{
@@ -443,4 +554,147 @@ var _ = Describe("CreateAppManifest", func() {
 			})
 		})
 	})
+
+	Describe("Execute with --all", func() {
+		var (
+			serviceRepo *apifakes.FakeServiceRepository
+			runCLIErr   error
+		)
+
+		BeforeEach(func() {
+			serviceRepo = new(apifakes.FakeServiceRepository)
+			deps.RepoLocator = deps.RepoLocator.SetServiceRepository(serviceRepo)
+			cmd.SetDependency(deps, false)
+
+			err := flagContext.Parse("--all")
+			Expect(err).NotTo(HaveOccurred())
+			cmd.Requirements(factory, flagContext)
+
+			web := models.Application{}
+			web.GUID = "web-guid"
+			web.Name = "web"
+			worker := models.Application{}
+			worker.GUID = "worker-guid"
+			worker.Name = "worker"
+			appSummaryRepo.GetSummariesInCurrentSpaceReturns([]models.Application{worker, web}, nil)
+
+			appSummaryRepo.GetSummaryStub = func(guid string) (models.Application, error) {
+				app := models.Application{}
+				app.GUID = guid
+				app.StackGUID = "the-stack-guid"
+				app.Memory = 256
+				app.InstanceCount = 1
+				app.DiskQuota = 1024
+				switch guid {
+				case "web-guid":
+					app.Name = "web"
+					app.HealthCheckType = "http"
+					app.Routes = []models.RouteSummary{{Host: "web", Domain: models.DomainFields{Name: "example.com"}, Path: "/api"}}
+					app.Services = []models.ServicePlanSummary{{Name: "db"}, {Name: "smtp"}}
+				case "worker-guid":
+					app.Name = "worker"
+					app.DockerImage = "example/worker"
+					app.HealthCheckType = "none"
+					app.Services = []models.ServicePlanSummary{{Name: "db"}}
+				}
+				return app, nil
+			}
+
+			stackRepo.FindByGUIDReturns(models.Stack{Name: "cflinuxfs2"}, nil)
+
+			serviceRepo.FindInstanceByNameStub = func(name string) (models.ServiceInstance, error) {
+				serviceInstance := models.ServiceInstance{}
+				serviceInstance.Name = name
+				if name == "db" {
+					serviceInstance.ServicePlan = models.ServicePlanFields{GUID: "small-guid", Name: "small"}
+					serviceInstance.ServiceOffering = models.ServiceOfferingFields{Label: "postgres"}
+					serviceInstance.Tags = []string{"sql"}
+				}
+				return serviceInstance, nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			runCLIErr = cmd.Execute(flagContext)
+		})
+
+		AfterEach(func() {
+			os.Remove("my-space_manifest.yml")
+		})
+
+		It("adds every app in the space, ordered by name", func() {
+			Expect(runCLIErr).NotTo(HaveOccurred())
+			Expect(appSummaryRepo.GetSummaryCallCount()).To(Equal(2))
+			Expect(appSummaryRepo.GetSummaryArgsForCall(0)).To(Equal("web-guid"))
+			Expect(appSummaryRepo.GetSummaryArgsForCall(1)).To(Equal("worker-guid"))
+
+			Expect(fakeManifest.MemoryCallCount()).To(Equal(2))
+			name, _ := fakeManifest.MemoryArgsForCall(0)
+			Expect(name).To(Equal("web"))
+
+			name, host, domain, path, _ := fakeManifest.RouteArgsForCall(0)
+			Expect([]string{name, host, domain, path}).To(Equal([]string{"web", "web", "example.com", "/api"}))
+
+			name, image := fakeManifest.DockerImageArgsForCall(0)
+			Expect(name).To(Equal("worker"))
+			Expect(image).To(Equal("example/worker"))
+
+			Expect(fakeManifest.HealthCheckTypeCallCount()).To(Equal(2))
+		})
+
+		It("looks up each stack once", func() {
+			Expect(runCLIErr).NotTo(HaveOccurred())
+			Expect(stackRepo.FindByGUIDCallCount()).To(Equal(1))
+		})
+
+		It("adds the service instances the apps are bound to", func() {
+			Expect(runCLIErr).NotTo(HaveOccurred())
+			Expect(serviceRepo.FindInstanceByNameCallCount()).To(Equal(2))
+
+			Expect(fakeManifest.ServiceInstanceCallCount()).To(Equal(1))
+			name, offering, plan, tags, parameters := fakeManifest.ServiceInstanceArgsForCall(0)
+			Expect(name).To(Equal("db"))
+			Expect(offering).To(Equal("postgres"))
+			Expect(plan).To(Equal("small"))
+			Expect(tags).To(Equal([]string{"sql"}))
+			Expect(parameters).To(Equal("((db.parameters))"))
+
+			Expect(fakeManifest.UserProvidedServiceInstanceCallCount()).To(Equal(1))
+			name, credentials := fakeManifest.UserProvidedServiceInstanceArgsForCall(0)
+			Expect(name).To(Equal("smtp"))
+			Expect(credentials).To(Equal("((smtp.credentials))"))
+		})
+
+		It("saves the manifest named after the space", func() {
+			Expect(runCLIErr).NotTo(HaveOccurred())
+			Expect(fakeManifest.SaveCallCount()).To(Equal(1))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Creating a manifest from current settings of all apps in org my-org / space my-space as my-user..."},
+				[]string{"OK"},
+				[]string{"Manifest file created successfully at ./my-space_manifest.yml"},
+			))
+		})
+
+		Context("when there are no apps in the space", func() {
+			BeforeEach(func() {
+				appSummaryRepo.GetSummariesInCurrentSpaceReturns([]models.Application{}, nil)
+			})
+
+			It("returns an error", func() {
+				Expect(runCLIErr).To(MatchError("No apps found in space my-space"))
+				Expect(fakeManifest.SaveCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when a service instance cannot be found", func() {
+			BeforeEach(func() {
+				serviceRepo.FindInstanceByNameReturns(models.ServiceInstance{}, errors.New("not-found"))
+				serviceRepo.FindInstanceByNameStub = nil
+			})
+
+			It("returns an error", func() {
+				Expect(runCLIErr).To(MatchError("Error getting service instance db: not-found"))
+			})
+		})
+	})
 })
@@ -3,12 +3,12 @@ package commands
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/util"
@@ -101,7 +101,7 @@ func (cmd *Curl) Execute(c flags.FlagContext) error {
 
 	responseHeader, responseBody, apiErr := cmd.curlRepo.Request(method, path, reqHeader, body)
 	if apiErr != nil {
-		return errors.New(T("Error creating request:\n{{.Err}}", map[string]interface{}{"Err": apiErr.Error()}))
+		return errors.NewWrappedError(T("Error creating request:\n{{.Err}}", map[string]interface{}{"Err": apiErr.Error()}), apiErr)
 	}
 
 	if trace.LoggingToStdout {
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
+	"github.com/cloudfoundry/cli/cf/flags"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+)
+
+type DeleteTarget struct {
+	ui     terminal.UI
+	config coreconfig.ReadWriter
+}
+
+func init() {
+	commandregistry.Register(&DeleteTarget{})
+}
+
+func (cmd *DeleteTarget) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Force deletion without confirmation")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "delete-target",
+		Description: T("Delete a saved target"),
+		Usage: []string{
+			T("CF_NAME delete-target NAME [-f]"),
+		},
+		Flags:     fs,
+		TotalArgs: 1,
+	}
+}
+
+func (cmd *DeleteTarget) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires target name as argument\n\n") + commandregistry.Commands.CommandUsage("delete-target"))
+	}
+
+	reqs := []requirements.Requirement{}
+	return reqs
+}
+
+func (cmd *DeleteTarget) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	return cmd
+}
+
+func (cmd *DeleteTarget) Execute(c flags.FlagContext) error {
+	name := c.Args()[0]
+
+	if !c.Bool("f") {
+		if !cmd.ui.ConfirmDelete(T("target"), name) {
+			return nil
+		}
+	}
+
+	cmd.ui.Say(T("Deleting saved target {{.TargetName}}...",
+		map[string]interface{}{"TargetName": terminal.EntityNameColor(name)}))
+
+	err := cmd.config.DeleteTarget(name)
+	if err != nil {
+		if _, ok := err.(*errors.ModelNotFoundError); ok {
+			cmd.ui.Ok()
+			cmd.ui.Warn(T("Target {{.TargetName}} does not exist.", map[string]interface{}{"TargetName": name}))
+			return nil
+		}
+		return err
+	}
+
+	cmd.ui.Ok()
+	return nil
+}
@@ -0,0 +1,74 @@
+package commands_test
+
+import (
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/requirements/requirementsfakes"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("delete-target command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		config              coreconfig.Repository
+		requirementsFactory *requirementsfakes.FakeFactory
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("delete-target").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		config = testconfig.NewRepositoryWithDefaults()
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+
+		config.SaveTarget("staging")
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("delete-target", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	It("fails with usage when no name is provided", func() {
+		runCommand()
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Requires target name as argument"},
+		))
+	})
+
+	It("asks for confirmation before deleting", func() {
+		ui.Inputs = []string{"n"}
+		runCommand("staging")
+
+		Expect(ui.Prompts).To(ContainSubstrings([]string{"Really delete the target", "staging"}))
+		Expect(config.SavedTargets()).To(HaveLen(1))
+	})
+
+	It("deletes the saved target when forced", func() {
+		runCommand("-f", "staging")
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Deleting saved target", "staging"},
+			[]string{"OK"},
+		))
+		Expect(config.SavedTargets()).To(BeEmpty())
+	})
+
+	It("warns when the target does not exist", func() {
+		runCommand("-f", "nope")
+
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"OK"}))
+		Expect(ui.WarnOutputs).To(ContainSubstrings([]string{"Target nope does not exist."}))
+	})
+})
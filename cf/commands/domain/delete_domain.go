@@ -75,8 +75,8 @@ func (cmd *DeleteDomain) Execute(c flags.FlagContext) error {
 		cmd.ui.Warn(err.Error())
 		return nil
 	default:
-		return errors.New(T("Error finding domain {{.DomainName}}\n{{.APIErr}}",
-			map[string]interface{}{"DomainName": domainName, "APIErr": err.Error()}))
+		return errors.NewWrappedError(T("Error finding domain {{.DomainName}}\n{{.APIErr}}",
+			map[string]interface{}{"DomainName": domainName, "APIErr": err.Error()}), err)
 	}
 
 	if !c.Bool("f") {
@@ -92,8 +92,8 @@ func (cmd *DeleteDomain) Execute(c flags.FlagContext) error {
 
 	err = cmd.domainRepo.Delete(domain.GUID)
 	if err != nil {
-		return errors.New(T("Error deleting domain {{.DomainName}}\n{{.APIErr}}",
-			map[string]interface{}{"DomainName": domainName, "APIErr": err.Error()}))
+		return errors.NewWrappedError(T("Error deleting domain {{.DomainName}}\n{{.APIErr}}",
+			map[string]interface{}{"DomainName": domainName, "APIErr": err.Error()}), err)
 	}
 
 	cmd.ui.Ok()
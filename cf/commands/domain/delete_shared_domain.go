@@ -80,10 +80,10 @@ func (cmd *DeleteSharedDomain) Execute(c flags.FlagContext) error {
 		cmd.ui.Warn(err.Error())
 		return nil
 	default:
-		return errors.New(T("Error finding domain {{.DomainName}}\n{{.Err}}",
+		return errors.NewWrappedError(T("Error finding domain {{.DomainName}}\n{{.Err}}",
 			map[string]interface{}{
 				"DomainName": domainName,
-				"Err":        err.Error()}))
+				"Err":        err.Error()}), err)
 	}
 
 	if !force {
@@ -96,8 +96,8 @@ func (cmd *DeleteSharedDomain) Execute(c flags.FlagContext) error {
 
 	err = cmd.domainRepo.DeleteSharedDomain(domain.GUID)
 	if err != nil {
-		return errors.New(T("Error deleting domain {{.DomainName}}\n{{.Err}}",
-			map[string]interface{}{"DomainName": domainName, "Err": err.Error()}))
+		return errors.NewWrappedError(T("Error deleting domain {{.DomainName}}\n{{.Err}}",
+			map[string]interface{}{"DomainName": domainName, "Err": err.Error()}), err)
 	}
 
 	cmd.ui.Ok()
@@ -1,11 +1,10 @@
 package domain
 
 import (
-	"errors"
-
 	"github.com/cloudfoundry/cli/cf/api"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
@@ -26,8 +25,9 @@ func init() {
 
 func (cmd *ListDomains) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "domains",
-		Description: T("List domains in the target org"),
+		Name:             "domains",
+		StructuredOutput: true,
+		Description:      T("List domains in the target org"),
 		Usage: []string{
 			"CF_NAME domains",
 		},
@@ -70,7 +70,7 @@ func (cmd *ListDomains) Execute(c flags.FlagContext) error {
 
 	domains, err := cmd.getDomains(org.GUID)
 	if err != nil {
-		return errors.New(T("Failed fetching domains.\n{{.Error}}", map[string]interface{}{"Error": err.Error()}))
+		return errors.NewWrappedError(T("Failed fetching domains.\n{{.Error}}", map[string]interface{}{"Error": err.Error()}), err)
 	}
 
 	table := cmd.ui.Table([]string{T("name"), T("status"), T("type")})
@@ -22,9 +22,10 @@ func init() {
 
 func (cmd *RunningEnvironmentVariableGroup) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "running-environment-variable-group",
-		Description: T("Retrieve the contents of the running environment variable group"),
-		ShortName:   "revg",
+		Name:             "running-environment-variable-group",
+		StructuredOutput: true,
+		Description:      T("Retrieve the contents of the running environment variable group"),
+		ShortName:        "revg",
 		Usage: []string{
 			T("CF_NAME running-environment-variable-group"),
 		},
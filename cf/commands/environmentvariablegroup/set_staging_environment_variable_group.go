@@ -1,11 +1,10 @@
 package environmentvariablegroup
 
 import (
-	"errors"
-
 	"github.com/cloudfoundry/cli/cf/api/environmentvariablegroups"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	cf_errors "github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
@@ -66,7 +65,7 @@ func (cmd *SetStagingEnvironmentVariableGroup) Execute(c flags.FlagContext) erro
 
 Your JSON string syntax is invalid.  Proper syntax is this:  cf set-staging-environment-variable-group '{"name":"value","name":"value"}'`)
 		}
-		return errors.New(err.Error() + suggestionText)
+		return errors.NewWrappedError(err.Error()+suggestionText, err)
 	}
 
 	cmd.ui.Ok()
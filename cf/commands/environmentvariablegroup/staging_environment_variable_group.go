@@ -22,9 +22,10 @@ func init() {
 
 func (cmd *StagingEnvironmentVariableGroup) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "staging-environment-variable-group",
-		Description: T("Retrieve the contents of the staging environment variable group"),
-		ShortName:   "sevg",
+		Name:             "staging-environment-variable-group",
+		StructuredOutput: true,
+		Description:      T("Retrieve the contents of the staging environment variable group"),
+		ShortName:        "sevg",
 		Usage: []string{
 			T("CF_NAME staging-environment-variable-group"),
 		},
@@ -22,8 +22,9 @@ func init() {
 
 func (cmd *ShowFeatureFlag) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "feature-flag",
-		Description: T("Retrieve an individual feature flag with status"),
+		Name:             "feature-flag",
+		StructuredOutput: true,
+		Description:      T("Retrieve an individual feature flag with status"),
 		Usage: []string{
 			T("CF_NAME feature-flag FEATURE_NAME"),
 		},
@@ -22,8 +22,9 @@ func init() {
 
 func (cmd *ListFeatureFlags) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "feature-flags",
-		Description: T("Retrieve list of feature flags with status of each flag-able feature"),
+		Name:             "feature-flags",
+		StructuredOutput: true,
+		Description:      T("Retrieve list of feature flags with status of each flag-able feature"),
 		Usage: []string{
 			T("CF_NAME feature-flags"),
 		},
@@ -1,8 +1,8 @@
 package commands
 
 import (
-	"errors"
 	"strconv"
+	"strings"
 
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/flags"
@@ -12,6 +12,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/api/organizations"
 	"github.com/cloudfoundry/cli/cf/api/spaces"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/models"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
@@ -41,6 +42,7 @@ func (cmd *Login) MetaData() commandregistry.CommandMetadata {
 	fs["o"] = &flags.StringFlag{ShortName: "o", Usage: T("Org")}
 	fs["s"] = &flags.StringFlag{ShortName: "s", Usage: T("Space")}
 	fs["sso"] = &flags.BoolFlag{Name: "sso", Usage: T("Use a one-time password to login")}
+	fs["sso-passcode"] = &flags.StringFlag{Name: "sso-passcode", Usage: T("One-time passcode, to login without prompting for it")}
 	fs["skip-ssl-validation"] = &flags.BoolFlag{Name: "skip-ssl-validation", Usage: T("Skip verification of the API endpoint. Not recommended!")}
 
 	return commandregistry.CommandMetadata{
@@ -57,6 +59,7 @@ func (cmd *Login) MetaData() commandregistry.CommandMetadata {
 			T("CF_NAME login -u name@example.com -p \"my password\" (use quotes for passwords with a space)"),
 			T("CF_NAME login -u name@example.com -p \"\\\"password\\\"\" (escape quotes if used in password)"),
 			T("CF_NAME login --sso (CF_NAME will provide a url to obtain a one-time password to login)"),
+			T("CF_NAME login --sso-passcode PASSCODE (log in with a one-time passcode obtained beforehand)"),
 		},
 		Flags: fs,
 	}
@@ -80,14 +83,25 @@ func (cmd *Login) SetDependency(deps commandregistry.Dependency, pluginCall bool
 func (cmd *Login) Execute(c flags.FlagContext) error {
 	cmd.config.ClearSession()
 
-	endpoint, skipSSL := cmd.decideEndpoint(c)
+	endpoint, skipSSL, err := cmd.decideEndpoint(c)
+	if err != nil {
+		return err
+	}
+
+	if endpointChanged(endpoint, cmd.config.APIEndpoint()) {
+		// the TLS files and proxy given to cf api are not used for other
+		// endpoints
+		cmd.config.SetCACertFile("")
+		cmd.config.SetClientCertificate("", "")
+		cmd.config.SetProxy("", "")
+	}
 
 	api := API{
 		ui:           cmd.ui,
 		config:       cmd.config,
 		endpointRepo: cmd.endpointRepo,
 	}
-	err := api.setAPIEndpoint(endpoint, skipSSL, cmd.MetaData().Name)
+	err = api.setAPIEndpoint(endpoint, skipSSL, cmd.MetaData().Name)
 	if err != nil {
 		return err
 	}
@@ -107,7 +121,7 @@ func (cmd *Login) Execute(c flags.FlagContext) error {
 	//   EITHER   username and password
 	//   OR       a one-time passcode
 
-	if c.Bool("sso") {
+	if c.Bool("sso") || c.String("sso-passcode") != "" {
 		err = cmd.authenticateSSO(c)
 		if err != nil {
 			return err
@@ -134,7 +148,7 @@ func (cmd *Login) Execute(c flags.FlagContext) error {
 	return nil
 }
 
-func (cmd Login) decideEndpoint(c flags.FlagContext) (string, bool) {
+func (cmd Login) decideEndpoint(c flags.FlagContext) (string, bool, error) {
 	endpoint := c.String("a")
 	skipSSL := c.Bool("skip-ssl-validation")
 	if endpoint == "" {
@@ -143,12 +157,25 @@ func (cmd Login) decideEndpoint(c flags.FlagContext) (string, bool) {
 	}
 
 	if endpoint == "" {
+		if !cmd.ui.Interactive() {
+			return "", false, terminal.NewNonInteractiveError(T("API endpoint"), "-a")
+		}
 		endpoint = cmd.ui.Ask(T("API endpoint"))
 	} else {
 		cmd.ui.Say(T("API endpoint: {{.Endpoint}}", map[string]interface{}{"Endpoint": terminal.EntityNameColor(endpoint)}))
 	}
 
-	return endpoint, skipSSL
+	return endpoint, skipSSL, nil
+}
+
+// endpointChanged reports whether endpoint, which may be given without a
+// scheme, is another API than the targeted one.
+func endpointChanged(endpoint, current string) bool {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	if !strings.Contains(endpoint, "://") {
+		current = strings.TrimPrefix(strings.TrimPrefix(current, "https://"), "http://")
+	}
+	return endpoint != current
 }
 
 func (cmd Login) authenticateSSO(c flags.FlagContext) error {
@@ -159,9 +186,17 @@ func (cmd Login) authenticateSSO(c flags.FlagContext) error {
 
 	credentials := make(map[string]string)
 	passcode := prompts["passcode"]
+	passcodeFlagValue := c.String("sso-passcode")
 
 	for i := 0; i < maxLoginTries; i++ {
-		credentials["passcode"] = cmd.ui.AskForPassword(passcode.DisplayName)
+		if passcodeFlagValue != "" {
+			credentials["passcode"] = passcodeFlagValue
+			passcodeFlagValue = ""
+		} else if !cmd.ui.Interactive() {
+			return terminal.NewNonInteractiveError(passcode.DisplayName, "--sso-passcode")
+		} else {
+			credentials["passcode"] = cmd.ui.AskForPassword(passcode.DisplayName)
+		}
 
 		cmd.ui.Say(T("Authenticating..."))
 		err = cmd.authenticator.Authenticate(credentials)
@@ -173,10 +208,13 @@ func (cmd Login) authenticateSSO(c flags.FlagContext) error {
 		}
 
 		cmd.ui.Say(err.Error())
+		if !cmd.ui.Interactive() {
+			break
+		}
 	}
 
 	if err != nil {
-		return errors.New(T("Unable to authenticate."))
+		return errors.NewNotLoggedInError(T("Unable to authenticate."))
 	}
 	return nil
 }
@@ -195,6 +233,8 @@ func (cmd Login) authenticate(c flags.FlagContext) error {
 	if value, ok := prompts["username"]; ok {
 		if prompts["username"].Type == coreconfig.AuthPromptTypeText && usernameFlagValue != "" {
 			credentials["username"] = usernameFlagValue
+		} else if !cmd.ui.Interactive() {
+			return terminal.NewNonInteractiveError(value.DisplayName, "-u")
 		} else {
 			credentials["username"] = cmd.ui.Ask(value.DisplayName)
 		}
@@ -209,6 +249,8 @@ func (cmd Login) authenticate(c flags.FlagContext) error {
 			passwordKeys = append(passwordKeys, key)
 		} else if key == "username" {
 			continue
+		} else if !cmd.ui.Interactive() {
+			return terminal.NewNonInteractiveError(prompt.DisplayName, "")
 		} else {
 			credentials[key] = cmd.ui.Ask(prompt.DisplayName)
 		}
@@ -219,6 +261,12 @@ func (cmd Login) authenticate(c flags.FlagContext) error {
 			if key == "password" && passwordFlagValue != "" {
 				credentials[key] = passwordFlagValue
 				passwordFlagValue = ""
+			} else if !cmd.ui.Interactive() {
+				flag := ""
+				if key == "password" {
+					flag = "-p"
+				}
+				return terminal.NewNonInteractiveError(prompts[key].DisplayName, flag)
 			} else {
 				credentials[key] = cmd.ui.AskForPassword(prompts[key].DisplayName)
 			}
@@ -239,10 +287,13 @@ func (cmd Login) authenticate(c flags.FlagContext) error {
 		}
 
 		cmd.ui.Say(err.Error())
+		if !cmd.ui.Interactive() {
+			break
+		}
 	}
 
 	if err != nil {
-		return errors.New(T("Unable to authenticate."))
+		return errors.NewNotLoggedInError(T("Unable to authenticate."))
 	}
 	return nil
 }
@@ -253,8 +304,8 @@ func (cmd Login) setOrganization(c flags.FlagContext) (bool, error) {
 	if orgName == "" {
 		orgs, err := cmd.orgRepo.ListOrgs(maxChoices)
 		if err != nil {
-			return false, errors.New(T("Error finding available orgs\n{{.APIErr}}",
-				map[string]interface{}{"APIErr": err.Error()}))
+			return false, errors.NewWrappedError(T("Error finding available orgs\n{{.APIErr}}",
+				map[string]interface{}{"APIErr": err.Error()}), err)
 		}
 
 		switch len(orgs) {
@@ -264,6 +315,9 @@ func (cmd Login) setOrganization(c flags.FlagContext) (bool, error) {
 			cmd.targetOrganization(orgs[0])
 			return true, nil
 		default:
+			if !cmd.ui.Interactive() {
+				return false, terminal.NewNonInteractiveError(T("Select an org"), "-o")
+			}
 			orgName = cmd.promptForOrgName(orgs)
 			if orgName == "" {
 				cmd.ui.Say("")
@@ -274,8 +328,8 @@ func (cmd Login) setOrganization(c flags.FlagContext) (bool, error) {
 
 	org, err := cmd.orgRepo.FindByName(orgName)
 	if err != nil {
-		return false, errors.New(T("Error finding org {{.OrgName}}\n{{.Err}}",
-			map[string]interface{}{"OrgName": terminal.EntityNameColor(orgName), "Err": err.Error()}))
+		return false, errors.NewWrappedError(T("Error finding org {{.OrgName}}\n{{.Err}}",
+			map[string]interface{}{"OrgName": terminal.EntityNameColor(orgName), "Err": err.Error()}), err)
 	}
 
 	cmd.targetOrganization(org)
@@ -307,8 +361,8 @@ func (cmd Login) setSpace(c flags.FlagContext) error {
 			return (len(availableSpaces) < maxChoices)
 		})
 		if err != nil {
-			return errors.New(T("Error finding available spaces\n{{.Err}}",
-				map[string]interface{}{"Err": err.Error()}))
+			return errors.NewWrappedError(T("Error finding available spaces\n{{.Err}}",
+				map[string]interface{}{"Err": err.Error()}), err)
 		}
 
 		if len(availableSpaces) == 0 {
@@ -317,6 +371,9 @@ func (cmd Login) setSpace(c flags.FlagContext) error {
 			cmd.targetSpace(availableSpaces[0])
 			return nil
 		} else {
+			if !cmd.ui.Interactive() {
+				return terminal.NewNonInteractiveError(T("Select a space"), "-s")
+			}
 			spaceName = cmd.promptForSpaceName(availableSpaces)
 			if spaceName == "" {
 				cmd.ui.Say("")
@@ -327,8 +384,8 @@ func (cmd Login) setSpace(c flags.FlagContext) error {
 
 	space, err := cmd.spaceRepo.FindByName(spaceName)
 	if err != nil {
-		return errors.New(T("Error finding space {{.SpaceName}}\n{{.Err}}",
-			map[string]interface{}{"SpaceName": terminal.EntityNameColor(spaceName), "Err": err.Error()}))
+		return errors.NewWrappedError(T("Error finding space {{.SpaceName}}\n{{.Err}}",
+			map[string]interface{}{"SpaceName": terminal.EntityNameColor(spaceName), "Err": err.Error()}), err)
 	}
 
 	cmd.targetSpace(space)
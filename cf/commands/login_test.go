@@ -533,6 +533,95 @@ var _ = Describe("Login Command", func() {
 		})
 	})
 
+	Context("non-interactive usage", func() {
+		BeforeEach(func() {
+			ui.NonInteractive = true
+			endpointRepo.GetCCInfoReturns(&coreconfig.CCInfo{
+				APIVersion:            "some-version",
+				AuthorizationEndpoint: "auth/endpoint",
+				LoggregatorEndpoint:   "loggregator/endpoint",
+			}, "api.example.com", nil)
+
+			org2 := models.Organization{}
+			org2.Name = "some-org"
+			orgRepo.ListOrgsReturns([]models.Organization{org, org2}, nil)
+			orgRepo.FindByNameReturns(org, nil)
+		})
+
+		It("logs in with the values of the flags without prompting", func() {
+			Flags = []string{"-a", "api.example.com", "-u", "the-username", "-p", "the-password", "-o", "my-new-org"}
+
+			testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+			Expect(ui.Prompts).To(BeEmpty())
+			Expect(ui.PasswordPrompts).To(BeEmpty())
+			Expect(authRepo.AuthenticateArgsForCall(0)).To(Equal(map[string]string{
+				"username": "the-username",
+				"password": "the-password",
+			}))
+			Expect(Config.OrganizationFields().Name).To(Equal("my-new-org"))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"FAILED"}))
+		})
+
+		It("fails naming -a when there is no API endpoint", func() {
+			testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+			Expect(ui.Prompts).To(BeEmpty())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Cannot prompt for 'API endpoint' in non-interactive mode. Use -a instead."},
+			))
+		})
+
+		It("fails naming -u and -p when the credentials are missing", func() {
+			Flags = []string{"-a", "api.example.com"}
+			testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Cannot prompt for 'Username' in non-interactive mode. Use -u instead."}))
+
+			Flags = []string{"-a", "api.example.com", "-u", "the-username"}
+			testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Cannot prompt for 'Password' in non-interactive mode. Use -p instead."}))
+			Expect(authRepo.AuthenticateCallCount()).To(Equal(0))
+		})
+
+		It("tries the password only once", func() {
+			authRepo.AuthenticateReturns(errors.New("Error authenticating."))
+			Flags = []string{"-a", "api.example.com", "-u", "the-username", "-p", "the-password"}
+
+			testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+			Expect(authRepo.AuthenticateCallCount()).To(Equal(1))
+			Expect(ui.PasswordPrompts).To(BeEmpty())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Unable to authenticate."}))
+		})
+
+		It("fails naming -o when there is more than one org to choose from", func() {
+			Flags = []string{"-a", "api.example.com", "-u", "the-username", "-p", "the-password"}
+
+			testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+			Expect(ui.Prompts).To(BeEmpty())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Cannot prompt for 'Select an org' in non-interactive mode. Use -o instead."}))
+		})
+
+		It("takes the one-time passcode from --sso-passcode", func() {
+			authRepo.GetLoginPromptsAndSaveUAAServerURLReturns(map[string]coreconfig.AuthPrompt{
+				"passcode": {
+					DisplayName: "One Time Code",
+					Type:        coreconfig.AuthPromptTypePassword,
+				},
+			}, nil)
+			Flags = []string{"-a", "api.example.com", "--sso-passcode", "the-one-time-code", "-o", "my-new-org"}
+
+			testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+			Expect(ui.PasswordPrompts).To(BeEmpty())
+			Expect(authRepo.AuthenticateArgsForCall(0)).To(Equal(map[string]string{
+				"passcode": "the-one-time-code",
+			}))
+		})
+	})
+
 	Describe("updates to the config", func() {
 		BeforeEach(func() {
 			Config.SetAPIEndpoint("api.the-old-endpoint.com")
@@ -581,6 +670,41 @@ var _ = Describe("Login Command", func() {
 			})
 		}
 
+		Describe("the TLS files and proxy given to cf api", func() {
+			BeforeEach(func() {
+				Config.SetAPIEndpoint("https://api.the-old-endpoint.com")
+				Config.SetCACertFile("/path/to/ca.pem")
+				Config.SetClientCertificate("/path/to/client.pem", "/path/to/client-key.pem")
+				Config.SetProxy("http://proxy.example.com:8080", "")
+			})
+
+			Context("when the user logs in to another API", func() {
+				BeforeEach(func() {
+					Flags = []string{"-a", "https://api.the-server.com", "-u", "the-user-name", "-p", "the-password"}
+				})
+
+				It("clears them", func() {
+					Expect(Config.CACertFile()).To(BeEmpty())
+					Expect(Config.ClientCertFile()).To(BeEmpty())
+					Expect(Config.ClientKeyFile()).To(BeEmpty())
+					Expect(Config.ProxyURL()).To(BeEmpty())
+				})
+			})
+
+			Context("when the user logs in to the same API", func() {
+				BeforeEach(func() {
+					Flags = []string{"-a", "api.the-old-endpoint.com/", "-u", "the-user-name", "-p", "the-password"}
+				})
+
+				It("keeps them", func() {
+					Expect(Config.CACertFile()).To(Equal("/path/to/ca.pem"))
+					Expect(Config.ClientCertFile()).To(Equal("/path/to/client.pem"))
+					Expect(Config.ClientKeyFile()).To(Equal("/path/to/client-key.pem"))
+					Expect(Config.ProxyURL()).To(Equal("http://proxy.example.com:8080"))
+				})
+			})
+		})
+
 		Describe("when the user is setting an API", func() {
 			BeforeEach(func() {
 				Flags = []string{"-a", "https://api.the-server.com", "-u", "the-user-name", "-p", "the-password"}
@@ -113,7 +113,7 @@ func (cmd *CreateOrg) Execute(c flags.FlagContext) error {
 		if setRolesByUsernameFlag.Enabled {
 			org, err := cmd.orgRepo.FindByName(name)
 			if err != nil {
-				return errors.New(T("Error accessing org {{.OrgName}} for GUID': ", map[string]interface{}{"Orgname": name}) + err.Error() + "\n" + T("Skip assigning org role to user"))
+				return errors.NewWrappedError(T("Error accessing org {{.OrgName}} for GUID': ", map[string]interface{}{"Orgname": name})+err.Error()+"\n"+T("Skip assigning org role to user"), err)
 			}
 
 			cmd.ui.Say("")
@@ -126,7 +126,7 @@ func (cmd *CreateOrg) Execute(c flags.FlagContext) error {
 
 			err = cmd.orgRoleSetter.SetOrgRole(org.GUID, models.RoleOrgManager, "", cmd.config.Username())
 			if err != nil {
-				return errors.New(T("Failed assigning org role to user: ") + err.Error())
+				return errors.NewWrappedError(T("Failed assigning org role to user: ")+err.Error(), err)
 			}
 
 			cmd.ui.Ok()
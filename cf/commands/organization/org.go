@@ -12,6 +12,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/formatters"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/records"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/plugin/models"
@@ -33,8 +34,9 @@ func (cmd *ShowOrg) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["guid"] = &flags.BoolFlag{Name: "guid", Usage: T("Retrieve and display the given org's guid.  All other output for the org is suppressed.")}
 	return commandregistry.CommandMetadata{
-		Name:        "org",
-		Description: T("Show org info"),
+		Name:             "org",
+		StructuredOutput: true,
+		Description:      T("Show org info"),
 		Usage: []string{
 			T("CF_NAME org ORG"),
 		},
@@ -70,6 +72,8 @@ func (cmd *ShowOrg) Execute(c flags.FlagContext) error {
 
 	if c.Bool("guid") {
 		cmd.ui.Say(org.GUID)
+	} else if cmd.ui.OutputFormat().IsStructured() && !cmd.pluginCall {
+		return cmd.ui.Output(records.NewOrgDetail(org))
 	} else {
 		cmd.ui.Say(T("Getting info for org {{.OrgName}} as {{.Username}}...",
 			map[string]interface{}{
@@ -9,6 +9,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/records"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/plugin/models"
@@ -30,9 +31,10 @@ func init() {
 
 func (cmd *ListOrgs) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "orgs",
-		ShortName:   "o",
-		Description: T("List all orgs"),
+		Name:             "orgs",
+		StructuredOutput: true,
+		ShortName:        "o",
+		Description:      T("List all orgs"),
 		Usage: []string{
 			"CF_NAME orgs",
 		},
@@ -75,6 +77,10 @@ func (cmd ListOrgs) Execute(fc flags.FlagContext) error {
 	if err != nil {
 		return err
 	}
+	if cmd.ui.OutputFormat().IsStructured() && !cmd.pluginCall {
+		return cmd.ui.Output(records.NewOrgs(orgs))
+	}
+
 	for _, org := range orgs {
 		table.Add(org.Name)
 		noOrgs = false
@@ -1,7 +1,6 @@
 package plugin
 
 import (
-	"errors"
 	"fmt"
 	"net/rpc"
 	"os"
@@ -13,6 +12,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/requirements"
@@ -179,12 +179,11 @@ func (cmd *PluginInstall) ensurePluginBinaryWithSameFileNameDoesNotAlreadyExist(
 			}),
 		)
 	} else if !os.IsNotExist(err) {
-		return errors.New(T(
+		return errors.NewWrappedError(T(
 			"Unexpected error has occurred:\n{{.Error}}",
 			map[string]interface{}{
 				"Error": err.Error(),
-			}),
-		)
+			}), err)
 	}
 	return nil
 }
@@ -272,12 +271,11 @@ func (cmd *PluginInstall) ensurePluginIsSafeForInstallation(pluginMetadata *plug
 func (cmd *PluginInstall) installPlugin(pluginMetadata *plugin.PluginMetadata, pluginDestinationFilepath, pluginSourceFilepath string) error {
 	err := fileutils.CopyPathToPath(pluginSourceFilepath, pluginDestinationFilepath)
 	if err != nil {
-		return errors.New(T(
+		return errors.NewWrappedError(T(
 			"Could not copy plugin binary: \n{{.Error}}",
 			map[string]interface{}{
 				"Error": err.Error(),
-			}),
-		)
+			}), err)
 	}
 
 	configMetadata := pluginconfig.PluginMetadata{
@@ -26,8 +26,9 @@ func (cmd *Plugins) MetaData() commandregistry.CommandMetadata {
 	fs["checksum"] = &flags.BoolFlag{Name: "checksum", Usage: T("Compute and show the sha1 value of the plugin binary file")}
 
 	return commandregistry.CommandMetadata{
-		Name:        "plugins",
-		Description: T("List all available plugin commands"),
+		Name:             "plugins",
+		StructuredOutput: true,
+		Description:      T("List all available plugin commands"),
 		Usage: []string{
 			T("CF_NAME plugins"),
 		},
@@ -2,7 +2,6 @@ package pluginrepo
 
 import (
 	"encoding/json"
-	"errors"
 	"io/ioutil"
 	"net"
 	"net/http"
@@ -11,6 +10,7 @@ import (
 
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	"github.com/cloudfoundry/cli/cf/models"
 	"github.com/cloudfoundry/cli/cf/requirements"
@@ -79,17 +79,17 @@ func (cmd *AddPluginRepo) Execute(c flags.FlagContext) error {
 		if urlErr, ok := err.(*url.Error); ok {
 			if opErr, opErrOk := urlErr.Err.(*net.OpError); opErrOk {
 				if opErr.Op == "dial" {
-					return errors.New(T("There is an error performing request on '{{.RepoURL}}': {{.Error}}\n{{.Tip}}", map[string]interface{}{
+					return errors.NewWrappedError(T("There is an error performing request on '{{.RepoURL}}': {{.Error}}\n{{.Tip}}", map[string]interface{}{
 						"RepoURL": repoURL,
 						"Error":   err.Error(),
 						"Tip":     T("TIP: If you are behind a firewall and require an HTTP proxy, verify the https_proxy environment variable is correctly set. Else, check your network connection."),
-					}))
+					}), err)
 				}
 			}
 		}
-		return errors.New(T("There is an error performing request on '{{.RepoURL}}': ", map[string]interface{}{
+		return errors.NewWrappedError(T("There is an error performing request on '{{.RepoURL}}': ", map[string]interface{}{
 			"RepoURL": repoURL,
-		}, err.Error()))
+		}, err.Error()), err)
 	}
 	defer resp.Body.Close()
 
@@ -99,13 +99,13 @@ func (cmd *AddPluginRepo) Execute(c flags.FlagContext) error {
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return errors.New(T("Error reading response from server: ") + err.Error())
+		return errors.NewWrappedError(T("Error reading response from server: ")+err.Error(), err)
 	}
 
 	result := clipr.PluginsJson{}
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return errors.New(T("Error processing data from server: ") + err.Error())
+		return errors.NewWrappedError(T("Error processing data from server: ")+err.Error(), err)
 	}
 
 	if result.Plugins == nil {
@@ -21,8 +21,9 @@ func init() {
 
 func (cmd *ListPluginRepos) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "list-plugin-repos",
-		Description: T("List all the added plugin repositories"),
+		Name:             "list-plugin-repos",
+		StructuredOutput: true,
+		Description:      T("List all the added plugin repositories"),
 		Usage: []string{
 			T("CF_NAME list-plugin-repos"),
 		},
@@ -32,8 +32,9 @@ func (cmd *RepoPlugins) MetaData() commandregistry.CommandMetadata {
 	fs["r"] = &flags.StringFlag{ShortName: "r", Usage: T("Name of a registered repository")}
 
 	return commandregistry.CommandMetadata{
-		Name:        T("repo-plugins"),
-		Description: T("List all available plugins in specified repository or in all added repositories"),
+		Name:             T("repo-plugins"),
+		StructuredOutput: true,
+		Description:      T("List all available plugins in specified repository or in all added repositories"),
 		Usage: []string{
 			T(`CF_NAME repo-plugins [-r REPO_NAME]`),
 		},
@@ -28,7 +28,8 @@ func init() {
 
 func (cmd *showQuota) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name: "quota",
+		Name:             "quota",
+		StructuredOutput: true,
 		Usage: []string{
 			T("CF_NAME quota QUOTA"),
 		},
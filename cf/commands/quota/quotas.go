@@ -28,8 +28,9 @@ func init() {
 
 func (cmd *ListQuotas) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "quotas",
-		Description: T("List available usage quotas"),
+		Name:             "quotas",
+		StructuredOutput: true,
+		Description:      T("List available usage quotas"),
 		Usage: []string{
 			T("CF_NAME quotas"),
 		},
@@ -1,11 +1,10 @@
 package route
 
 import (
-	"errors"
-
 	"github.com/cloudfoundry/cli/cf/api"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
@@ -89,7 +88,7 @@ func (cmd *DeleteOrphanedRoutes) Execute(c flags.FlagContext) error {
 	})
 
 	if err != nil {
-		return errors.New(T("Failed fetching routes.\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+		return errors.NewWrappedError(T("Failed fetching routes.\n{{.Err}}", map[string]interface{}{"Err": err.Error()}), err)
 	}
 	cmd.ui.Ok()
 	return nil
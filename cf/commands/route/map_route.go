@@ -1,13 +1,13 @@
 package route
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/cloudfoundry/cli/cf"
 	"github.com/cloudfoundry/cli/cf/api"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/requirements"
@@ -132,7 +132,7 @@ func (cmd *MapRoute) Execute(c flags.FlagContext) error {
 	randomPort := c.Bool("random-port")
 	route, err := cmd.routeCreator.CreateRoute(hostName, path, port, randomPort, domain, cmd.config.SpaceFields())
 	if err != nil {
-		return errors.New(T("Error resolving route:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+		return errors.NewWrappedError(T("Error resolving route:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}), err)
 	}
 	cmd.ui.Say(T("Adding route {{.URL}} to app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
 		map[string]interface{}{
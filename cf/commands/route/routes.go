@@ -1,10 +1,10 @@
 package route
 
 import (
-	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 
@@ -12,6 +12,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/records"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 )
@@ -21,6 +22,7 @@ type ListRoutes struct {
 	routeRepo  api.RouteRepository
 	domainRepo api.DomainRepository
 	config     coreconfig.Reader
+	pluginCall bool
 }
 
 func init() {
@@ -32,9 +34,10 @@ func (cmd *ListRoutes) MetaData() commandregistry.CommandMetadata {
 	fs["orglevel"] = &flags.BoolFlag{Name: "orglevel", Usage: T("List all the routes for all spaces of current organization")}
 
 	return commandregistry.CommandMetadata{
-		Name:        "routes",
-		ShortName:   "r",
-		Description: T("List all routes in the current space or the current organization"),
+		Name:             "routes",
+		StructuredOutput: true,
+		ShortName:        "r",
+		Description:      T("List all routes in the current space or the current organization"),
 		Usage: []string{
 			"CF_NAME routes [--orglevel]",
 		},
@@ -64,6 +67,7 @@ func (cmd *ListRoutes) SetDependency(deps commandregistry.Dependency, pluginCall
 	cmd.config = deps.Config
 	cmd.routeRepo = deps.RepoLocator.GetRouteRepository()
 	cmd.domainRepo = deps.RepoLocator.GetDomainRepository()
+	cmd.pluginCall = pluginCall
 	return cmd
 }
 
@@ -93,12 +97,14 @@ func (cmd *ListRoutes) Execute(c flags.FlagContext) error {
 		return true
 	})
 	if err != nil {
-		return errors.New(T("Failed fetching domains for organization %s.\n{{.Err}}", cmd.config.OrganizationFields().Name, map[string]interface{}{"Err": err.Error()}))
+		return errors.NewWrappedError(T("Failed fetching domains for organization %s.\n{{.Err}}", cmd.config.OrganizationFields().Name, map[string]interface{}{"Err": err.Error()}), err)
 	}
 
 	var routesFound bool
+	routeRecords := []records.Route{}
 	cb := func(route models.Route) bool {
 		routesFound = true
+		routeRecords = append(routeRecords, records.NewRoute(route, d[route.Domain.GUID]))
 		appNames := []string{}
 		for _, app := range route.Apps {
 			appNames = append(appNames, app.Name)
@@ -130,9 +136,16 @@ func (cmd *ListRoutes) Execute(c flags.FlagContext) error {
 		err = cmd.routeRepo.ListRoutes(cb)
 	}
 
-	table.Print()
+	structured := cmd.ui.OutputFormat().IsStructured() && !cmd.pluginCall
+	if !structured {
+		table.Print()
+	}
 	if err != nil {
-		return errors.New(T("Failed fetching routes.\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+		return errors.NewWrappedError(T("Failed fetching routes.\n{{.Err}}", map[string]interface{}{"Err": err.Error()}), err)
+	}
+
+	if structured {
+		return cmd.ui.Output(routeRecords)
 	}
 
 	if !routesFound {
@@ -18,6 +18,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/cloudfoundry/cli/cf/commands/route"
+	cferrors "github.com/cloudfoundry/cli/cf/errors"
 	. "github.com/cloudfoundry/cli/testhelpers/matchers"
 )
 
@@ -160,6 +161,56 @@ var _ = Describe("routes command", func() {
 			Expect(terminal.Decolorize(ui.Outputs()[5])).To(MatchRegexp(`^my-space\s+cookieclicker\.co\s+9090\s+tcp\s+dora,bora\s*$`))
 
 		})
+
+		It("prints a record for each route when the output format is csv", func() {
+			ui.Format = terminal.CSVOutput
+			runCommand()
+
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"space", "host", "domain"}))
+			Expect(ui.StructuredOutput()).To(Equal(`guid,url,space,host,domain,port,path,type,apps,service
+,hostname-1.example.com,my-space,hostname-1,example.com,0,,,"[""dora""]",test-service
+,hostname-2.cookieclicker.co/foo,my-space,hostname-2,cookieclicker.co,0,/foo,,"[""dora"",""bora""]",
+,cookieclicker.co:9090,my-space,,cookieclicker.co,9090,,tcp,"[""dora"",""bora""]",
+`))
+		})
+
+		It("prints the fields of the routes selected with a JSONPath template", func() {
+			ui.JSONPath = "{[*].host}"
+			runCommand()
+
+			Expect(ui.StructuredOutput()).To(Equal("hostname-1 hostname-2 \n"))
+		})
+
+		It("sorts the routes and prints only the chosen columns", func() {
+			ui.SortBy = "-host"
+			ui.Columns = "host,domain"
+			runCommand()
+
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"space", "port"}))
+			Expect(terminal.Decolorize(ui.Outputs()[2])).To(MatchRegexp(`^host\s+domain\s*$`))
+			Expect(terminal.Decolorize(ui.Outputs()[3])).To(MatchRegexp(`^hostname-2\s+cookieclicker\.co\s*$`))
+			Expect(terminal.Decolorize(ui.Outputs()[4])).To(MatchRegexp(`^hostname-1\s+example\.com\s*$`))
+			Expect(terminal.Decolorize(ui.Outputs()[5])).To(MatchRegexp(`^\s+cookieclicker\.co\s*$`))
+		})
+
+		It("filters the records when the output format is csv", func() {
+			ui.Format = terminal.CSVOutput
+			ui.Filter = "type=tcp"
+			ui.Columns = "url,type"
+			runCommand()
+
+			Expect(ui.StructuredOutput()).To(Equal("url,type\ncookieclicker.co:9090,tcp\n"))
+		})
+
+		It("fails when a column is unknown", func() {
+			ui.SortBy = "memory"
+			runCommand()
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Unknown column"},
+			))
+		})
 	})
 
 	Context("when there are routes in different spaces", func() {
@@ -226,6 +277,16 @@ var _ = Describe("routes command", func() {
 			routeRepo.ListRoutesReturns(errors.New("an-error"))
 		})
 
+		It("keeps the exit code of the error", func() {
+			routeRepo.ListRoutesReturns(cferrors.NewNetworkError("connection refused", nil))
+			updateCommandDependency(false)
+
+			cmd := commandregistry.Commands.FindCommand("routes")
+			err := cmd.Execute(flags.NewFlagContext(cmd.MetaData().Flags))
+			Expect(err).To(MatchError(ContainSubstring("Failed fetching routes.")))
+			Expect(cferrors.ExitCode(err)).To(Equal(cferrors.ExitCodeNetwork))
+		})
+
 		It("returns an error to the user", func() {
 			runCommand()
 
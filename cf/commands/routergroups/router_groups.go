@@ -1,11 +1,10 @@
 package routergroups
 
 import (
-	"errors"
-
 	"github.com/cloudfoundry/cli/cf/api"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
@@ -25,8 +24,9 @@ func init() {
 
 func (cmd *RouterGroups) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "router-groups",
-		Description: T("List router groups"),
+		Name:             "router-groups",
+		StructuredOutput: true,
+		Description:      T("List router groups"),
 		Usage: []string{
 			"CF_NAME router-groups",
 		},
@@ -69,7 +69,7 @@ func (cmd *RouterGroups) Execute(c flags.FlagContext) error {
 
 	apiErr := cmd.routingAPIRepo.ListRouterGroups(cb)
 	if apiErr != nil {
-		return errors.New(T("Failed fetching router groups.\n{{.Err}}", map[string]interface{}{"Err": apiErr.Error()}))
+		return errors.NewWrappedError(T("Failed fetching router groups.\n{{.Err}}", map[string]interface{}{"Err": apiErr.Error()}), apiErr)
 	}
 
 	if noRouterGroups {
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"github.com/cloudfoundry/cli/cf"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/flags"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+)
+
+type SaveTarget struct {
+	ui     terminal.UI
+	config coreconfig.ReadWriter
+}
+
+func init() {
+	commandregistry.Register(&SaveTarget{})
+}
+
+func (cmd *SaveTarget) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "save-target",
+		Description: T("Save the current api endpoint, session, org and space under a name"),
+		Usage: []string{
+			T("CF_NAME save-target NAME"),
+		},
+		Examples: []string{
+			"CF_NAME save-target staging",
+		},
+		TotalArgs: 1,
+	}
+}
+
+func (cmd *SaveTarget) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires target name as argument\n\n") + commandregistry.Commands.CommandUsage("save-target"))
+	}
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewAPIEndpointRequirement(),
+	}
+	return reqs
+}
+
+func (cmd *SaveTarget) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	return cmd
+}
+
+func (cmd *SaveTarget) Execute(c flags.FlagContext) error {
+	name := c.Args()[0]
+
+	cmd.ui.Say(T("Saving target {{.APIEndpoint}} as {{.TargetName}}...",
+		map[string]interface{}{
+			"APIEndpoint": terminal.EntityNameColor(cmd.config.APIEndpoint()),
+			"TargetName":  terminal.EntityNameColor(name),
+		}))
+
+	cmd.config.SaveTarget(name)
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Use '{{.Command}}' to switch back to this target.",
+		map[string]interface{}{"Command": terminal.CommandColor(cf.Name + " switch-target " + name)}))
+	return nil
+}
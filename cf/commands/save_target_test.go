@@ -0,0 +1,76 @@
+package commands_test
+
+import (
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/requirements/requirementsfakes"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("save-target command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		config              coreconfig.Repository
+		requirementsFactory *requirementsfakes.FakeFactory
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("save-target").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		config = testconfig.NewRepositoryWithDefaults()
+		config.SetAPIEndpoint("https://api.example.com")
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewAPIEndpointRequirementReturns(requirements.Passing{})
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("save-target", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("requirements", func() {
+		It("fails with usage when no name is provided", func() {
+			runCommand()
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires target name as argument"},
+			))
+		})
+
+		It("fails when no api endpoint is set", func() {
+			requirementsFactory.NewAPIEndpointRequirementReturns(requirements.Failing{Message: "no api set"})
+
+			Expect(runCommand("staging")).To(BeFalse())
+		})
+	})
+
+	It("saves the current target under the given name", func() {
+		runCommand("staging")
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Saving target", "https://api.example.com", "staging"},
+			[]string{"OK"},
+			[]string{"switch-target staging"},
+		))
+
+		targets := config.SavedTargets()
+		Expect(targets).To(HaveLen(1))
+		Expect(targets[0].Name).To(Equal("staging"))
+		Expect(targets[0].Target).To(Equal("https://api.example.com"))
+		Expect(targets[0].OrganizationFields.Name).To(Equal("my-org"))
+		Expect(targets[0].SpaceFields.Name).To(Equal("my-space"))
+		Expect(config.CurrentTargetName()).To(Equal("staging"))
+	})
+})
@@ -26,8 +26,9 @@ func init() {
 
 func (cmd *ShowSecurityGroup) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "security-group",
-		Description: T("Show a single security group"),
+		Name:             "security-group",
+		StructuredOutput: true,
+		Description:      T("Show a single security group"),
 		Usage: []string{
 			T("CF_NAME security-group SECURITY_GROUP"),
 		},
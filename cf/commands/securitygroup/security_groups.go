@@ -26,8 +26,9 @@ func init() {
 
 func (cmd *SecurityGroups) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "security-groups",
-		Description: T("List all security groups"),
+		Name:             "security-groups",
+		StructuredOutput: true,
+		Description:      T("List all security groups"),
 		Usage: []string{
 			"CF_NAME security-groups",
 		},
@@ -110,6 +110,9 @@ func (cmd *CreateUserProvidedService) Execute(c flags.FlagContext) error {
 		if err != nil {
 			for _, param := range strings.Split(credentials, ",") {
 				param = strings.Trim(param, " ")
+				if !cmd.ui.Interactive() {
+					return terminal.NewNonInteractiveError(param, T("-p with the credentials as JSON"))
+				}
 				credentialsMap[param] = cmd.ui.Ask(param)
 			}
 		}
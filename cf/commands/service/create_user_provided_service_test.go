@@ -12,6 +12,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/flags"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/requirements/requirementsfakes"
+	"github.com/cloudfoundry/cli/cf/terminal"
 
 	"github.com/cloudfoundry/cli/cf/api/apifakes"
 	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
@@ -256,6 +257,19 @@ var _ = Describe("CreateUserProvidedService", func() {
 					"key2": "value2",
 				}))
 			})
+
+			Context("when the UI is non-interactive", func() {
+				BeforeEach(func() {
+					ui.NonInteractive = true
+				})
+
+				It("returns an error naming the -p flag instead of prompting", func() {
+					Expect(runCLIErr).To(BeAssignableToTypeOf(&terminal.NonInteractiveError{}))
+					Expect(runCLIErr.Error()).To(ContainSubstring("-p with the credentials as JSON"))
+					Expect(ui.Prompts).To(BeEmpty())
+					Expect(serviceInstanceRepo.CreateCallCount()).To(BeZero())
+				})
+			})
 		})
 	})
 })
@@ -32,9 +32,10 @@ func (cmd *MarketplaceServices) MetaData() commandregistry.CommandMetadata {
 	fs["s"] = &flags.StringFlag{ShortName: "s", Usage: T("Show plan details for a particular service offering")}
 
 	return commandregistry.CommandMetadata{
-		Name:        "marketplace",
-		ShortName:   "m",
-		Description: T("List available offerings in the marketplace"),
+		Name:             "marketplace",
+		StructuredOutput: true,
+		ShortName:        "m",
+		Description:      T("List available offerings in the marketplace"),
 		Usage: []string{
 			"CF_NAME marketplace ",
 			fmt.Sprintf("[-s %s] ", T("SERVICE")),
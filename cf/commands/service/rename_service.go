@@ -72,11 +72,11 @@ func (cmd *RenameService) Execute(c flags.FlagContext) error {
 
 	if err != nil {
 		if httpError, ok := err.(errors.HTTPError); ok && httpError.ErrorCode() == errors.ServiceInstanceNameTaken {
-			return errors.New(T("{{.ErrorDescription}}\nTIP: Use '{{.CFServicesCommand}}' to view all services in this org and space.",
+			return errors.NewWrappedError(T("{{.ErrorDescription}}\nTIP: Use '{{.CFServicesCommand}}' to view all services in this org and space.",
 				map[string]interface{}{
 					"ErrorDescription":  httpError.Error(),
 					"CFServicesCommand": cf.Name + " " + "services",
-				}))
+				}), httpError)
 		}
 		return err
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/records"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/plugin/models"
@@ -30,8 +31,9 @@ func (cmd *ShowService) MetaData() commandregistry.CommandMetadata {
 	fs["guid"] = &flags.BoolFlag{Name: "guid", Usage: T("Retrieve and display the given service's guid.  All other output for the service is suppressed.")}
 
 	return commandregistry.CommandMetadata{
-		Name:        "service",
-		Description: T("Show service instance info"),
+		Name:             "service",
+		StructuredOutput: true,
+		Description:      T("Show service instance info"),
 		Usage: []string{
 			T("CF_NAME service SERVICE_INSTANCE"),
 		},
@@ -84,6 +86,8 @@ func (cmd *ShowService) Execute(c flags.FlagContext) error {
 
 	if c.Bool("guid") {
 		cmd.ui.Say(serviceInstance.GUID)
+	} else if cmd.ui.OutputFormat().IsStructured() {
+		return cmd.ui.Output(records.NewServiceInstanceDetail(serviceInstance, boundApps))
 	} else {
 		cmd.ui.Say("")
 		cmd.ui.Say(T("Service instance: {{.ServiceName}}", map[string]interface{}{"ServiceName": terminal.EntityNameColor(serviceInstance.Name)}))
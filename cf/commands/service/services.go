@@ -10,6 +10,7 @@ import (
 
 	"github.com/cloudfoundry/cli/cf/api"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/records"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 )
@@ -28,9 +29,10 @@ func init() {
 
 func (cmd *ListServices) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "services",
-		ShortName:   "s",
-		Description: T("List all service instances in the target space"),
+		Name:             "services",
+		StructuredOutput: true,
+		ShortName:        "s",
+		Description:      T("List all service instances in the target space"),
 		Usage: []string{
 			"CF_NAME services",
 		},
@@ -80,6 +82,10 @@ func (cmd *ListServices) Execute(fc flags.FlagContext) error {
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
+	if cmd.ui.OutputFormat().IsStructured() && !cmd.pluginCall {
+		return cmd.ui.Output(records.NewServiceInstances(serviceInstances))
+	}
+
 	if len(serviceInstances) == 0 {
 		cmd.ui.Say(T("No services found"))
 		return nil
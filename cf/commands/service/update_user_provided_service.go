@@ -108,6 +108,9 @@ func (cmd *UpdateUserProvidedService) Execute(c flags.FlagContext) error {
 		if err != nil {
 			for _, param := range strings.Split(credentials, ",") {
 				param = strings.Trim(param, " ")
+				if !cmd.ui.Interactive() {
+					return terminal.NewNonInteractiveError(param, T("-p with the credentials as JSON"))
+				}
 				credentialsMap[param] = cmd.ui.Ask(param)
 			}
 		}
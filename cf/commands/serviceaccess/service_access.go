@@ -33,8 +33,9 @@ func (cmd *ServiceAccess) MetaData() commandregistry.CommandMetadata {
 	fs["o"] = &flags.StringFlag{ShortName: "o", Usage: T("Plans accessible by a particular organization")}
 
 	return commandregistry.CommandMetadata{
-		Name:        "service-access",
-		Description: T("List service access settings"),
+		Name:             "service-access",
+		StructuredOutput: true,
+		Description:      T("List service access settings"),
 		Usage: []string{
 			"CF_NAME service-access [-b BROKER] [-e SERVICE] [-o ORG]",
 		},
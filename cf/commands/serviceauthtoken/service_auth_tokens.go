@@ -23,8 +23,9 @@ func init() {
 
 func (cmd *ListServiceAuthTokens) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "service-auth-tokens",
-		Description: T("List service auth tokens"),
+		Name:             "service-auth-tokens",
+		StructuredOutput: true,
+		Description:      T("List service auth tokens"),
 		Usage: []string{
 			T("CF_NAME service-auth-tokens"),
 		},
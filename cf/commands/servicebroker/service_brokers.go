@@ -32,8 +32,9 @@ func init() {
 
 func (cmd *ListServiceBrokers) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "service-brokers",
-		Description: T("List service brokers"),
+		Name:             "service-brokers",
+		StructuredOutput: true,
+		Description:      T("List service brokers"),
 		Usage: []string{
 			"CF_NAME service-brokers",
 		},
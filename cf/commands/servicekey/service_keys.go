@@ -25,9 +25,10 @@ func init() {
 
 func (cmd *ServiceKeys) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "service-keys",
-		ShortName:   "sk",
-		Description: T("List keys for a service instance"),
+		Name:             "service-keys",
+		StructuredOutput: true,
+		ShortName:        "sk",
+		Description:      T("List keys for a service instance"),
 		Usage: []string{
 			T("CF_NAME service-keys SERVICE_INSTANCE"),
 		},
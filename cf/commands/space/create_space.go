@@ -102,11 +102,11 @@ func (cmd *CreateSpace) Execute(c flags.FlagContext) error {
 		case *errors.ModelNotFoundError:
 			return errors.New(T("Org {{.OrgName}} does not exist or is not accessible", map[string]interface{}{"OrgName": orgName}))
 		default:
-			return errors.New(T("Error finding org {{.OrgName}}\n{{.ErrorDescription}}",
+			return errors.NewWrappedError(T("Error finding org {{.OrgName}}\n{{.ErrorDescription}}",
 				map[string]interface{}{
 					"OrgName":          orgName,
 					"ErrorDescription": err.Error(),
-				}))
+				}), err)
 		}
 
 		orgGUID = org.GUID
@@ -1,12 +1,12 @@
 package space
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/cloudfoundry/cli/cf/api/spaces"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/requirements"
@@ -70,7 +70,7 @@ func (cmd *DisallowSpaceSSH) Execute(fc flags.FlagContext) error {
 
 	err := cmd.spaceRepo.SetAllowSSH(space.GUID, false)
 	if err != nil {
-		return errors.New(T("Error disabling ssh support for space ") + space.Name + ": " + err.Error())
+		return errors.NewWrappedError(T("Error disabling ssh support for space ")+space.Name+": "+err.Error(), err)
 	}
 
 	cmd.ui.Ok()
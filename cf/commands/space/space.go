@@ -13,6 +13,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/api/spacequotas"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/formatters"
+	"github.com/cloudfoundry/cli/cf/records"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 )
@@ -35,8 +36,9 @@ func (cmd *ShowSpace) MetaData() commandregistry.CommandMetadata {
 	fs["guid"] = &flags.BoolFlag{Name: "guid", Usage: T("Retrieve and display the given space's guid.  All other output for the space is suppressed.")}
 	fs["security-group-rules"] = &flags.BoolFlag{Name: "security-group-rules", Usage: T("Retrieve the rules for all the security groups associated with the space")}
 	return commandregistry.CommandMetadata{
-		Name:        "space",
-		Description: T("Show space info"),
+		Name:             "space",
+		StructuredOutput: true,
+		Description:      T("Show space info"),
 		Usage: []string{
 			T("CF_NAME space SPACE"),
 		},
@@ -77,6 +79,8 @@ func (cmd *ShowSpace) Execute(c flags.FlagContext) error {
 	}
 	if c.Bool("guid") {
 		cmd.ui.Say(space.GUID)
+	} else if cmd.ui.OutputFormat().IsStructured() {
+		return cmd.outputSpace(space, c.Bool("security-group-rules"))
 	} else {
 		cmd.ui.Say(T("Getting info for space {{.TargetSpace}} in org {{.OrgName}} as {{.CurrentUser}}...",
 			map[string]interface{}{
@@ -142,6 +146,23 @@ func (cmd *ShowSpace) Execute(c flags.FlagContext) error {
 	return nil
 }
 
+func (cmd *ShowSpace) outputSpace(space models.Space, securityGroupRules bool) error {
+	var quota *models.SpaceQuota
+	if space.SpaceQuotaGUID != "" {
+		spaceQuota, err := cmd.quotaRepo.FindByGUID(space.SpaceQuotaGUID)
+		if err != nil {
+			return err
+		}
+		quota = &spaceQuota
+	}
+
+	record := records.NewSpaceDetail(space, quota)
+	if securityGroupRules {
+		record.AddSecurityGroupRules(space)
+	}
+	return cmd.ui.Output(record)
+}
+
 func (cmd *ShowSpace) quotaString(space models.Space) (string, error) {
 	if space.SpaceQuotaGUID == "" {
 		return "", nil
@@ -1,14 +1,14 @@
 package space
 
 import (
-	"errors"
-
 	"github.com/cloudfoundry/cli/cf/api/spaces"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/records"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/plugin/models"
@@ -29,8 +29,9 @@ func init() {
 
 func (cmd *ListSpaces) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "spaces",
-		Description: T("List all spaces in an org"),
+		Name:             "spaces",
+		StructuredOutput: true,
+		Description:      T("List all spaces in an org"),
 		Usage: []string{
 			T("CF_NAME spaces"),
 		},
@@ -72,8 +73,10 @@ func (cmd *ListSpaces) Execute(c flags.FlagContext) error {
 		}))
 
 	foundSpaces := false
+	spaceRecords := []records.Space{}
 	table := cmd.ui.Table([]string{T("name")})
 	err := cmd.spaceRepo.ListSpaces(func(space models.Space) bool {
+		spaceRecords = append(spaceRecords, records.NewSpace(space))
 		table.Add(space.Name)
 		foundSpaces = true
 
@@ -86,13 +89,20 @@ func (cmd *ListSpaces) Execute(c flags.FlagContext) error {
 
 		return true
 	})
-	table.Print()
 
+	structured := cmd.ui.OutputFormat().IsStructured() && !cmd.pluginCall
+	if !structured {
+		table.Print()
+	}
 	if err != nil {
-		return errors.New(T("Failed fetching spaces.\n{{.ErrorDescription}}",
+		return errors.NewWrappedError(T("Failed fetching spaces.\n{{.ErrorDescription}}",
 			map[string]interface{}{
 				"ErrorDescription": err.Error(),
-			}))
+			}), err)
+	}
+
+	if structured {
+		return cmd.ui.Output(spaceRecords)
 	}
 
 	if !foundSpaces {
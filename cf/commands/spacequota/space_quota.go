@@ -25,8 +25,9 @@ func init() {
 
 func (cmd *SpaceQuota) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "space-quota",
-		Description: T("Show space quota info"),
+		Name:             "space-quota",
+		StructuredOutput: true,
+		Description:      T("Show space quota info"),
 		Usage: []string{
 			T("CF_NAME space-quota SPACE_QUOTA_NAME"),
 		},
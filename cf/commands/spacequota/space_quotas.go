@@ -26,8 +26,9 @@ func init() {
 
 func (cmd *ListSpaceQuotas) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "space-quotas",
-		Description: T("List available space resource quotas"),
+		Name:             "space-quotas",
+		StructuredOutput: true,
+		Description:      T("List available space resource quotas"),
 		Usage: []string{
 			T("CF_NAME space-quotas"),
 		},
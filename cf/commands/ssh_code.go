@@ -1,8 +1,7 @@
 package commands
 
 import (
-	"errors"
-
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/flags"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 
@@ -85,17 +84,17 @@ func (cmd *OneTimeSSHCode) Get() (string, error) {
 
 	_, err := refresher.Refresh()
 	if err != nil {
-		return "", errors.New("Error refreshing config: " + err.Error())
+		return "", errors.NewWrappedError("Error refreshing config: "+err.Error(), err)
 	}
 
 	token, err := cmd.authRepo.RefreshAuthToken()
 	if err != nil {
-		return "", errors.New(T("Error refreshing oauth token: ") + err.Error())
+		return "", errors.NewWrappedError(T("Error refreshing oauth token: ")+err.Error(), err)
 	}
 
 	sshCode, err := cmd.authRepo.Authorize(token)
 	if err != nil {
-		return "", errors.New(T("Error getting SSH code: ") + err.Error())
+		return "", errors.NewWrappedError(T("Error getting SSH code: ")+err.Error(), err)
 	}
 
 	return sshCode, nil
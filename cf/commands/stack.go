@@ -25,8 +25,9 @@ func (cmd *ListStack) MetaData() commandregistry.CommandMetadata {
 	fs["guid"] = &flags.BoolFlag{Name: "guid", Usage: T("Retrieve and display the given stack's guid. All other output for the stack is suppressed.")}
 
 	return commandregistry.CommandMetadata{
-		Name:        "stack",
-		Description: T("Show information for a stack (a stack is a pre-built file system, including an operating system, that can run apps)"),
+		Name:             "stack",
+		StructuredOutput: true,
+		Description:      T("Show information for a stack (a stack is a pre-built file system, including an operating system, that can run apps)"),
 		Usage: []string{
 			T("CF_NAME stack STACK_NAME"),
 		},
@@ -22,8 +22,9 @@ func init() {
 
 func (cmd *ListStacks) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "stacks",
-		Description: T("List all stacks (a stack is a pre-built file system, including an operating system, that can run apps)"),
+		Name:             "stacks",
+		StructuredOutput: true,
+		Description:      T("List all stacks (a stack is a pre-built file system, including an operating system, that can run apps)"),
 		Usage: []string{
 			T("CF_NAME stacks"),
 		},
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"github.com/cloudfoundry/cli/cf"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
+	"github.com/cloudfoundry/cli/cf/flags"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+)
+
+type SwitchTarget struct {
+	ui     terminal.UI
+	config coreconfig.ReadWriter
+}
+
+func init() {
+	commandregistry.Register(&SwitchTarget{})
+}
+
+func (cmd *SwitchTarget) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "switch-target",
+		Description: T("Make a saved target the current api endpoint, session, org and space"),
+		Usage: []string{
+			T("CF_NAME switch-target NAME"),
+		},
+		Examples: []string{
+			"CF_NAME switch-target staging",
+		},
+		TotalArgs: 1,
+	}
+}
+
+func (cmd *SwitchTarget) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires target name as argument\n\n") + commandregistry.Commands.CommandUsage("switch-target"))
+	}
+
+	reqs := []requirements.Requirement{}
+	return reqs
+}
+
+func (cmd *SwitchTarget) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	return cmd
+}
+
+func (cmd *SwitchTarget) Execute(c flags.FlagContext) error {
+	name := c.Args()[0]
+
+	cmd.ui.Say(T("Switching to target {{.TargetName}}...",
+		map[string]interface{}{"TargetName": terminal.EntityNameColor(name)}))
+
+	err := cmd.config.SwitchTarget(name)
+	if err != nil {
+		if _, ok := err.(*errors.ModelNotFoundError); ok {
+			return errors.New(T("Target {{.TargetName}} not found. Use '{{.Command}}' to list saved targets.",
+				map[string]interface{}{
+					"TargetName": name,
+					"Command":    terminal.CommandColor(cf.Name + " targets"),
+				}))
+		}
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	cmd.ui.ShowConfiguration(cmd.config)
+	return nil
+}
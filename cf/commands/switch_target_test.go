@@ -0,0 +1,78 @@
+package commands_test
+
+import (
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/requirements/requirementsfakes"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("switch-target command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		config              coreconfig.Repository
+		requirementsFactory *requirementsfakes.FakeFactory
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("switch-target").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		config = testconfig.NewRepositoryWithDefaults()
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+
+		config.SetAPIEndpoint("https://api.staging.example.com")
+		config.SaveTarget("staging")
+
+		config.SetAPIEndpoint("https://api.prod.example.com")
+		config.SetOrganizationFields(models.OrganizationFields{Name: "prod-org", GUID: "prod-org-guid"})
+		config.SaveTarget("prod")
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("switch-target", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	It("fails with usage when no name is provided", func() {
+		runCommand()
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Requires target name as argument"},
+		))
+	})
+
+	It("switches to the saved target and shows it", func() {
+		runCommand("staging")
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Switching to target", "staging"},
+			[]string{"OK"},
+		))
+		Expect(ui.ShowConfigurationCalled).To(BeTrue())
+		Expect(config.APIEndpoint()).To(Equal("https://api.staging.example.com"))
+		Expect(config.OrganizationFields().Name).To(Equal("my-org"))
+		Expect(config.CurrentTargetName()).To(Equal("staging"))
+	})
+
+	It("fails when the target has not been saved", func() {
+		runCommand("nope")
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Target nope not found"},
+		))
+		Expect(config.APIEndpoint()).To(Equal("https://api.prod.example.com"))
+	})
+})
@@ -108,8 +108,8 @@ func (cmd Target) setOrganization(orgName string) error {
 
 	org, apiErr := cmd.orgRepo.FindByName(orgName)
 	if apiErr != nil {
-		return fmt.Errorf(T("Could not target org.\n{{.APIErr}}",
-			map[string]interface{}{"APIErr": apiErr.Error()}))
+		return errors.NewWrappedError(fmt.Sprintf(T("Could not target org.\n{{.APIErr}}",
+			map[string]interface{}{"APIErr": apiErr.Error()})), apiErr)
 	}
 
 	cmd.config.SetOrganizationFields(org.OrganizationFields)
@@ -125,8 +125,8 @@ func (cmd Target) setSpace(spaceName string) error {
 
 	space, apiErr := cmd.spaceRepo.FindByName(spaceName)
 	if apiErr != nil {
-		return fmt.Errorf(T("Unable to access space {{.SpaceName}}.\n{{.APIErr}}",
-			map[string]interface{}{"SpaceName": spaceName, "APIErr": apiErr.Error()}))
+		return errors.NewWrappedError(fmt.Sprintf(T("Unable to access space {{.SpaceName}}.\n{{.APIErr}}",
+			map[string]interface{}{"SpaceName": spaceName, "APIErr": apiErr.Error()})), apiErr)
 	}
 
 	cmd.config.SetSpaceFields(space.SpaceFields)
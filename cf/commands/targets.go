@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"github.com/cloudfoundry/cli/cf"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/flags"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+)
+
+type ListTargets struct {
+	ui     terminal.UI
+	config coreconfig.Reader
+}
+
+func init() {
+	commandregistry.Register(&ListTargets{})
+}
+
+func (cmd *ListTargets) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:             "targets",
+		StructuredOutput: true,
+		Description:      T("List all saved targets"),
+		Usage: []string{
+			T("CF_NAME targets"),
+		},
+	}
+}
+
+func (cmd *ListTargets) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	usageReq := requirements.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+		T("No argument required"),
+		func() bool {
+			return len(fc.Args()) != 0
+		},
+	)
+
+	reqs := []requirements.Requirement{
+		usageReq,
+	}
+	return reqs
+}
+
+func (cmd *ListTargets) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	return cmd
+}
+
+func (cmd *ListTargets) Execute(c flags.FlagContext) error {
+	cmd.ui.Say(T("Getting saved targets..."))
+
+	targets := cmd.config.SavedTargets()
+	if len(targets) == 0 {
+		cmd.ui.Ok()
+		cmd.ui.Say("")
+		cmd.ui.Say(T("No saved targets found. Use '{{.Command}}' to save the current target.",
+			map[string]interface{}{"Command": terminal.CommandColor(cf.Name + " save-target")}))
+		return nil
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	current := cmd.config.CurrentTargetName()
+	table := cmd.ui.Table([]string{"", T("name"), T("api endpoint"), T("org"), T("space"), T("user")})
+	for _, target := range targets {
+		marker := ""
+		if target.Name == current {
+			marker = "*"
+		}
+
+		table.Add(
+			marker,
+			target.Name,
+			target.Target,
+			target.OrganizationFields.Name,
+			target.SpaceFields.Name,
+			coreconfig.NewTokenInfo(target.AccessToken).Username,
+		)
+	}
+
+	table.Print()
+	return nil
+}
@@ -0,0 +1,74 @@
+package commands_test
+
+import (
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/requirements/requirementsfakes"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("targets command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		config              coreconfig.Repository
+		requirementsFactory *requirementsfakes.FakeFactory
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("targets").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		config = testconfig.NewRepositoryWithDefaults()
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("targets", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	It("fails with usage when arguments are provided", func() {
+		Expect(runCommand("blahblah")).To(BeFalse())
+	})
+
+	It("tells the user when there are no saved targets", func() {
+		runCommand()
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Getting saved targets..."},
+			[]string{"OK"},
+			[]string{"No saved targets found"},
+		))
+	})
+
+	It("lists the saved targets and marks the current one", func() {
+		config.SetAPIEndpoint("https://api.dev.example.com")
+		config.SaveTarget("dev")
+
+		config.SetAPIEndpoint("https://api.prod.example.com")
+		config.SetOrganizationFields(models.OrganizationFields{Name: "prod-org", GUID: "prod-org-guid"})
+		config.SetSpaceFields(models.SpaceFields{Name: "prod-space", GUID: "prod-space-guid"})
+		config.SaveTarget("prod")
+
+		runCommand()
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Getting saved targets..."},
+			[]string{"OK"},
+			[]string{"name", "api endpoint", "org", "space", "user"},
+			[]string{"dev", "https://api.dev.example.com", "my-org", "my-space", "my-user"},
+			[]string{"*", "prod", "https://api.prod.example.com", "prod-org", "prod-space", "my-user"},
+		))
+	})
+})
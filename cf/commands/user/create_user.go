@@ -68,11 +68,11 @@ func (cmd *CreateUser) Execute(c flags.FlagContext) error {
 	case *errors.ModelAlreadyExistsError:
 		cmd.ui.Warn("%s", err.Error())
 	default:
-		return errors.New(T("Error creating user {{.TargetUser}}.\n{{.Error}}",
+		return errors.NewWrappedError(T("Error creating user {{.TargetUser}}.\n{{.Error}}",
 			map[string]interface{}{
 				"TargetUser": terminal.EntityNameColor(username),
 				"Error":      err.Error(),
-			}))
+			}), err)
 	}
 
 	cmd.ui.Ok()
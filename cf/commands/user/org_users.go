@@ -32,8 +32,9 @@ func (cmd *OrgUsers) MetaData() commandregistry.CommandMetadata {
 	fs["a"] = &flags.BoolFlag{ShortName: "a", Usage: T("List all users in the org")}
 
 	return commandregistry.CommandMetadata{
-		Name:        "org-users",
-		Description: T("Show org users by role"),
+		Name:             "org-users",
+		StructuredOutput: true,
+		Description:      T("Show org users by role"),
 		Usage: []string{
 			T("CF_NAME org-users ORG"),
 		},
@@ -94,6 +95,13 @@ func (cmd *OrgUsers) printer(c flags.FlagContext) userprint.UserPrinter {
 			roles,
 		)
 	}
+	if cmd.ui.OutputFormat().IsStructured() {
+		return &userprint.RecordPrinter{
+			UI:         cmd.ui,
+			UserLister: cmd.userLister(),
+			Roles:      roles,
+		}
+	}
 	return &userprint.OrgUsersUIPrinter{
 		UI:         cmd.ui,
 		UserLister: cmd.userLister(),
@@ -7,6 +7,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/models"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/requirements/requirementsfakes"
+	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/cf/trace/tracefakes"
 	"github.com/cloudfoundry/cli/plugin/models"
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
@@ -224,6 +225,33 @@ var _ = Describe("org-users command", func() {
 			))
 		})
 
+		It("prints a record for each user when the output format is yaml", func() {
+			ui.Format = terminal.YAMLOutput
+			userRepo.ListUsersInOrgForRoleStub = func(_ string, roleName models.Role) ([]models.UserFields, error) {
+				userFields := map[models.Role][]models.UserFields{
+					models.RoleOrgManager:     {{Username: "user1", GUID: "user1-guid"}},
+					models.RoleBillingManager: {{Username: "user1", GUID: "user1-guid"}, {Username: "user2", GUID: "user2-guid", IsAdmin: true}},
+				}[roleName]
+				return userFields, nil
+			}
+
+			runCommand("the-org")
+
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"ORG MANAGER"}))
+			Expect(ui.StructuredOutput()).To(Equal(`- username: user1
+  guid: user1-guid
+  admin: false
+  roles:
+  - org_manager
+  - billing_manager
+- username: user2
+  guid: user2-guid
+  admin: true
+  roles:
+  - billing_manager
+`))
+		})
+
 		Context("when the -a flag is provided", func() {
 			BeforeEach(func() {
 				user := models.UserFields{Username: "user1"}
@@ -31,8 +31,9 @@ func init() {
 
 func (cmd *SpaceUsers) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "space-users",
-		Description: T("Show space users by role"),
+		Name:             "space-users",
+		StructuredOutput: true,
+		Description:      T("Show space users by role"),
 		Usage: []string{
 			T("CF_NAME space-users ORG SPACE"),
 		},
@@ -97,6 +98,14 @@ func (cmd *SpaceUsers) printer(org models.Organization, space models.Space, user
 			"CurrentUser": terminal.EntityNameColor(username),
 		}))
 
+	if cmd.ui.OutputFormat().IsStructured() {
+		return &userprint.RecordPrinter{
+			UI:         cmd.ui,
+			UserLister: cmd.userLister(),
+			Roles:      roles,
+		}
+	}
+
 	return &userprint.SpaceUsersUIPrinter{
 		UI:         cmd.ui,
 		UserLister: cmd.userLister(),
@@ -28,8 +28,9 @@ func init() {
 
 func (c *V3Apps) MetaData() commandregistry.CommandMetadata {
 	return commandregistry.CommandMetadata{
-		Name:        "v3apps",
-		Description: T("List all apps in the target space"),
+		Name:             "v3apps",
+		StructuredOutput: true,
+		Description:      T("List all apps in the target space"),
 		Usage: []string{
 			"CF_NAME v3apps",
 		},
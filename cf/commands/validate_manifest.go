@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/errors"
+	"github.com/cloudfoundry/cli/cf/flags"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/manifest"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+)
+
+type ValidateManifest struct {
+	ui           terminal.UI
+	manifestRepo manifest.Repository
+}
+
+func init() {
+	commandregistry.Register(&ValidateManifest{})
+}
+
+func (cmd *ValidateManifest) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["f"] = &flags.StringFlag{ShortName: "f", Usage: T("Path to manifest")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "validate-manifest",
+		Description: T("Check a manifest for unknown keys and invalid values without pushing"),
+		Usage: []string{
+			T("CF_NAME validate-manifest [-f MANIFEST_PATH]"),
+		},
+		Examples: []string{
+			"CF_NAME validate-manifest",
+			"CF_NAME validate-manifest -f ~/apps/my-app/manifest.yml",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *ValidateManifest) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	usageReq := requirements.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+		T("No argument required"),
+		func() bool {
+			return len(fc.Args()) != 0
+		},
+	)
+
+	reqs := []requirements.Requirement{
+		usageReq,
+	}
+	return reqs
+}
+
+func (cmd *ValidateManifest) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.manifestRepo = deps.ManifestRepo
+	return cmd
+}
+
+func (cmd *ValidateManifest) Execute(c flags.FlagContext) error {
+	path := c.String("f")
+	if path == "" {
+		var err error
+		path, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.ui.Say(T("Validating manifest {{.Path}}...",
+		map[string]interface{}{"Path": terminal.EntityNameColor(path)}))
+
+	err := cmd.manifestRepo.ValidateManifest(path)
+	if err != nil {
+		if validationErrs, ok := err.(manifest.ValidationErrors); ok {
+			return errors.NewWrappedError(T("Found {{.Count}} problem(s) in the manifest:\n{{.Errors}}",
+				map[string]interface{}{"Count": len(validationErrs), "Errors": validationErrs.Error()}), validationErrs)
+		}
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say(T("The manifest is valid."))
+	return nil
+}
@@ -0,0 +1,122 @@
+package commands_test
+
+import (
+	"errors"
+	"os"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/commands"
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/cloudfoundry/cli/cf/manifest"
+	"github.com/cloudfoundry/cli/cf/manifest/manifestfakes"
+	"github.com/cloudfoundry/cli/cf/requirements/requirementsfakes"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateManifest", func() {
+	var (
+		ui           *testterm.FakeUI
+		manifestRepo *manifestfakes.FakeRepository
+
+		cmd         commandregistry.Command
+		factory     *requirementsfakes.FakeFactory
+		flagContext flags.FlagContext
+	)
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		manifestRepo = new(manifestfakes.FakeRepository)
+
+		deps := commandregistry.Dependency{
+			UI:           ui,
+			ManifestRepo: manifestRepo,
+		}
+
+		cmd = &commands.ValidateManifest{}
+		cmd.SetDependency(deps, false)
+
+		flagContext = flags.NewFlagContext(cmd.MetaData().Flags)
+		factory = new(requirementsfakes.FakeFactory)
+	})
+
+	Describe("Requirements", func() {
+		It("does not require an API endpoint or login", func() {
+			reqs := cmd.Requirements(factory, flagContext)
+			Expect(reqs).To(HaveLen(1))
+			Expect(reqs[0].Execute()).To(Succeed())
+		})
+
+		Context("when given arguments", func() {
+			BeforeEach(func() {
+				flagContext.Parse("manifest.yml")
+			})
+
+			It("fails with usage", func() {
+				reqs := cmd.Requirements(factory, flagContext)
+				err := reqs[0].Execute()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Incorrect Usage. No argument required"))
+			})
+		})
+	})
+
+	Describe("Execute", func() {
+		var executeErr error
+
+		JustBeforeEach(func() {
+			executeErr = cmd.Execute(flagContext)
+		})
+
+		It("validates the manifest in the current directory by default", func() {
+			Expect(executeErr).NotTo(HaveOccurred())
+
+			cwd, _ := os.Getwd()
+			Expect(manifestRepo.ValidateManifestArgsForCall(0)).To(Equal(cwd))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Validating manifest", cwd},
+				[]string{"OK"},
+				[]string{"The manifest is valid."},
+			))
+		})
+
+		Context("when given a manifest path", func() {
+			BeforeEach(func() {
+				flagContext.Parse("-f", "apps/manifest.yml")
+			})
+
+			It("validates that manifest", func() {
+				Expect(executeErr).NotTo(HaveOccurred())
+				Expect(manifestRepo.ValidateManifestArgsForCall(0)).To(Equal("apps/manifest.yml"))
+			})
+		})
+
+		Context("when the manifest has problems", func() {
+			BeforeEach(func() {
+				manifestRepo.ValidateManifestReturns(manifest.ValidationErrors{
+					{File: "manifest.yml", Line: 3, Column: 3, Message: "Unknown key applications[0].instance"},
+					{File: "manifest.yml", Line: 4, Column: 3, Message: "Invalid byte size 1X for applications[0].memory"},
+				})
+			})
+
+			It("lists every problem with its position", func() {
+				Expect(executeErr).To(HaveOccurred())
+				Expect(executeErr.Error()).To(ContainSubstring("Found 2 problem(s) in the manifest:"))
+				Expect(executeErr.Error()).To(ContainSubstring("manifest.yml:3:3: Unknown key applications[0].instance\nmanifest.yml:4:3: Invalid byte size"))
+			})
+		})
+
+		Context("when the manifest cannot be found", func() {
+			BeforeEach(func() {
+				manifestRepo.ValidateManifestReturns(errors.New("Error finding manifest"))
+			})
+
+			It("returns the error", func() {
+				Expect(executeErr).To(MatchError("Error finding manifest"))
+			})
+		})
+	})
+})
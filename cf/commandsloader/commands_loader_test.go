@@ -2,6 +2,7 @@ package commandsloader_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -34,7 +35,14 @@ func walkDirAndCountCommand(path string) int {
 
 		if !info.IsDir() && !strings.HasSuffix(dir, "fakes") {
 			if strings.HasSuffix(info.Name(), ".go") && !strings.HasSuffix(info.Name(), "_test.go") {
-				cmdCount += 1
+				// commands may keep parts of themselves in files of their own
+				contents, err := ioutil.ReadFile(p)
+				if err != nil {
+					return err
+				}
+				if strings.Contains(string(contents), "commandregistry.Register(") {
+					cmdCount += 1
+				}
 			}
 		}
 		return nil
@@ -1,8 +1,13 @@
 package configuration
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sync"
 )
 
 const (
@@ -26,13 +31,64 @@ type DataInterface interface {
 	JSONUnmarshalV3([]byte) error
 }
 
+// VersionedData is a DataInterface whose serialized form records the version
+// of its format. DiskPersistor backs up files in an older format before they
+// are migrated and never overwrites files in a newer one.
+type VersionedData interface {
+	DataInterface
+	Version([]byte) (int, error)
+	CurrentVersion() int
+}
+
+// NewerVersionError is returned when a file was written in a newer format than
+// this CLI understands.
+type NewerVersionError struct {
+	Path           string
+	Version        int
+	CurrentVersion int
+}
+
+func (e *NewerVersionError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "The config"
+	}
+	return fmt.Sprintf("%s was written by a newer version of the CLI (format version %d, this CLI supports up to %d). Upgrade the CLI, or move the file aside to start over.", path, e.Version, e.CurrentVersion)
+}
+
+// InvalidFileError is returned when a file could not be parsed. The file was
+// copied to BackupPath and replaced with the defaults.
+type InvalidFileError struct {
+	Path       string
+	BackupPath string
+	Err        error
+}
+
+func (e *InvalidFileError) Error() string {
+	return fmt.Sprintf("%s could not be read (%s). It was backed up to %s and reset to the defaults; run the command again to continue with them.", e.Path, e.Err, e.BackupPath)
+}
+
+// DiskPersistor reads and writes a JSON file. Every read-modify-write holds an
+// advisory lock on a sibling ".lock" file, and the file itself is replaced
+// atomically, so several cf processes can share it safely. When the file was
+// changed by someone else since it was last loaded, Save keeps their changes
+// to any top-level field this process did not change itself.
 type DiskPersistor struct {
 	filePath string
+	snapshot *snapshot
+}
+
+// snapshot holds the contents of the file as last loaded or saved, which is
+// the common ancestor used when merging.
+type snapshot struct {
+	mutex    *sync.Mutex
+	contents []byte
 }
 
 func NewDiskPersistor(path string) DiskPersistor {
 	return DiskPersistor{
 		filePath: path,
+		snapshot: &snapshot{mutex: new(sync.Mutex)},
 	}
 }
 
@@ -49,42 +105,253 @@ func (dp DiskPersistor) Delete() {
 }
 
 func (dp DiskPersistor) Load(data DataInterface) error {
-	err := dp.read(data)
-	if os.IsPermission(err) {
+	err := dp.makeDirectory()
+	if err != nil {
 		return err
 	}
 
-	if err != nil {
-		err = dp.write(data)
-	}
-	return err
+	return dp.withLock(func() error {
+		err := dp.read(data)
+		if _, ok := err.(*NewerVersionError); ok || os.IsPermission(err) {
+			return err
+		}
+
+		if invalidErr, ok := err.(*InvalidFileError); ok {
+			err = dp.write(data)
+			if err != nil {
+				return err
+			}
+			return invalidErr
+		}
+
+		if err != nil {
+			err = dp.write(data)
+		}
+		return err
+	})
 }
 
 func (dp DiskPersistor) Save(data DataInterface) error {
-	return dp.write(data)
+	err := dp.makeDirectory()
+	if err != nil {
+		return err
+	}
+
+	return dp.withLock(func() error {
+		err := dp.mergeChanges(data)
+		if err != nil {
+			return err
+		}
+
+		return dp.write(data)
+	})
 }
 
-func (dp DiskPersistor) read(data DataInterface) error {
-	err := dp.makeDirectory()
+func (dp DiskPersistor) withLock(cb func() error) error {
+	dp.snapshot.mutex.Lock()
+	defer dp.snapshot.mutex.Unlock()
+
+	file, err := os.OpenFile(dp.filePath+".lock", os.O_RDWR|os.O_CREATE, filePermissions)
 	if err != nil {
 		return err
 	}
+	defer file.Close()
+
+	err = lockFile(file)
+	if err != nil {
+		return err
+	}
+	defer unlockFile(file)
+
+	return cb()
+}
 
+func (dp DiskPersistor) read(data DataInterface) error {
 	jsonBytes, err := ioutil.ReadFile(dp.filePath)
 	if err != nil {
 		return err
 	}
 
+	migrated := false
+	if versioned, ok := data.(VersionedData); ok {
+		migrated, err = dp.backupOlderVersion(versioned, jsonBytes)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = data.JSONUnmarshalV3(jsonBytes)
-	return err
+	if err != nil {
+		// empty files are new files, which Load fills with the defaults
+		if _, ok := err.(*NewerVersionError); ok || len(bytes.TrimSpace(jsonBytes)) == 0 {
+			return err
+		}
+		return dp.backupInvalidFile(jsonBytes, err)
+	}
+
+	dp.snapshot.contents = jsonBytes
+
+	if migrated {
+		return dp.write(data)
+	}
+	return nil
+}
+
+// backupOlderVersion copies the file aside when its contents are in an older
+// format than data is saved in, and reports whether it did. Files that cannot
+// be parsed are left to JSONUnmarshalV3 to report.
+func (dp DiskPersistor) backupOlderVersion(data VersionedData, jsonBytes []byte) (bool, error) {
+	version, err := data.Version(jsonBytes)
+	if err != nil {
+		return false, nil
+	}
+
+	switch {
+	case version > data.CurrentVersion():
+		return false, &NewerVersionError{
+			Path:           dp.filePath,
+			Version:        version,
+			CurrentVersion: data.CurrentVersion(),
+		}
+	case version < data.CurrentVersion():
+		backupPath := fmt.Sprintf("%s.v%d.bak", dp.filePath, version)
+		return true, ioutil.WriteFile(backupPath, jsonBytes, filePermissions)
+	default:
+		return false, nil
+	}
+}
+
+// backupInvalidFile copies a file that could not be parsed aside, so that
+// Load can replace it with the defaults without losing its contents.
+func (dp DiskPersistor) backupInvalidFile(jsonBytes []byte, parseErr error) error {
+	backupPath := dp.filePath + ".bak"
+	err := ioutil.WriteFile(backupPath, jsonBytes, filePermissions)
+	if err != nil {
+		return err
+	}
+
+	return &InvalidFileError{Path: dp.filePath, BackupPath: backupPath, Err: parseErr}
 }
 
 func (dp DiskPersistor) write(data DataInterface) error {
-	bytes, err := data.JSONMarshalV3()
+	jsonBytes, err := data.JSONMarshalV3()
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := ioutil.TempFile(filepath.Dir(dp.filePath), filepath.Base(dp.filePath)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmpFile.Write(jsonBytes)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	closeErr := tmpFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpFile.Name(), filePermissions)
+	}
+	if err == nil {
+		err = os.Rename(tmpFile.Name(), dp.filePath)
+	}
+	if err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return err
+	}
+
+	dp.snapshot.contents = jsonBytes
+	return nil
+}
+
+// mergeChanges folds in changes made to the file by other processes since it
+// was last loaded. A top-level field that data still holds at its loaded value
+// takes the value currently on disk; fields changed in data win.
+func (dp DiskPersistor) mergeChanges(data DataInterface) error {
+	if dp.snapshot.contents == nil {
+		return nil
+	}
+
+	onDisk, err := ioutil.ReadFile(dp.filePath)
+	if err != nil || bytes.Equal(onDisk, dp.snapshot.contents) {
+		return nil
+	}
+
+	if versioned, ok := data.(VersionedData); ok {
+		version, err := versioned.Version(onDisk)
+		if err == nil && version > versioned.CurrentVersion() {
+			return &NewerVersionError{
+				Path:           dp.filePath,
+				Version:        version,
+				CurrentVersion: versioned.CurrentVersion(),
+			}
+		}
+	}
+
+	base, err := jsonFields(dp.snapshot.contents)
+	if err != nil {
+		return nil
+	}
+
+	theirs, err := jsonFields(onDisk)
+	if err != nil {
+		return nil
+	}
+
+	ourBytes, err := data.JSONMarshalV3()
+	if err != nil {
+		return err
+	}
+
+	ours, err := jsonFields(ourBytes)
 	if err != nil {
 		return err
 	}
 
-	err = ioutil.WriteFile(dp.filePath, bytes, filePermissions)
-	return err
+	merged := map[string]json.RawMessage{}
+	for key, value := range theirs {
+		merged[key] = value
+	}
+
+	for key, value := range ours {
+		if !sameJSON(value, base[key]) {
+			merged[key] = value
+		}
+	}
+
+	for key := range base {
+		if _, ok := ours[key]; !ok {
+			delete(merged, key)
+		}
+	}
+
+	mergedBytes, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+
+	return data.JSONUnmarshalV3(mergedBytes)
+}
+
+func jsonFields(input []byte) (map[string]json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	err := json.Unmarshal(input, &fields)
+	return fields, err
+}
+
+func sameJSON(a, b json.RawMessage) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	compactA := new(bytes.Buffer)
+	compactB := new(bytes.Buffer)
+	if json.Compact(compactA, a) != nil || json.Compact(compactB, b) != nil {
+		return false
+	}
+
+	return bytes.Equal(compactA.Bytes(), compactB.Bytes())
 }
@@ -2,12 +2,18 @@ package configuration_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
 
 	. "github.com/cloudfoundry/cli/cf/configuration"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
 )
 
 var _ = Describe("DiskPersistor", func() {
@@ -30,6 +36,7 @@ var _ = Describe("DiskPersistor", func() {
 
 	AfterEach(func() {
 		os.Remove(tmpFile.Name())
+		os.Remove(tmpFile.Name() + ".lock")
 	})
 
 	Describe(".Delete", func() {
@@ -54,6 +61,217 @@ var _ = Describe("DiskPersistor", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(string(dataBytes)).To(ContainSubstring(d.Info))
 		})
+
+		It("replaces the file without leaving temporary files behind", func() {
+			dir, err := ioutil.TempDir("", "disk-persistor")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			diskPersistor = NewDiskPersistor(filepath.Join(dir, "config.json"))
+			err = diskPersistor.Save(&data{Info: "save test"})
+			Expect(err).ToNot(HaveOccurred())
+
+			files, err := ioutil.ReadDir(dir)
+			Expect(err).ToNot(HaveOccurred())
+
+			names := []string{}
+			for _, file := range files {
+				names = append(names, file.Name())
+			}
+			Expect(names).To(ConsistOf("config.json", "config.json.lock"))
+		})
+
+		Context("when the file was changed by another persistor since it was loaded", func() {
+			var (
+				otherPersistor DiskPersistor
+				ours, theirs   *fields
+			)
+
+			BeforeEach(func() {
+				err := ioutil.WriteFile(tmpFile.Name(), []byte(`{"a":"1","b":"1","c":"1"}`), 0600)
+				Expect(err).ToNot(HaveOccurred())
+
+				otherPersistor = NewDiskPersistor(tmpFile.Name())
+				ours = &fields{}
+				theirs = &fields{}
+				Expect(diskPersistor.Load(ours)).To(Succeed())
+				Expect(otherPersistor.Load(theirs)).To(Succeed())
+
+				(*theirs)["b"] = "theirs"
+				(*theirs)["c"] = "theirs"
+				delete(*theirs, "a")
+				Expect(otherPersistor.Save(theirs)).To(Succeed())
+			})
+
+			It("keeps their changes to fields it did not change", func() {
+				(*ours)["d"] = "ours"
+				Expect(diskPersistor.Save(ours)).To(Succeed())
+
+				Expect(*ours).To(Equal(fields{"b": "theirs", "c": "theirs", "d": "ours"}))
+
+				onDisk := &fields{}
+				Expect(NewDiskPersistor(tmpFile.Name()).Load(onDisk)).To(Succeed())
+				Expect(*onDisk).To(Equal(*ours))
+			})
+
+			It("prefers its own changes to fields both changed", func() {
+				(*ours)["c"] = "ours"
+				Expect(diskPersistor.Save(ours)).To(Succeed())
+
+				Expect(*ours).To(Equal(fields{"b": "theirs", "c": "ours"}))
+			})
+
+			It("keeps its own removal of a field", func() {
+				delete(*ours, "b")
+				Expect(diskPersistor.Save(ours)).To(Succeed())
+
+				Expect(*ours).To(Equal(fields{"c": "theirs"}))
+			})
+		})
+	})
+
+	Describe("versioned data", func() {
+		var dir, configPath string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "disk-persistor")
+			Expect(err).ToNot(HaveOccurred())
+
+			configPath = filepath.Join(dir, "config.json")
+			diskPersistor = NewDiskPersistor(configPath)
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		Context("when the file is in an older format", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(configPath, []byte(`{"FormatVersion":1,"Info":"old"}`), 0600)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("backs up the file and saves it in the current format", func() {
+				d := &versionedData{}
+				Expect(diskPersistor.Load(d)).To(Succeed())
+				Expect(d.Info).To(Equal("old"))
+
+				backup, err := ioutil.ReadFile(configPath + ".v1.bak")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(backup).To(MatchJSON(`{"FormatVersion":1,"Info":"old"}`))
+
+				saved, err := ioutil.ReadFile(configPath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(saved).To(MatchJSON(`{"FormatVersion":2,"Info":"old"}`))
+			})
+		})
+
+		Context("when the file is in a newer format", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(configPath, []byte(`{"FormatVersion":3,"Info":"new"}`), 0600)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("refuses to load it and leaves it untouched", func() {
+				err := diskPersistor.Load(&versionedData{})
+				Expect(err).To(Equal(&NewerVersionError{Path: configPath, Version: 3, CurrentVersion: 2}))
+				Expect(err.Error()).To(ContainSubstring("newer version of the CLI"))
+
+				saved, err := ioutil.ReadFile(configPath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(saved).To(MatchJSON(`{"FormatVersion":3,"Info":"new"}`))
+			})
+		})
+
+		Context("when the file is upgraded by a newer CLI after it was loaded", func() {
+			It("refuses to overwrite it", func() {
+				d := &versionedData{}
+				Expect(diskPersistor.Load(d)).To(Succeed())
+
+				err := ioutil.WriteFile(configPath, []byte(`{"FormatVersion":3,"Info":"new"}`), 0600)
+				Expect(err).ToNot(HaveOccurred())
+
+				d.Info = "changed"
+				err = diskPersistor.Save(d)
+				Expect(err).To(BeAssignableToTypeOf(&NewerVersionError{}))
+
+				saved, err := ioutil.ReadFile(configPath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(saved).To(MatchJSON(`{"FormatVersion":3,"Info":"new"}`))
+			})
+		})
+	})
+
+	Describe("concurrent access", func() {
+		const (
+			workers    = 10
+			iterations = 20
+		)
+
+		var configPath string
+
+		BeforeEach(func() {
+			configPath = filepath.Join(tmpDir, filepath.Base(tmpFile.Name())+".json")
+		})
+
+		AfterEach(func() {
+			os.Remove(configPath)
+			os.Remove(configPath + ".lock")
+		})
+
+		expectEveryWorkerSaved := func() {
+			onDisk := &fields{}
+			Expect(NewDiskPersistor(configPath).Load(onDisk)).To(Succeed())
+
+			Expect(*onDisk).To(HaveLen(workers))
+			for i := 0; i < workers; i++ {
+				Expect(*onDisk).To(HaveKeyWithValue(fmt.Sprintf("worker-%d", i), strconv.Itoa(iterations)))
+			}
+		}
+
+		It("does not lose changes saved from many goroutines", func() {
+			wg := new(sync.WaitGroup)
+			for i := 0; i < workers; i++ {
+				wg.Add(1)
+				go func(worker int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					persistor := NewDiskPersistor(configPath)
+					d := &fields{}
+					Expect(persistor.Load(d)).To(Succeed())
+
+					for j := 1; j <= iterations; j++ {
+						(*d)[fmt.Sprintf("worker-%d", worker)] = strconv.Itoa(j)
+						Expect(persistor.Save(d)).To(Succeed())
+					}
+				}(i)
+			}
+			wg.Wait()
+
+			expectEveryWorkerSaved()
+		})
+
+		It("does not lose changes saved from many processes", func() {
+			hammerPath, err := gexec.Build("github.com/cloudfoundry/cli/fixtures/config/persistor-hammer")
+			Expect(err).ToNot(HaveOccurred())
+			defer gexec.CleanupBuildArtifacts()
+
+			sessions := []*gexec.Session{}
+			for i := 0; i < workers; i++ {
+				cmd := exec.Command(hammerPath, configPath, fmt.Sprintf("worker-%d", i), strconv.Itoa(iterations))
+				session, err := gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+				sessions = append(sessions, session)
+			}
+
+			for _, session := range sessions {
+				Eventually(session, 30).Should(gexec.Exit(0))
+			}
+
+			expectEveryWorkerSaved()
+		})
 	})
 
 	Describe(".Load", func() {
@@ -75,6 +293,30 @@ var _ = Describe("DiskPersistor", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(d.Info).To(Equal("test string"))
 		})
+
+		Context("when the file is not valid JSON", func() {
+			AfterEach(func() {
+				os.Remove(tmpFile.Name() + ".bak")
+			})
+
+			It("backs it up before loading the defaults", func() {
+				err := ioutil.WriteFile(tmpFile.Name(), []byte(`{"a":`), 0600)
+				Expect(err).ToNot(HaveOccurred())
+
+				loaded := &fields{"b": "default"}
+				err = diskPersistor.Load(loaded)
+				Expect(err).To(BeAssignableToTypeOf(&InvalidFileError{}))
+				Expect(err.Error()).To(ContainSubstring("backed up to " + tmpFile.Name() + ".bak"))
+
+				backup, err := ioutil.ReadFile(tmpFile.Name() + ".bak")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(backup)).To(Equal(`{"a":`))
+
+				onDisk := &fields{}
+				Expect(NewDiskPersistor(tmpFile.Name()).Load(onDisk)).To(Succeed())
+				Expect(*onDisk).To(Equal(fields{"b": "default"}))
+			})
+		})
 	})
 })
 
@@ -89,3 +331,44 @@ func (d *data) JSONMarshalV3() ([]byte, error) {
 func (d *data) JSONUnmarshalV3(data []byte) error {
 	return json.Unmarshal(data, d)
 }
+
+type fields map[string]string
+
+func (f *fields) JSONMarshalV3() ([]byte, error) {
+	return json.MarshalIndent(f, "", "  ")
+}
+
+func (f *fields) JSONUnmarshalV3(input []byte) error {
+	data := fields{}
+	err := json.Unmarshal(input, &data)
+	if err != nil {
+		return err
+	}
+
+	*f = data
+	return nil
+}
+
+type versionedData struct {
+	FormatVersion int
+	Info          string
+}
+
+func (d *versionedData) JSONMarshalV3() ([]byte, error) {
+	d.FormatVersion = d.CurrentVersion()
+	return json.Marshal(d)
+}
+
+func (d *versionedData) JSONUnmarshalV3(input []byte) error {
+	return json.Unmarshal(input, d)
+}
+
+func (d *versionedData) Version(input []byte) (int, error) {
+	v := &versionedData{}
+	err := json.Unmarshal(input, v)
+	return v.FormatVersion, err
+}
+
+func (d *versionedData) CurrentVersion() int {
+	return 2
+}
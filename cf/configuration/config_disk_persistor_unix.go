@@ -5,8 +5,17 @@ package configuration
 import (
 	"os"
 	"path/filepath"
+	"syscall"
 )
 
 func (dp DiskPersistor) makeDirectory() error {
 	return os.MkdirAll(filepath.Dir(dp.filePath), dirPermissions)
 }
+
+func lockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+}
+
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
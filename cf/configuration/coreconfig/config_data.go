@@ -3,6 +3,7 @@ package coreconfig
 import (
 	"encoding/json"
 
+	"github.com/cloudfoundry/cli/cf/configuration"
 	"github.com/cloudfoundry/cli/cf/models"
 )
 
@@ -33,6 +34,11 @@ type Data struct {
 	OrganizationFields       models.OrganizationFields
 	SpaceFields              models.SpaceFields
 	SSLDisabled              bool
+	CACertFile               string `json:",omitempty"`
+	ClientCertFile           string `json:",omitempty"`
+	ClientKeyFile            string `json:",omitempty"`
+	ProxyURL                 string `json:",omitempty"`
+	NoProxy                  string `json:",omitempty"`
 	AsyncTimeout             uint
 	Trace                    string
 	ColorEnabled             string
@@ -40,6 +46,36 @@ type Data struct {
 	PluginRepos              []models.PluginRepo
 	MinCLIVersion            string
 	MinRecommendedCLIVersion string
+	CurrentTarget            string       `json:",omitempty"`
+	Targets                  []TargetInfo `json:",omitempty"`
+	CredentialStore          string       `json:",omitempty"`
+}
+
+// TargetInfo is a named snapshot of everything needed to talk to one API
+// endpoint: the endpoints themselves, the session tokens and the targeted
+// org and space.
+type TargetInfo struct {
+	Name                     string
+	Target                   string
+	APIVersion               string
+	AuthorizationEndpoint    string
+	LoggregatorEndPoint      string
+	DopplerEndPoint          string
+	UaaEndpoint              string
+	RoutingAPIEndpoint       string
+	AccessToken              string
+	SSHOAuthClient           string
+	RefreshToken             string
+	OrganizationFields       models.OrganizationFields
+	SpaceFields              models.SpaceFields
+	SSLDisabled              bool
+	CACertFile               string `json:",omitempty"`
+	ClientCertFile           string `json:",omitempty"`
+	ClientKeyFile            string `json:",omitempty"`
+	ProxyURL                 string `json:",omitempty"`
+	NoProxy                  string `json:",omitempty"`
+	MinCLIVersion            string
+	MinRecommendedCLIVersion string
 }
 
 func NewData() *Data {
@@ -47,20 +83,162 @@ func NewData() *Data {
 }
 
 func (d *Data) JSONMarshalV3() ([]byte, error) {
-	d.ConfigVersion = 3
+	d.ConfigVersion = CurrentConfigVersion
 	return json.MarshalIndent(d, "", "  ")
 }
 
+// JSONUnmarshalV3 loads input into d, migrating it first when it was written
+// in an older format. Input written in a newer format is refused.
 func (d *Data) JSONUnmarshalV3(input []byte) error {
-	err := json.Unmarshal(input, d)
+	version, err := d.Version(input)
 	if err != nil {
 		return err
 	}
 
-	if d.ConfigVersion != 3 {
-		*d = Data{}
-		return nil
+	if version > CurrentConfigVersion {
+		return &configuration.NewerVersionError{
+			Version:        version,
+			CurrentVersion: CurrentConfigVersion,
+		}
 	}
 
+	if version < CurrentConfigVersion {
+		input, err = migrateConfig(input, version)
+		if err != nil {
+			return err
+		}
+	}
+
+	data := Data{}
+	err = json.Unmarshal(input, &data)
+	if err != nil {
+		return err
+	}
+
+	*d = data
 	return nil
 }
+
+func (d *Data) Version(input []byte) (int, error) {
+	return configVersion(input)
+}
+
+func (d *Data) CurrentVersion() int {
+	return CurrentConfigVersion
+}
+
+func (d *Data) targetInfo(name string) TargetInfo {
+	return TargetInfo{
+		Name:                     name,
+		Target:                   d.Target,
+		APIVersion:               d.APIVersion,
+		AuthorizationEndpoint:    d.AuthorizationEndpoint,
+		LoggregatorEndPoint:      d.LoggregatorEndPoint,
+		DopplerEndPoint:          d.DopplerEndPoint,
+		UaaEndpoint:              d.UaaEndpoint,
+		RoutingAPIEndpoint:       d.RoutingAPIEndpoint,
+		AccessToken:              d.AccessToken,
+		SSHOAuthClient:           d.SSHOAuthClient,
+		RefreshToken:             d.RefreshToken,
+		OrganizationFields:       d.OrganizationFields,
+		SpaceFields:              d.SpaceFields,
+		SSLDisabled:              d.SSLDisabled,
+		CACertFile:               d.CACertFile,
+		ClientCertFile:           d.ClientCertFile,
+		ClientKeyFile:            d.ClientKeyFile,
+		ProxyURL:                 d.ProxyURL,
+		NoProxy:                  d.NoProxy,
+		MinCLIVersion:            d.MinCLIVersion,
+		MinRecommendedCLIVersion: d.MinRecommendedCLIVersion,
+	}
+}
+
+func (d *Data) useTargetInfo(info TargetInfo) {
+	d.Target = info.Target
+	d.APIVersion = info.APIVersion
+	d.AuthorizationEndpoint = info.AuthorizationEndpoint
+	d.LoggregatorEndPoint = info.LoggregatorEndPoint
+	d.DopplerEndPoint = info.DopplerEndPoint
+	d.UaaEndpoint = info.UaaEndpoint
+	d.RoutingAPIEndpoint = info.RoutingAPIEndpoint
+	d.AccessToken = info.AccessToken
+	d.SSHOAuthClient = info.SSHOAuthClient
+	d.RefreshToken = info.RefreshToken
+	d.OrganizationFields = info.OrganizationFields
+	d.SpaceFields = info.SpaceFields
+	d.SSLDisabled = info.SSLDisabled
+	d.CACertFile = info.CACertFile
+	d.ClientCertFile = info.ClientCertFile
+	d.ClientKeyFile = info.ClientKeyFile
+	d.ProxyURL = info.ProxyURL
+	d.NoProxy = info.NoProxy
+	d.MinCLIVersion = info.MinCLIVersion
+	d.MinRecommendedCLIVersion = info.MinRecommendedCLIVersion
+	d.CurrentTarget = info.Name
+}
+
+func (d *Data) findTarget(name string) int {
+	for i, target := range d.Targets {
+		if target.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// storeTarget saves the current session under name, replacing any target
+// previously saved with the same name.
+func (d *Data) storeTarget(name string) {
+	info := d.targetInfo(name)
+	if i := d.findTarget(name); i != -1 {
+		d.Targets[i] = info
+		return
+	}
+	d.Targets = append(d.Targets, info)
+}
+
+func (d *Data) hasCredentials() bool {
+	if d.AccessToken != "" || d.RefreshToken != "" {
+		return true
+	}
+
+	for _, target := range d.Targets {
+		if target.AccessToken != "" || target.RefreshToken != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutCredentials returns a copy of d with every token removed.
+func (d *Data) withoutCredentials() *Data {
+	data := *d
+	data.AccessToken = ""
+	data.RefreshToken = ""
+
+	data.Targets = make([]TargetInfo, len(d.Targets))
+	for i, target := range d.Targets {
+		target.AccessToken = ""
+		target.RefreshToken = ""
+		data.Targets[i] = target
+	}
+	if d.Targets == nil {
+		data.Targets = nil
+	}
+
+	return &data
+}
+
+// copyCredentials puts back the tokens that withoutCredentials removed,
+// matching saved targets by name.
+func (d *Data) copyCredentials(from *Data) {
+	d.AccessToken = from.AccessToken
+	d.RefreshToken = from.RefreshToken
+
+	for i := range d.Targets {
+		if j := from.findTarget(d.Targets[i].Name); j != -1 {
+			d.Targets[i].AccessToken = from.Targets[j].AccessToken
+			d.Targets[i].RefreshToken = from.Targets[j].RefreshToken
+		}
+	}
+}
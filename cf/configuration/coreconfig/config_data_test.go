@@ -1,6 +1,9 @@
 package coreconfig_test
 
 import (
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/configuration"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/models"
 
@@ -145,14 +148,10 @@ var _ = Describe("V3 Config files", func() {
 	})
 
 	Describe("JSONUnmarshalV3", func() {
-		It("returns an error when the JSON is invalid", func() {
-			configData := coreconfig.NewData()
-			err := configData.JSONUnmarshalV3([]byte(`{ "not_valid": ### }`))
-			Expect(err).To(HaveOccurred())
-		})
+		var expectedData *coreconfig.Data
 
-		It("creates a config object from valid V3 JSON", func() {
-			expectedData := &coreconfig.Data{
+		BeforeEach(func() {
+			expectedData = &coreconfig.Data{
 				ConfigVersion:            3,
 				Target:                   "api.example.com",
 				APIVersion:               "3",
@@ -186,7 +185,15 @@ var _ = Describe("V3 Config files", func() {
 					},
 				},
 			}
+		})
 
+		It("returns an error when the JSON is invalid", func() {
+			configData := coreconfig.NewData()
+			err := configData.JSONUnmarshalV3([]byte(`{ "not_valid": ### }`))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("creates a config object from valid V3 JSON", func() {
 			actualData := coreconfig.NewData()
 			err := actualData.JSONUnmarshalV3([]byte(exampleV3JSON))
 			Expect(err).NotTo(HaveOccurred())
@@ -194,12 +201,53 @@ var _ = Describe("V3 Config files", func() {
 			Expect(actualData).To(Equal(expectedData))
 		})
 
-		It("returns an empty Data object for non-V3 JSON", func() {
+		It("replaces fields that are missing from the JSON", func() {
+			actualData := coreconfig.NewData()
+			actualData.CurrentTarget = "stale-target"
+			actualData.Targets = []coreconfig.TargetInfo{{Name: "stale-target"}}
+
+			err := actualData.JSONUnmarshalV3([]byte(exampleV3JSON))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(actualData.CurrentTarget).To(BeEmpty())
+			Expect(actualData.Targets).To(BeEmpty())
+		})
+
+		It("migrates V2 JSON", func() {
 			actualData := coreconfig.NewData()
 			err := actualData.JSONUnmarshalV3([]byte(exampleV2JSON))
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(*actualData).To(Equal(coreconfig.Data{}))
+			Expect(actualData).To(Equal(expectedData))
+		})
+
+		It("migrates V1 JSON, keeping the session", func() {
+			actualData := coreconfig.NewData()
+			err := actualData.JSONUnmarshalV3([]byte(strings.Replace(exampleV2JSON, `"ConfigVersion": 2`, `"ConfigVersion": 1`, 1)))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(actualData).To(Equal(expectedData))
+		})
+
+		It("migrates JSON without a version", func() {
+			actualData := coreconfig.NewData()
+			err := actualData.JSONUnmarshalV3([]byte(`{"Target": "api.example.com", "AccessToken": "the-access-token"}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(*actualData).To(Equal(coreconfig.Data{
+				ConfigVersion: coreconfig.CurrentConfigVersion,
+				Target:        "api.example.com",
+				AccessToken:   "the-access-token",
+			}))
+		})
+
+		It("refuses JSON written in a newer format", func() {
+			actualData := coreconfig.NewData()
+			actualData.Target = "api.example.com"
+
+			err := actualData.JSONUnmarshalV3([]byte(`{"ConfigVersion": 4, "Target": "api.other.example.com"}`))
+			Expect(err).To(Equal(&configuration.NewerVersionError{Version: 4, CurrentVersion: 3}))
+			Expect(actualData.Target).To(Equal("api.example.com"))
 		})
 	})
 })
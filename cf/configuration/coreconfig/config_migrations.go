@@ -0,0 +1,89 @@
+package coreconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// CurrentConfigVersion is the version of the config file format written by
+// this CLI. Bump it, and append a migration to configMigrations, whenever the
+// format changes in a way older data cannot simply be unmarshalled into.
+const CurrentConfigVersion = 3
+
+// configMigrations upgrade a config file one version at a time:
+// configMigrations[n] turns a version n file into a version n+1 file.
+var configMigrations = []func(configFields){
+	keepConfigFields,
+	keepConfigFields,
+	keepConfigFields,
+}
+
+// configFields is a config file decoded without a schema, so that fields can
+// be renamed, reshaped or dropped between versions.
+type configFields map[string]interface{}
+
+// key returns the key under which name is stored. Field names are matched
+// case-insensitively, as encoding/json does when unmarshalling into Data.
+func (f configFields) key(name string) (string, bool) {
+	if _, ok := f[name]; ok {
+		return name, true
+	}
+
+	for key := range f {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+func (f configFields) delete(name string) {
+	if key, ok := f.key(name); ok {
+		delete(f, key)
+	}
+}
+
+func configVersion(input []byte) (int, error) {
+	var versioned struct {
+		ConfigVersion int
+	}
+
+	err := json.Unmarshal(input, &versioned)
+	if err != nil {
+		return 0, err
+	}
+
+	if versioned.ConfigVersion < 0 {
+		return 0, fmt.Errorf("Invalid config version %d", versioned.ConfigVersion)
+	}
+	return versioned.ConfigVersion, nil
+}
+
+// migrateConfig upgrades input from version to CurrentConfigVersion.
+func migrateConfig(input []byte, version int) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.UseNumber()
+
+	fields := configFields{}
+	err := decoder.Decode(&fields)
+	if err != nil {
+		return nil, err
+	}
+
+	for ; version < CurrentConfigVersion; version++ {
+		configMigrations[version](fields)
+	}
+
+	fields.delete("ConfigVersion")
+	fields["ConfigVersion"] = CurrentConfigVersion
+
+	return json.Marshal(fields)
+}
+
+// Files of versions 0 to 2 are read with the version 3 layout. Earlier CLIs
+// discarded them rather than converting them, so no conversion is known;
+// reading them as they are keeps users logged in with their targets, plugin
+// repos and preferences.
+func keepConfigFields(fields configFields) {}
@@ -1,11 +1,14 @@
 package coreconfig
 
 import (
+	"os"
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/blang/semver"
 	"github.com/cloudfoundry/cli/cf/configuration"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/models"
 )
 
@@ -15,6 +18,11 @@ type ConfigRepository struct {
 	initOnce  *sync.Once
 	persistor configuration.Persistor
 	onError   func(error)
+
+	credentialStores    CredentialStoreFactory
+	credentialStoreName string
+	credentialStore     CredentialStore
+	storedCredentials   map[string]Credentials
 }
 
 type CCInfo struct {
@@ -31,10 +39,42 @@ func NewRepositoryFromFilepath(filepath string, errorHandler func(error)) Reposi
 	if errorHandler == nil {
 		return nil
 	}
-	return NewRepositoryFromPersistor(configuration.NewDiskPersistor(filepath), errorHandler)
+
+	credentialStores := func(name string) (CredentialStore, error) {
+		return NewCredentialStore(name, filepath)
+	}
+	return NewRepository(configuration.NewDiskPersistor(filepath), credentialStores, errorHandler)
+}
+
+// NewReadOnlyRepositoryFromFilepath returns a Repository loaded like
+// NewRepositoryFromFilepath, whose changes are kept in memory only and never
+// reach the config file or the credential store.
+func NewReadOnlyRepositoryFromFilepath(filepath string, errorHandler func(error)) Repository {
+	if errorHandler == nil {
+		return nil
+	}
+
+	credentialStores := func(name string) (CredentialStore, error) {
+		store, err := NewCredentialStore(name, filepath)
+		if store == nil || err != nil {
+			return nil, err
+		}
+		return readOnlyCredentialStore{store}, nil
+	}
+	return NewRepository(configuration.NewReadOnlyPersistor(configuration.NewDiskPersistor(filepath)), credentialStores, errorHandler)
 }
 
 func NewRepositoryFromPersistor(persistor configuration.Persistor, errorHandler func(error)) Repository {
+	credentialStores := func(name string) (CredentialStore, error) {
+		return NewCredentialStore(name, "")
+	}
+	return NewRepository(persistor, credentialStores, errorHandler)
+}
+
+// NewRepository returns a Repository saved by persistor, whose session tokens
+// are kept in the credential store named by CF_CREDENTIAL_STORE or, failing
+// that, by the config itself.
+func NewRepository(persistor configuration.Persistor, credentialStores CredentialStoreFactory, errorHandler func(error)) Repository {
 	data := NewData()
 	if !persistor.Exists() {
 		//set default plugin repo
@@ -45,11 +85,12 @@ func NewRepositoryFromPersistor(persistor configuration.Persistor, errorHandler
 	}
 
 	return &ConfigRepository{
-		data:      data,
-		mutex:     new(sync.RWMutex),
-		initOnce:  new(sync.Once),
-		persistor: persistor,
-		onError:   errorHandler,
+		data:             data,
+		mutex:            new(sync.RWMutex),
+		initOnce:         new(sync.Once),
+		persistor:        persistor,
+		onError:          errorHandler,
+		credentialStores: credentialStores,
 	}
 }
 
@@ -78,6 +119,11 @@ type Reader interface {
 	UserEmail() string
 	IsLoggedIn() bool
 	IsSSLDisabled() bool
+	CACertFile() string
+	ClientCertFile() string
+	ClientKeyFile() string
+	ProxyURL() string
+	NoProxy() string
 	IsMinAPIVersion(semver.Version) bool
 	IsMinCLIVersion(string) bool
 	MinCLIVersion() string
@@ -91,6 +137,9 @@ type Reader interface {
 	Locale() string
 
 	PluginRepos() []models.PluginRepo
+
+	CurrentTargetName() string
+	SavedTargets() []TargetInfo
 }
 
 //go:generate counterfeiter . ReadWriter
@@ -113,12 +162,19 @@ type ReadWriter interface {
 	SetOrganizationFields(models.OrganizationFields)
 	SetSpaceFields(models.SpaceFields)
 	SetSSLDisabled(bool)
+	SetCACertFile(string)
+	SetClientCertificate(certFile string, keyFile string)
+	SetProxy(proxyURL string, noProxy string)
 	SetAsyncTimeout(uint)
 	SetTrace(string)
 	SetColorEnabled(string)
 	SetLocale(string)
 	SetPluginRepo(models.PluginRepo)
 	UnSetPluginRepo(int)
+	SaveTarget(string)
+	SwitchTarget(string) error
+	DeleteTarget(string) error
+	SetCredentialStore(string) error
 }
 
 //go:generate counterfeiter . Repository
@@ -133,6 +189,12 @@ type Repository interface {
 func (c *ConfigRepository) init() {
 	c.initOnce.Do(func() {
 		err := c.persistor.Load(c.data)
+		if err != nil {
+			c.onError(err)
+			return
+		}
+
+		err = c.loadCredentials()
 		if err != nil {
 			c.onError(err)
 		}
@@ -154,12 +216,136 @@ func (c *ConfigRepository) write(cb func()) {
 
 	cb()
 
-	err := c.persistor.Save(c.data)
+	err := c.save()
 	if err != nil {
 		c.onError(err)
 	}
 }
 
+// CREDENTIALS
+
+func (c *ConfigRepository) loadCredentials() error {
+	name := os.Getenv("CF_CREDENTIAL_STORE")
+	if name == "" {
+		name = c.data.CredentialStore
+	}
+	if name == FileCredentialStoreName {
+		name = ""
+	}
+
+	store, err := c.credentialStores(name)
+	if err != nil {
+		return err
+	}
+
+	c.credentialStoreName = name
+	c.credentialStore = store
+	c.storedCredentials = map[string]Credentials{}
+	if store == nil {
+		return nil
+	}
+
+	// tokens left in the config file by an earlier CLI, or before the store
+	// was chosen, are moved into the store straight away
+	if c.data.hasCredentials() {
+		err = c.save()
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.data.AccessToken != "" || c.data.RefreshToken != "" {
+		return nil
+	}
+
+	credentials, err := store.Get(sessionCredentialsKey)
+	if err != nil {
+		return err
+	}
+
+	c.storedCredentials[sessionCredentialsKey] = credentials
+	c.data.AccessToken = credentials.AccessToken
+	c.data.RefreshToken = credentials.RefreshToken
+	return nil
+}
+
+// loadTargetCredentials fills in the tokens of a saved target, which are only
+// fetched from the credential store when they are needed.
+func (c *ConfigRepository) loadTargetCredentials(target *TargetInfo) error {
+	credentials, err := c.targetCredentials(*target)
+	if err != nil || c.credentialStore == nil {
+		return err
+	}
+
+	c.storedCredentials[targetCredentialsKey(target.Name)] = credentials
+	target.AccessToken = credentials.AccessToken
+	target.RefreshToken = credentials.RefreshToken
+	return nil
+}
+
+func (c *ConfigRepository) targetCredentials(target TargetInfo) (Credentials, error) {
+	if c.credentialStore == nil || target.AccessToken != "" || target.RefreshToken != "" {
+		return Credentials{AccessToken: target.AccessToken, RefreshToken: target.RefreshToken}, nil
+	}
+
+	return c.credentialStore.Get(targetCredentialsKey(target.Name))
+}
+
+// save persists the config. With a credential store the tokens are handed to
+// the store and the config file is saved without them.
+func (c *ConfigRepository) save() error {
+	if c.credentialStore == nil {
+		return c.persistor.Save(c.data)
+	}
+
+	err := c.storeCredentials()
+	if err != nil {
+		return err
+	}
+
+	data := c.data.withoutCredentials()
+	err = c.persistor.Save(data)
+	if err != nil {
+		return err
+	}
+
+	data.copyCredentials(c.data)
+	*c.data = *data
+	return nil
+}
+
+func (c *ConfigRepository) storeCredentials() error {
+	credentials := map[string]Credentials{
+		sessionCredentialsKey: {AccessToken: c.data.AccessToken, RefreshToken: c.data.RefreshToken},
+	}
+	for _, target := range c.data.Targets {
+		if target.AccessToken != "" || target.RefreshToken != "" {
+			credentials[targetCredentialsKey(target.Name)] = Credentials{AccessToken: target.AccessToken, RefreshToken: target.RefreshToken}
+		}
+	}
+
+	for key, value := range credentials {
+		stored, known := c.storedCredentials[key]
+		if known && stored == value || !known && value == (Credentials{}) {
+			continue
+		}
+
+		var err error
+		if value == (Credentials{}) {
+			err = c.credentialStore.Erase(key)
+		} else {
+			err = c.credentialStore.Store(key, value)
+		}
+		if err != nil {
+			return err
+		}
+
+		c.storedCredentials[key] = value
+	}
+
+	return nil
+}
+
 // CLOSERS
 
 func (c *ConfigRepository) Close() {
@@ -317,6 +503,50 @@ func (c *ConfigRepository) IsSSLDisabled() (isSSLDisabled bool) {
 	return
 }
 
+// CACertFile names a PEM file of certificate authorities trusted for the
+// targeted endpoints, in addition to the system's.
+func (c *ConfigRepository) CACertFile() (caCertFile string) {
+	c.read(func() {
+		caCertFile = c.data.CACertFile
+	})
+	return
+}
+
+// ClientCertFile and ClientKeyFile name the PEM certificate and key the CLI
+// presents to the targeted endpoints for mutual TLS.
+func (c *ConfigRepository) ClientCertFile() (clientCertFile string) {
+	c.read(func() {
+		clientCertFile = c.data.ClientCertFile
+	})
+	return
+}
+
+func (c *ConfigRepository) ClientKeyFile() (clientKeyFile string) {
+	c.read(func() {
+		clientKeyFile = c.data.ClientKeyFile
+	})
+	return
+}
+
+// ProxyURL is the HTTP or SOCKS5 proxy, such as socks5://bastion:1080, that
+// all traffic to the targeted endpoints goes through instead of the proxy
+// given by the environment.
+func (c *ConfigRepository) ProxyURL() (proxyURL string) {
+	c.read(func() {
+		proxyURL = c.data.ProxyURL
+	})
+	return
+}
+
+// NoProxy lists the hosts, domains and CIDR ranges that are reached without
+// ProxyURL, separated by commas.
+func (c *ConfigRepository) NoProxy() (noProxy string) {
+	c.read(func() {
+		noProxy = c.data.NoProxy
+	})
+	return
+}
+
 func (c *ConfigRepository) IsMinAPIVersion(requiredVersion semver.Version) bool {
 	var apiVersion string
 	c.read(func() {
@@ -402,6 +632,37 @@ func (c *ConfigRepository) PluginRepos() (repos []models.PluginRepo) {
 	return
 }
 
+func (c *ConfigRepository) CurrentTargetName() (name string) {
+	c.read(func() {
+		name = c.data.CurrentTarget
+	})
+	return
+}
+
+func (c *ConfigRepository) SavedTargets() (targets []TargetInfo) {
+	c.read(func() {
+		targets = make([]TargetInfo, len(c.data.Targets))
+		copy(targets, c.data.Targets)
+
+		for i := range targets {
+			credentials, err := c.targetCredentials(targets[i])
+			if err != nil {
+				continue
+			}
+			targets[i].AccessToken = credentials.AccessToken
+			targets[i].RefreshToken = credentials.RefreshToken
+		}
+	})
+	sort.Sort(targetsByName(targets))
+	return
+}
+
+type targetsByName []TargetInfo
+
+func (t targetsByName) Len() int           { return len(t) }
+func (t targetsByName) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
+func (t targetsByName) Less(i, j int) bool { return t[i].Name < t[j].Name }
+
 // SETTERS
 
 func (c *ConfigRepository) ClearSession() {
@@ -413,8 +674,13 @@ func (c *ConfigRepository) ClearSession() {
 	})
 }
 
+// SetAPIEndpoint also forgets the saved target in use, if the endpoint is
+// another one.
 func (c *ConfigRepository) SetAPIEndpoint(endpoint string) {
 	c.write(func() {
+		if endpoint != c.data.Target {
+			c.data.CurrentTarget = ""
+		}
 		c.data.Target = endpoint
 	})
 }
@@ -503,6 +769,26 @@ func (c *ConfigRepository) SetSSLDisabled(disabled bool) {
 	})
 }
 
+func (c *ConfigRepository) SetCACertFile(caCertFile string) {
+	c.write(func() {
+		c.data.CACertFile = caCertFile
+	})
+}
+
+func (c *ConfigRepository) SetClientCertificate(certFile string, keyFile string) {
+	c.write(func() {
+		c.data.ClientCertFile = certFile
+		c.data.ClientKeyFile = keyFile
+	})
+}
+
+func (c *ConfigRepository) SetProxy(proxyURL string, noProxy string) {
+	c.write(func() {
+		c.data.ProxyURL = proxyURL
+		c.data.NoProxy = noProxy
+	})
+}
+
 func (c *ConfigRepository) SetAsyncTimeout(timeout uint) {
 	c.write(func() {
 		c.data.AsyncTimeout = timeout
@@ -538,3 +824,123 @@ func (c *ConfigRepository) UnSetPluginRepo(index int) {
 		c.data.PluginRepos = append(c.data.PluginRepos[:index], c.data.PluginRepos[index+1:]...)
 	})
 }
+
+func (c *ConfigRepository) SaveTarget(name string) {
+	c.write(func() {
+		c.data.storeTarget(name)
+		c.data.CurrentTarget = name
+	})
+}
+
+func (c *ConfigRepository) SwitchTarget(name string) (err error) {
+	c.write(func() {
+		i := c.data.findTarget(name)
+		if i == -1 {
+			err = errors.NewModelNotFoundError("Target", name)
+			return
+		}
+
+		// tokens are refreshed while a target is in use, so remember the
+		// latest session of the target being left before replacing it
+		if current := c.data.findTarget(c.data.CurrentTarget); current != -1 && c.data.Targets[current].Target == c.data.Target {
+			c.data.storeTarget(c.data.CurrentTarget)
+		}
+
+		err = c.loadTargetCredentials(&c.data.Targets[i])
+		if err != nil {
+			return
+		}
+
+		c.data.useTargetInfo(c.data.Targets[i])
+	})
+	return
+}
+
+func (c *ConfigRepository) DeleteTarget(name string) (err error) {
+	c.write(func() {
+		i := c.data.findTarget(name)
+		if i == -1 {
+			err = errors.NewModelNotFoundError("Target", name)
+			return
+		}
+
+		if c.credentialStore != nil {
+			err = c.credentialStore.Erase(targetCredentialsKey(name))
+			if err != nil {
+				return
+			}
+			delete(c.storedCredentials, targetCredentialsKey(name))
+		}
+
+		c.data.Targets = append(c.data.Targets[:i], c.data.Targets[i+1:]...)
+		if c.data.CurrentTarget == name {
+			c.data.CurrentTarget = ""
+		}
+	})
+	return
+}
+
+// SetCredentialStore moves the session tokens, including those of saved
+// targets, into the named credential store and keeps them there from now on.
+func (c *ConfigRepository) SetCredentialStore(name string) error {
+	if name == FileCredentialStoreName {
+		name = ""
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.init()
+
+	if name == c.credentialStoreName {
+		if c.data.CredentialStore == name {
+			return nil
+		}
+		c.data.CredentialStore = name
+		return c.save()
+	}
+
+	// the store is checked before the config changes, so that a store that
+	// cannot be used is never saved as the one to use
+	store, err := c.credentialStores(name)
+	if err != nil {
+		return err
+	}
+
+	for i := range c.data.Targets {
+		err = c.loadTargetCredentials(&c.data.Targets[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	previousName := c.data.CredentialStore
+	previousStoreName := c.credentialStoreName
+	previous := c.credentialStore
+	previousCredentials := c.storedCredentials
+
+	c.data.CredentialStore = name
+	c.credentialStoreName = name
+	c.credentialStore = store
+	c.storedCredentials = map[string]Credentials{}
+
+	err = c.save()
+	if err != nil {
+		c.data.CredentialStore = previousName
+		c.credentialStoreName = previousStoreName
+		c.credentialStore = previous
+		c.storedCredentials = previousCredentials
+		return err
+	}
+
+	if previous == nil {
+		return nil
+	}
+
+	for key := range previousCredentials {
+		err = previous.Erase(key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package coreconfig_test
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -9,6 +10,8 @@ import (
 	"github.com/cloudfoundry/cli/cf/configuration"
 	"github.com/cloudfoundry/cli/cf/configuration/configurationfakes"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig/coreconfigfakes"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/models"
 
 	. "github.com/onsi/ginkgo"
@@ -99,6 +102,17 @@ var _ = Describe("Configuration Repository", func() {
 		config.SetSSLDisabled(false)
 		Expect(config.IsSSLDisabled()).To(BeFalse())
 
+		config.SetCACertFile("/path/to/ca.pem")
+		Expect(config.CACertFile()).To(Equal("/path/to/ca.pem"))
+
+		config.SetClientCertificate("/path/to/client.crt", "/path/to/client.key")
+		Expect(config.ClientCertFile()).To(Equal("/path/to/client.crt"))
+		Expect(config.ClientKeyFile()).To(Equal("/path/to/client.key"))
+
+		config.SetProxy("socks5://bastion:1080", ".internal.example.com")
+		Expect(config.ProxyURL()).To(Equal("socks5://bastion:1080"))
+		Expect(config.NoProxy()).To(Equal(".internal.example.com"))
+
 		config.SetLocale("en_US")
 		Expect(config.Locale()).To(Equal("en_US"))
 
@@ -248,6 +262,413 @@ var _ = Describe("Configuration Repository", func() {
 		})
 	})
 
+	Describe("NewReadOnlyRepositoryFromFilepath", func() {
+		var (
+			tmpDir     string
+			configPath string
+		)
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "test-config")
+			Expect(err).NotTo(HaveOccurred())
+			configPath = filepath.Join(tmpDir, ".cf", "config.json")
+
+			config = coreconfig.NewRepositoryFromFilepath(configPath, func(err error) {
+				panic(err)
+			})
+			config.SetAPIEndpoint("https://api.example.com")
+			config.SetAccessToken("my-access-token")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmpDir)
+		})
+
+		It("loads the config but never saves it", func() {
+			readOnly := coreconfig.NewReadOnlyRepositoryFromFilepath(configPath, func(err error) {
+				panic(err)
+			})
+			Expect(readOnly.APIEndpoint()).To(Equal("https://api.example.com"))
+
+			readOnly.SetAccessToken("replayed-access-token")
+			Expect(readOnly.AccessToken()).To(Equal("replayed-access-token"))
+
+			config = coreconfig.NewRepositoryFromFilepath(configPath, func(err error) {
+				panic(err)
+			})
+			Expect(config.AccessToken()).To(Equal("my-access-token"))
+		})
+	})
+
+	Describe("saved targets", func() {
+		BeforeEach(func() {
+			config.SetAPIEndpoint("https://api.staging.example.com")
+			config.SetAPIVersion("2.54.0")
+			config.SetAccessToken("staging-access-token")
+			config.SetRefreshToken("staging-refresh-token")
+			config.SetSSLDisabled(true)
+			config.SetOrganizationFields(models.OrganizationFields{Name: "staging-org", GUID: "staging-org-guid"})
+			config.SetSpaceFields(models.SpaceFields{Name: "staging-space", GUID: "staging-space-guid"})
+		})
+
+		Describe("SaveTarget", func() {
+			It("saves the current session under the given name", func() {
+				config.SaveTarget("staging")
+
+				targets := config.SavedTargets()
+				Expect(targets).To(HaveLen(1))
+				Expect(targets[0].Name).To(Equal("staging"))
+				Expect(targets[0].Target).To(Equal("https://api.staging.example.com"))
+				Expect(targets[0].APIVersion).To(Equal("2.54.0"))
+				Expect(targets[0].AccessToken).To(Equal("staging-access-token"))
+				Expect(targets[0].RefreshToken).To(Equal("staging-refresh-token"))
+				Expect(targets[0].SSLDisabled).To(BeTrue())
+				Expect(targets[0].OrganizationFields.Name).To(Equal("staging-org"))
+				Expect(targets[0].SpaceFields.Name).To(Equal("staging-space"))
+				Expect(config.CurrentTargetName()).To(Equal("staging"))
+			})
+
+			It("replaces a target saved with the same name", func() {
+				config.SaveTarget("staging")
+				config.SetSpaceFields(models.SpaceFields{Name: "other-space", GUID: "other-space-guid"})
+				config.SaveTarget("staging")
+
+				targets := config.SavedTargets()
+				Expect(targets).To(HaveLen(1))
+				Expect(targets[0].SpaceFields.Name).To(Equal("other-space"))
+			})
+
+			It("lists saved targets sorted by name", func() {
+				config.SaveTarget("staging")
+				config.SaveTarget("dev")
+
+				targets := config.SavedTargets()
+				Expect(targets).To(HaveLen(2))
+				Expect(targets[0].Name).To(Equal("dev"))
+				Expect(targets[1].Name).To(Equal("staging"))
+			})
+
+			It("forgets the target in use when the API endpoint changes", func() {
+				config.SaveTarget("staging")
+
+				config.SetAPIEndpoint("https://api.staging.example.com")
+				Expect(config.CurrentTargetName()).To(Equal("staging"))
+
+				config.SetAPIEndpoint("https://api.prod.example.com")
+				Expect(config.CurrentTargetName()).To(BeEmpty())
+				Expect(config.SavedTargets()).To(HaveLen(1))
+			})
+		})
+
+		Describe("SwitchTarget", func() {
+			BeforeEach(func() {
+				config.SaveTarget("staging")
+
+				config.ClearSession()
+				config.SetAPIEndpoint("https://api.prod.example.com")
+				config.SetAPIVersion("2.60.0")
+				config.SetAccessToken("prod-access-token")
+				config.SetSSLDisabled(false)
+				config.SetOrganizationFields(models.OrganizationFields{Name: "prod-org", GUID: "prod-org-guid"})
+				config.SaveTarget("prod")
+			})
+
+			It("makes the saved target the current one", func() {
+				err := config.SwitchTarget("staging")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(config.APIEndpoint()).To(Equal("https://api.staging.example.com"))
+				Expect(config.APIVersion()).To(Equal("2.54.0"))
+				Expect(config.AccessToken()).To(Equal("staging-access-token"))
+				Expect(config.RefreshToken()).To(Equal("staging-refresh-token"))
+				Expect(config.IsSSLDisabled()).To(BeTrue())
+				Expect(config.OrganizationFields().Name).To(Equal("staging-org"))
+				Expect(config.SpaceFields().Name).To(Equal("staging-space"))
+				Expect(config.CurrentTargetName()).To(Equal("staging"))
+			})
+
+			It("keeps the latest session of the target being left", func() {
+				config.SetAccessToken("refreshed-prod-access-token")
+
+				err := config.SwitchTarget("staging")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = config.SwitchTarget("prod")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.AccessToken()).To(Equal("refreshed-prod-access-token"))
+			})
+
+			It("does not overwrite the saved target when the api endpoint was changed", func() {
+				config.SetAPIEndpoint("https://api.other.example.com")
+				config.SetAccessToken("other-access-token")
+
+				err := config.SwitchTarget("staging")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = config.SwitchTarget("prod")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.APIEndpoint()).To(Equal("https://api.prod.example.com"))
+				Expect(config.AccessToken()).To(Equal("prod-access-token"))
+			})
+
+			It("returns a not found error when the target does not exist", func() {
+				err := config.SwitchTarget("nope")
+				Expect(err).To(BeAssignableToTypeOf(&errors.ModelNotFoundError{}))
+				Expect(config.APIEndpoint()).To(Equal("https://api.prod.example.com"))
+			})
+		})
+
+		Describe("DeleteTarget", func() {
+			BeforeEach(func() {
+				config.SaveTarget("staging")
+			})
+
+			It("removes the saved target without changing the current session", func() {
+				err := config.DeleteTarget("staging")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(config.SavedTargets()).To(BeEmpty())
+				Expect(config.CurrentTargetName()).To(BeEmpty())
+				Expect(config.APIEndpoint()).To(Equal("https://api.staging.example.com"))
+			})
+
+			It("returns a not found error when the target does not exist", func() {
+				err := config.DeleteTarget("nope")
+				Expect(err).To(BeAssignableToTypeOf(&errors.ModelNotFoundError{}))
+			})
+		})
+	})
+
+	Describe("credential stores", func() {
+		var (
+			fileContents string
+			store        *coreconfigfakes.FakeCredentialStore
+			otherStore   *coreconfigfakes.FakeCredentialStore
+			requested    []string
+		)
+
+		fakeStore := func() (*coreconfigfakes.FakeCredentialStore, map[string]coreconfig.Credentials) {
+			stored := map[string]coreconfig.Credentials{}
+			store := new(coreconfigfakes.FakeCredentialStore)
+			store.GetStub = func(key string) (coreconfig.Credentials, error) {
+				return stored[key], nil
+			}
+			store.StoreStub = func(key string, credentials coreconfig.Credentials) error {
+				stored[key] = credentials
+				return nil
+			}
+			store.EraseStub = func(key string) error {
+				delete(stored, key)
+				return nil
+			}
+			return store, stored
+		}
+
+		var stored, otherStored map[string]coreconfig.Credentials
+
+		newConfig := func() coreconfig.Repository {
+			return coreconfig.NewRepository(persistor, func(name string) (coreconfig.CredentialStore, error) {
+				requested = append(requested, name)
+				switch name {
+				case "":
+					return nil, nil
+				case "fake":
+					return store, nil
+				case "other":
+					return otherStore, nil
+				default:
+					return nil, fmt.Errorf("Unknown credential store '%s'", name)
+				}
+			}, func(err error) { panic(err) })
+		}
+
+		BeforeEach(func() {
+			requested = nil
+			store, stored = fakeStore()
+			otherStore, otherStored = fakeStore()
+
+			fileContents = `{"ConfigVersion": 3, "CredentialStore": "fake"}`
+			persistor.LoadStub = func(data configuration.DataInterface) error {
+				return data.JSONUnmarshalV3([]byte(fileContents))
+			}
+			persistor.SaveStub = func(data configuration.DataInterface) error {
+				contents, err := data.JSONMarshalV3()
+				fileContents = string(contents)
+				return err
+			}
+		})
+
+		It("keeps tokens in the config file when no store is chosen", func() {
+			fileContents = `{"ConfigVersion": 3}`
+			config = newConfig()
+
+			config.SetAccessToken("the-access-token")
+
+			Expect(requested).To(Equal([]string{""}))
+			Expect(fileContents).To(ContainSubstring("the-access-token"))
+		})
+
+		It("gets the session tokens from the store named in the config", func() {
+			stored["session"] = coreconfig.Credentials{AccessToken: "stored-access-token", RefreshToken: "stored-refresh-token"}
+			config = newConfig()
+
+			Expect(config.AccessToken()).To(Equal("stored-access-token"))
+			Expect(config.RefreshToken()).To(Equal("stored-refresh-token"))
+			Expect(requested).To(Equal([]string{"fake"}))
+		})
+
+		It("prefers the store named by CF_CREDENTIAL_STORE", func() {
+			os.Setenv("CF_CREDENTIAL_STORE", "other")
+			defer os.Unsetenv("CF_CREDENTIAL_STORE")
+			config = newConfig()
+
+			config.AccessToken()
+			Expect(requested).To(Equal([]string{"other"}))
+		})
+
+		It("reports an unknown store", func() {
+			fileContents = `{"ConfigVersion": 3, "CredentialStore": "keyring"}`
+
+			var reported error
+			config = coreconfig.NewRepository(persistor, func(name string) (coreconfig.CredentialStore, error) {
+				return coreconfig.NewCredentialStore(name, "")
+			}, func(err error) { reported = err })
+
+			config.AccessToken()
+			Expect(reported).To(HaveOccurred())
+			Expect(reported.Error()).To(ContainSubstring("Unknown credential store 'keyring'"))
+		})
+
+		It("hands new tokens to the store and leaves them out of the config file", func() {
+			config = newConfig()
+
+			config.SetAccessToken("new-access-token")
+			config.SetRefreshToken("new-refresh-token")
+
+			Expect(stored["session"]).To(Equal(coreconfig.Credentials{AccessToken: "new-access-token", RefreshToken: "new-refresh-token"}))
+			Expect(fileContents).NotTo(ContainSubstring("new-access-token"))
+			Expect(fileContents).NotTo(ContainSubstring("new-refresh-token"))
+			Expect(config.AccessToken()).To(Equal("new-access-token"))
+		})
+
+		It("only hands tokens to the store when they change", func() {
+			config = newConfig()
+			config.SetAccessToken("new-access-token")
+			config.SetAsyncTimeout(5)
+
+			Expect(store.StoreCallCount()).To(Equal(1))
+		})
+
+		It("moves tokens found in the config file into the store", func() {
+			fileContents = `{"ConfigVersion": 3, "CredentialStore": "fake", "AccessToken": "file-access-token", "RefreshToken": "file-refresh-token"}`
+			config = newConfig()
+
+			Expect(config.AccessToken()).To(Equal("file-access-token"))
+			Expect(stored["session"]).To(Equal(coreconfig.Credentials{AccessToken: "file-access-token", RefreshToken: "file-refresh-token"}))
+			Expect(fileContents).NotTo(ContainSubstring("file-access-token"))
+		})
+
+		It("erases the session from the store when it is cleared", func() {
+			stored["session"] = coreconfig.Credentials{AccessToken: "stored-access-token"}
+			config = newConfig()
+
+			config.ClearSession()
+
+			Expect(stored).NotTo(HaveKey("session"))
+			Expect(config.IsLoggedIn()).To(BeFalse())
+		})
+
+		Describe("saved targets", func() {
+			BeforeEach(func() {
+				config = newConfig()
+				config.SetAPIEndpoint("https://api.dev.example.com")
+				config.SetAccessToken("dev-access-token")
+				config.SaveTarget("dev")
+			})
+
+			It("keeps their tokens in the store", func() {
+				Expect(stored["target:dev"]).To(Equal(coreconfig.Credentials{AccessToken: "dev-access-token"}))
+				Expect(fileContents).NotTo(ContainSubstring("dev-access-token"))
+			})
+
+			It("gets their tokens from the store when switching to them", func() {
+				config = newConfig()
+				config.ClearSession()
+				config.SetAPIEndpoint("https://api.prod.example.com")
+
+				Expect(config.SavedTargets()[0].AccessToken).To(Equal("dev-access-token"))
+
+				Expect(config.SwitchTarget("dev")).To(Succeed())
+				Expect(config.AccessToken()).To(Equal("dev-access-token"))
+				Expect(stored["session"].AccessToken).To(Equal("dev-access-token"))
+			})
+
+			It("erases their tokens when they are deleted", func() {
+				Expect(config.DeleteTarget("dev")).To(Succeed())
+				Expect(stored).NotTo(HaveKey("target:dev"))
+			})
+		})
+
+		Describe("SetCredentialStore", func() {
+			BeforeEach(func() {
+				config = newConfig()
+				config.SetAccessToken("the-access-token")
+				config.SaveTarget("dev")
+				config = newConfig()
+			})
+
+			It("moves every token into the new store", func() {
+				Expect(config.SetCredentialStore("other")).To(Succeed())
+
+				Expect(otherStored["session"].AccessToken).To(Equal("the-access-token"))
+				Expect(otherStored["target:dev"].AccessToken).To(Equal("the-access-token"))
+				Expect(stored).To(BeEmpty())
+				Expect(fileContents).To(ContainSubstring(`"CredentialStore": "other"`))
+			})
+
+			It("moves every token back into the config file", func() {
+				Expect(config.SetCredentialStore("file")).To(Succeed())
+
+				Expect(stored).To(BeEmpty())
+				Expect(fileContents).To(ContainSubstring("the-access-token"))
+				Expect(fileContents).NotTo(ContainSubstring("CredentialStore"))
+			})
+
+			It("does nothing when the store is already in use", func() {
+				Expect(config.SetCredentialStore("fake")).To(Succeed())
+
+				Expect(stored["session"].AccessToken).To(Equal("the-access-token"))
+				Expect(store.EraseCallCount()).To(BeZero())
+			})
+
+			It("returns an error for unknown stores", func() {
+				Expect(config.SetCredentialStore("keyring")).NotTo(Succeed())
+				Expect(stored["session"].AccessToken).To(Equal("the-access-token"))
+			})
+
+			It("leaves the config file usable when the store cannot be used", func() {
+				saved := fileContents
+				Expect(config.SetCredentialStore("keyring")).NotTo(Succeed())
+				Expect(fileContents).To(Equal(saved))
+
+				config = newConfig()
+				Expect(config.AccessToken()).To(Equal("the-access-token"))
+				Expect(config.SetCredentialStore("file")).To(Succeed())
+			})
+
+			It("keeps the current store when the config cannot be saved", func() {
+				persistor.SaveReturns(errors.New("disk full"))
+				persistor.SaveStub = nil
+
+				Expect(config.SetCredentialStore("other")).To(MatchError("disk full"))
+				Expect(fileContents).To(ContainSubstring(`"CredentialStore": "fake"`))
+				Expect(stored["session"].AccessToken).To(Equal("the-access-token"))
+				Expect(store.EraseCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("IsMinCLIVersion", func() {
 		It("returns true when the actual version is BUILT_FROM_SOURCE", func() {
 			Expect(config.IsMinCLIVersion("BUILT_FROM_SOURCE")).To(BeTrue())
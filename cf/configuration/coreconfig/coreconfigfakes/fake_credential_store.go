@@ -0,0 +1,137 @@
+// This file was generated by counterfeiter
+package coreconfigfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+)
+
+type FakeCredentialStore struct {
+	GetStub        func(key string) (coreconfig.Credentials, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		key string
+	}
+	getReturns struct {
+		result1 coreconfig.Credentials
+		result2 error
+	}
+	StoreStub        func(key string, credentials coreconfig.Credentials) error
+	storeMutex       sync.RWMutex
+	storeArgsForCall []struct {
+		key         string
+		credentials coreconfig.Credentials
+	}
+	storeReturns struct {
+		result1 error
+	}
+	EraseStub        func(key string) error
+	eraseMutex       sync.RWMutex
+	eraseArgsForCall []struct {
+		key string
+	}
+	eraseReturns struct {
+		result1 error
+	}
+}
+
+func (fake *FakeCredentialStore) Get(key string) (coreconfig.Credentials, error) {
+	fake.getMutex.Lock()
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		key string
+	}{key})
+	fake.getMutex.Unlock()
+	if fake.GetStub != nil {
+		return fake.GetStub(key)
+	} else {
+		return fake.getReturns.result1, fake.getReturns.result2
+	}
+}
+
+func (fake *FakeCredentialStore) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeCredentialStore) GetArgsForCall(i int) string {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return fake.getArgsForCall[i].key
+}
+
+func (fake *FakeCredentialStore) GetReturns(result1 coreconfig.Credentials, result2 error) {
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 coreconfig.Credentials
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCredentialStore) Store(key string, credentials coreconfig.Credentials) error {
+	fake.storeMutex.Lock()
+	fake.storeArgsForCall = append(fake.storeArgsForCall, struct {
+		key         string
+		credentials coreconfig.Credentials
+	}{key, credentials})
+	fake.storeMutex.Unlock()
+	if fake.StoreStub != nil {
+		return fake.StoreStub(key, credentials)
+	} else {
+		return fake.storeReturns.result1
+	}
+}
+
+func (fake *FakeCredentialStore) StoreCallCount() int {
+	fake.storeMutex.RLock()
+	defer fake.storeMutex.RUnlock()
+	return len(fake.storeArgsForCall)
+}
+
+func (fake *FakeCredentialStore) StoreArgsForCall(i int) (string, coreconfig.Credentials) {
+	fake.storeMutex.RLock()
+	defer fake.storeMutex.RUnlock()
+	return fake.storeArgsForCall[i].key, fake.storeArgsForCall[i].credentials
+}
+
+func (fake *FakeCredentialStore) StoreReturns(result1 error) {
+	fake.StoreStub = nil
+	fake.storeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCredentialStore) Erase(key string) error {
+	fake.eraseMutex.Lock()
+	fake.eraseArgsForCall = append(fake.eraseArgsForCall, struct {
+		key string
+	}{key})
+	fake.eraseMutex.Unlock()
+	if fake.EraseStub != nil {
+		return fake.EraseStub(key)
+	} else {
+		return fake.eraseReturns.result1
+	}
+}
+
+func (fake *FakeCredentialStore) EraseCallCount() int {
+	fake.eraseMutex.RLock()
+	defer fake.eraseMutex.RUnlock()
+	return len(fake.eraseArgsForCall)
+}
+
+func (fake *FakeCredentialStore) EraseArgsForCall(i int) string {
+	fake.eraseMutex.RLock()
+	defer fake.eraseMutex.RUnlock()
+	return fake.eraseArgsForCall[i].key
+}
+
+func (fake *FakeCredentialStore) EraseReturns(result1 error) {
+	fake.EraseStub = nil
+	fake.eraseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+var _ coreconfig.CredentialStore = new(FakeCredentialStore)
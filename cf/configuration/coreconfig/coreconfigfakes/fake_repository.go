@@ -130,6 +130,24 @@ type FakeRepository struct {
 	isSSLDisabledReturns     struct {
 		result1 bool
 	}
+	CACertFileStub        func() string
+	cACertFileMutex       sync.RWMutex
+	cACertFileArgsForCall []struct{}
+	cACertFileReturns     struct {
+		result1 string
+	}
+	ClientCertFileStub        func() string
+	clientCertFileMutex       sync.RWMutex
+	clientCertFileArgsForCall []struct{}
+	clientCertFileReturns     struct {
+		result1 string
+	}
+	ClientKeyFileStub        func() string
+	clientKeyFileMutex       sync.RWMutex
+	clientKeyFileArgsForCall []struct{}
+	clientKeyFileReturns     struct {
+		result1 string
+	}
 	IsMinAPIVersionStub        func(semver.Version) bool
 	isMinAPIVersionMutex       sync.RWMutex
 	isMinAPIVersionArgsForCall []struct {
@@ -188,6 +206,18 @@ type FakeRepository struct {
 	pluginReposReturns     struct {
 		result1 []models.PluginRepo
 	}
+	CurrentTargetNameStub        func() string
+	currentTargetNameMutex       sync.RWMutex
+	currentTargetNameArgsForCall []struct{}
+	currentTargetNameReturns     struct {
+		result1 string
+	}
+	SavedTargetsStub        func() []coreconfig.TargetInfo
+	savedTargetsMutex       sync.RWMutex
+	savedTargetsArgsForCall []struct{}
+	savedTargetsReturns     struct {
+		result1 []coreconfig.TargetInfo
+	}
 	ClearSessionStub          func()
 	clearSessionMutex         sync.RWMutex
 	clearSessionArgsForCall   []struct{}
@@ -266,6 +296,17 @@ type FakeRepository struct {
 	setSSLDisabledArgsForCall []struct {
 		arg1 bool
 	}
+	SetCACertFileStub        func(string)
+	setCACertFileMutex       sync.RWMutex
+	setCACertFileArgsForCall []struct {
+		arg1 string
+	}
+	SetClientCertificateStub        func(string, string)
+	setClientCertificateMutex       sync.RWMutex
+	setClientCertificateArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
 	SetAsyncTimeoutStub        func(uint)
 	setAsyncTimeoutMutex       sync.RWMutex
 	setAsyncTimeoutArgsForCall []struct {
@@ -296,9 +337,56 @@ type FakeRepository struct {
 	unSetPluginRepoArgsForCall []struct {
 		arg1 int
 	}
-	CloseStub        func()
-	closeMutex       sync.RWMutex
-	closeArgsForCall []struct{}
+	SaveTargetStub        func(string)
+	saveTargetMutex       sync.RWMutex
+	saveTargetArgsForCall []struct {
+		arg1 string
+	}
+	SwitchTargetStub        func(string) error
+	switchTargetMutex       sync.RWMutex
+	switchTargetArgsForCall []struct {
+		arg1 string
+	}
+	switchTargetReturns struct {
+		result1 error
+	}
+	DeleteTargetStub        func(string) error
+	deleteTargetMutex       sync.RWMutex
+	deleteTargetArgsForCall []struct {
+		arg1 string
+	}
+	deleteTargetReturns struct {
+		result1 error
+	}
+	SetCredentialStoreStub        func(string) error
+	setCredentialStoreMutex       sync.RWMutex
+	setCredentialStoreArgsForCall []struct {
+		arg1 string
+	}
+	setCredentialStoreReturns struct {
+		result1 error
+	}
+	CloseStub           func()
+	closeMutex          sync.RWMutex
+	closeArgsForCall    []struct{}
+	ProxyURLStub        func() string
+	proxyURLMutex       sync.RWMutex
+	proxyURLArgsForCall []struct{}
+	proxyURLReturns     struct {
+		result1 string
+	}
+	NoProxyStub        func() string
+	noProxyMutex       sync.RWMutex
+	noProxyArgsForCall []struct{}
+	noProxyReturns     struct {
+		result1 string
+	}
+	SetProxyStub        func(string, string)
+	setProxyMutex       sync.RWMutex
+	setProxyArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
 }
 
 func (fake *FakeRepository) APIEndpoint() string {
@@ -781,6 +869,78 @@ func (fake *FakeRepository) IsSSLDisabledReturns(result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeRepository) CACertFile() string {
+	fake.cACertFileMutex.Lock()
+	fake.cACertFileArgsForCall = append(fake.cACertFileArgsForCall, struct{}{})
+	fake.cACertFileMutex.Unlock()
+	if fake.CACertFileStub != nil {
+		return fake.CACertFileStub()
+	} else {
+		return fake.cACertFileReturns.result1
+	}
+}
+
+func (fake *FakeRepository) CACertFileCallCount() int {
+	fake.cACertFileMutex.RLock()
+	defer fake.cACertFileMutex.RUnlock()
+	return len(fake.cACertFileArgsForCall)
+}
+
+func (fake *FakeRepository) CACertFileReturns(result1 string) {
+	fake.CACertFileStub = nil
+	fake.cACertFileReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) ClientCertFile() string {
+	fake.clientCertFileMutex.Lock()
+	fake.clientCertFileArgsForCall = append(fake.clientCertFileArgsForCall, struct{}{})
+	fake.clientCertFileMutex.Unlock()
+	if fake.ClientCertFileStub != nil {
+		return fake.ClientCertFileStub()
+	} else {
+		return fake.clientCertFileReturns.result1
+	}
+}
+
+func (fake *FakeRepository) ClientCertFileCallCount() int {
+	fake.clientCertFileMutex.RLock()
+	defer fake.clientCertFileMutex.RUnlock()
+	return len(fake.clientCertFileArgsForCall)
+}
+
+func (fake *FakeRepository) ClientCertFileReturns(result1 string) {
+	fake.ClientCertFileStub = nil
+	fake.clientCertFileReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) ClientKeyFile() string {
+	fake.clientKeyFileMutex.Lock()
+	fake.clientKeyFileArgsForCall = append(fake.clientKeyFileArgsForCall, struct{}{})
+	fake.clientKeyFileMutex.Unlock()
+	if fake.ClientKeyFileStub != nil {
+		return fake.ClientKeyFileStub()
+	} else {
+		return fake.clientKeyFileReturns.result1
+	}
+}
+
+func (fake *FakeRepository) ClientKeyFileCallCount() int {
+	fake.clientKeyFileMutex.RLock()
+	defer fake.clientKeyFileMutex.RUnlock()
+	return len(fake.clientKeyFileArgsForCall)
+}
+
+func (fake *FakeRepository) ClientKeyFileReturns(result1 string) {
+	fake.ClientKeyFileStub = nil
+	fake.clientKeyFileReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeRepository) IsMinAPIVersion(arg1 semver.Version) bool {
 	fake.isMinAPIVersionMutex.Lock()
 	fake.isMinAPIVersionArgsForCall = append(fake.isMinAPIVersionArgsForCall, struct {
@@ -1013,6 +1173,54 @@ func (fake *FakeRepository) PluginReposReturns(result1 []models.PluginRepo) {
 	}{result1}
 }
 
+func (fake *FakeRepository) CurrentTargetName() string {
+	fake.currentTargetNameMutex.Lock()
+	fake.currentTargetNameArgsForCall = append(fake.currentTargetNameArgsForCall, struct{}{})
+	fake.currentTargetNameMutex.Unlock()
+	if fake.CurrentTargetNameStub != nil {
+		return fake.CurrentTargetNameStub()
+	} else {
+		return fake.currentTargetNameReturns.result1
+	}
+}
+
+func (fake *FakeRepository) CurrentTargetNameCallCount() int {
+	fake.currentTargetNameMutex.RLock()
+	defer fake.currentTargetNameMutex.RUnlock()
+	return len(fake.currentTargetNameArgsForCall)
+}
+
+func (fake *FakeRepository) CurrentTargetNameReturns(result1 string) {
+	fake.CurrentTargetNameStub = nil
+	fake.currentTargetNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) SavedTargets() []coreconfig.TargetInfo {
+	fake.savedTargetsMutex.Lock()
+	fake.savedTargetsArgsForCall = append(fake.savedTargetsArgsForCall, struct{}{})
+	fake.savedTargetsMutex.Unlock()
+	if fake.SavedTargetsStub != nil {
+		return fake.SavedTargetsStub()
+	} else {
+		return fake.savedTargetsReturns.result1
+	}
+}
+
+func (fake *FakeRepository) SavedTargetsCallCount() int {
+	fake.savedTargetsMutex.RLock()
+	defer fake.savedTargetsMutex.RUnlock()
+	return len(fake.savedTargetsArgsForCall)
+}
+
+func (fake *FakeRepository) SavedTargetsReturns(result1 []coreconfig.TargetInfo) {
+	fake.SavedTargetsStub = nil
+	fake.savedTargetsReturns = struct {
+		result1 []coreconfig.TargetInfo
+	}{result1}
+}
+
 func (fake *FakeRepository) ClearSession() {
 	fake.clearSessionMutex.Lock()
 	fake.clearSessionArgsForCall = append(fake.clearSessionArgsForCall, struct{}{})
@@ -1373,6 +1581,53 @@ func (fake *FakeRepository) SetSSLDisabledArgsForCall(i int) bool {
 	return fake.setSSLDisabledArgsForCall[i].arg1
 }
 
+func (fake *FakeRepository) SetCACertFile(arg1 string) {
+	fake.setCACertFileMutex.Lock()
+	fake.setCACertFileArgsForCall = append(fake.setCACertFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setCACertFileMutex.Unlock()
+	if fake.SetCACertFileStub != nil {
+		fake.SetCACertFileStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetCACertFileCallCount() int {
+	fake.setCACertFileMutex.RLock()
+	defer fake.setCACertFileMutex.RUnlock()
+	return len(fake.setCACertFileArgsForCall)
+}
+
+func (fake *FakeRepository) SetCACertFileArgsForCall(i int) string {
+	fake.setCACertFileMutex.RLock()
+	defer fake.setCACertFileMutex.RUnlock()
+	return fake.setCACertFileArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetClientCertificate(arg1 string, arg2 string) {
+	fake.setClientCertificateMutex.Lock()
+	fake.setClientCertificateArgsForCall = append(fake.setClientCertificateArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.setClientCertificateMutex.Unlock()
+	if fake.SetClientCertificateStub != nil {
+		fake.SetClientCertificateStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeRepository) SetClientCertificateCallCount() int {
+	fake.setClientCertificateMutex.RLock()
+	defer fake.setClientCertificateMutex.RUnlock()
+	return len(fake.setClientCertificateArgsForCall)
+}
+
+func (fake *FakeRepository) SetClientCertificateArgsForCall(i int) (string, string) {
+	fake.setClientCertificateMutex.RLock()
+	defer fake.setClientCertificateMutex.RUnlock()
+	return fake.setClientCertificateArgsForCall[i].arg1, fake.setClientCertificateArgsForCall[i].arg2
+}
+
 func (fake *FakeRepository) SetAsyncTimeout(arg1 uint) {
 	fake.setAsyncTimeoutMutex.Lock()
 	fake.setAsyncTimeoutArgsForCall = append(fake.setAsyncTimeoutArgsForCall, struct {
@@ -1511,6 +1766,125 @@ func (fake *FakeRepository) UnSetPluginRepoArgsForCall(i int) int {
 	return fake.unSetPluginRepoArgsForCall[i].arg1
 }
 
+func (fake *FakeRepository) SaveTarget(arg1 string) {
+	fake.saveTargetMutex.Lock()
+	fake.saveTargetArgsForCall = append(fake.saveTargetArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.saveTargetMutex.Unlock()
+	if fake.SaveTargetStub != nil {
+		fake.SaveTargetStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SaveTargetCallCount() int {
+	fake.saveTargetMutex.RLock()
+	defer fake.saveTargetMutex.RUnlock()
+	return len(fake.saveTargetArgsForCall)
+}
+
+func (fake *FakeRepository) SaveTargetArgsForCall(i int) string {
+	fake.saveTargetMutex.RLock()
+	defer fake.saveTargetMutex.RUnlock()
+	return fake.saveTargetArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SwitchTarget(arg1 string) error {
+	fake.switchTargetMutex.Lock()
+	fake.switchTargetArgsForCall = append(fake.switchTargetArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.switchTargetMutex.Unlock()
+	if fake.SwitchTargetStub != nil {
+		return fake.SwitchTargetStub(arg1)
+	} else {
+		return fake.switchTargetReturns.result1
+	}
+}
+
+func (fake *FakeRepository) SwitchTargetCallCount() int {
+	fake.switchTargetMutex.RLock()
+	defer fake.switchTargetMutex.RUnlock()
+	return len(fake.switchTargetArgsForCall)
+}
+
+func (fake *FakeRepository) SwitchTargetArgsForCall(i int) string {
+	fake.switchTargetMutex.RLock()
+	defer fake.switchTargetMutex.RUnlock()
+	return fake.switchTargetArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SwitchTargetReturns(result1 error) {
+	fake.SwitchTargetStub = nil
+	fake.switchTargetReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) DeleteTarget(arg1 string) error {
+	fake.deleteTargetMutex.Lock()
+	fake.deleteTargetArgsForCall = append(fake.deleteTargetArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.deleteTargetMutex.Unlock()
+	if fake.DeleteTargetStub != nil {
+		return fake.DeleteTargetStub(arg1)
+	} else {
+		return fake.deleteTargetReturns.result1
+	}
+}
+
+func (fake *FakeRepository) DeleteTargetCallCount() int {
+	fake.deleteTargetMutex.RLock()
+	defer fake.deleteTargetMutex.RUnlock()
+	return len(fake.deleteTargetArgsForCall)
+}
+
+func (fake *FakeRepository) DeleteTargetArgsForCall(i int) string {
+	fake.deleteTargetMutex.RLock()
+	defer fake.deleteTargetMutex.RUnlock()
+	return fake.deleteTargetArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) DeleteTargetReturns(result1 error) {
+	fake.DeleteTargetStub = nil
+	fake.deleteTargetReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) SetCredentialStore(arg1 string) error {
+	fake.setCredentialStoreMutex.Lock()
+	fake.setCredentialStoreArgsForCall = append(fake.setCredentialStoreArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setCredentialStoreMutex.Unlock()
+	if fake.SetCredentialStoreStub != nil {
+		return fake.SetCredentialStoreStub(arg1)
+	} else {
+		return fake.setCredentialStoreReturns.result1
+	}
+}
+
+func (fake *FakeRepository) SetCredentialStoreCallCount() int {
+	fake.setCredentialStoreMutex.RLock()
+	defer fake.setCredentialStoreMutex.RUnlock()
+	return len(fake.setCredentialStoreArgsForCall)
+}
+
+func (fake *FakeRepository) SetCredentialStoreArgsForCall(i int) string {
+	fake.setCredentialStoreMutex.RLock()
+	defer fake.setCredentialStoreMutex.RUnlock()
+	return fake.setCredentialStoreArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetCredentialStoreReturns(result1 error) {
+	fake.SetCredentialStoreStub = nil
+	fake.setCredentialStoreReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRepository) Close() {
 	fake.closeMutex.Lock()
 	fake.closeArgsForCall = append(fake.closeArgsForCall, struct{}{})
@@ -1526,4 +1900,76 @@ func (fake *FakeRepository) CloseCallCount() int {
 	return len(fake.closeArgsForCall)
 }
 
+func (fake *FakeRepository) ProxyURL() string {
+	fake.proxyURLMutex.Lock()
+	fake.proxyURLArgsForCall = append(fake.proxyURLArgsForCall, struct{}{})
+	fake.proxyURLMutex.Unlock()
+	if fake.ProxyURLStub != nil {
+		return fake.ProxyURLStub()
+	} else {
+		return fake.proxyURLReturns.result1
+	}
+}
+
+func (fake *FakeRepository) ProxyURLCallCount() int {
+	fake.proxyURLMutex.RLock()
+	defer fake.proxyURLMutex.RUnlock()
+	return len(fake.proxyURLArgsForCall)
+}
+
+func (fake *FakeRepository) ProxyURLReturns(result1 string) {
+	fake.ProxyURLStub = nil
+	fake.proxyURLReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) NoProxy() string {
+	fake.noProxyMutex.Lock()
+	fake.noProxyArgsForCall = append(fake.noProxyArgsForCall, struct{}{})
+	fake.noProxyMutex.Unlock()
+	if fake.NoProxyStub != nil {
+		return fake.NoProxyStub()
+	} else {
+		return fake.noProxyReturns.result1
+	}
+}
+
+func (fake *FakeRepository) NoProxyCallCount() int {
+	fake.noProxyMutex.RLock()
+	defer fake.noProxyMutex.RUnlock()
+	return len(fake.noProxyArgsForCall)
+}
+
+func (fake *FakeRepository) NoProxyReturns(result1 string) {
+	fake.NoProxyStub = nil
+	fake.noProxyReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) SetProxy(arg1 string, arg2 string) {
+	fake.setProxyMutex.Lock()
+	fake.setProxyArgsForCall = append(fake.setProxyArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.setProxyMutex.Unlock()
+	if fake.SetProxyStub != nil {
+		fake.SetProxyStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeRepository) SetProxyCallCount() int {
+	fake.setProxyMutex.RLock()
+	defer fake.setProxyMutex.RUnlock()
+	return len(fake.setProxyArgsForCall)
+}
+
+func (fake *FakeRepository) SetProxyArgsForCall(i int) (string, string) {
+	fake.setProxyMutex.RLock()
+	defer fake.setProxyMutex.RUnlock()
+	return fake.setProxyArgsForCall[i].arg1, fake.setProxyArgsForCall[i].arg2
+}
+
 var _ coreconfig.Repository = new(FakeRepository)
@@ -0,0 +1,75 @@
+package coreconfig
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Credentials are the session tokens the CLI keeps for a target.
+type Credentials struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+//go:generate counterfeiter . CredentialStore
+
+// CredentialStore keeps session tokens outside of the config file. Keys name
+// the current session or a saved target; getting a key that was never stored
+// returns empty Credentials.
+type CredentialStore interface {
+	Get(key string) (Credentials, error)
+	Store(key string, credentials Credentials) error
+	Erase(key string) error
+}
+
+// CredentialStoreFactory returns the credential store with the given name. A
+// nil store means that credentials are kept in the config file.
+type CredentialStoreFactory func(name string) (CredentialStore, error)
+
+const (
+	FileCredentialStoreName          = "file"
+	EncryptedFileCredentialStoreName = "encrypted-file"
+	HelperCredentialStorePrefix      = "helper:"
+
+	sessionCredentialsKey = "session"
+)
+
+func targetCredentialsKey(name string) string {
+	return "target:" + name
+}
+
+// NewCredentialStore returns the store with the given name for the config
+// file at configPath:
+//
+//	file            tokens stay in the config file (the default)
+//	encrypted-file  tokens are encrypted into a file next to the config file
+//	helper:NAME     tokens are handed to the cf-credential-NAME executable
+func NewCredentialStore(name string, configPath string) (CredentialStore, error) {
+	switch {
+	case name == "" || name == FileCredentialStoreName:
+		return nil, nil
+	case name == EncryptedFileCredentialStoreName:
+		store, err := NewEncryptedFileCredentialStore(configPath)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case strings.HasPrefix(name, HelperCredentialStorePrefix) && len(name) > len(HelperCredentialStorePrefix):
+		return NewHelperCredentialStore(strings.TrimPrefix(name, HelperCredentialStorePrefix), configPath), nil
+	default:
+		return nil, fmt.Errorf("Unknown credential store '%s'. Use '%s', '%s' or '%sNAME'.", name, FileCredentialStoreName, EncryptedFileCredentialStoreName, HelperCredentialStorePrefix)
+	}
+}
+
+// readOnlyCredentialStore gets credentials from a store without changing it.
+type readOnlyCredentialStore struct {
+	CredentialStore
+}
+
+func (store readOnlyCredentialStore) Store(key string, credentials Credentials) error {
+	return nil
+}
+
+func (store readOnlyCredentialStore) Erase(key string) error {
+	return nil
+}
@@ -0,0 +1,211 @@
+package coreconfig_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/onsi/gomega/gexec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Credential stores", func() {
+	var (
+		dir        string
+		configPath string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "credential-store")
+		Expect(err).NotTo(HaveOccurred())
+		configPath = filepath.Join(dir, "config.json")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("NewCredentialStore", func() {
+		It("keeps credentials in the config file by default", func() {
+			store, err := coreconfig.NewCredentialStore("", configPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(store).To(BeNil())
+
+			store, err = coreconfig.NewCredentialStore("file", configPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(store).To(BeNil())
+		})
+
+		It("returns a helper store for helper:NAME", func() {
+			store, err := coreconfig.NewCredentialStore("helper:osxkeychain", configPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(store).To(BeAssignableToTypeOf(coreconfig.HelperCredentialStore{}))
+		})
+
+		It("returns an error for unknown stores", func() {
+			_, err := coreconfig.NewCredentialStore("keyring", configPath)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unknown credential store 'keyring'"))
+
+			_, err = coreconfig.NewCredentialStore("helper:", configPath)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("EncryptedFileCredentialStore", func() {
+		var credentials coreconfig.Credentials
+
+		BeforeEach(func() {
+			credentials = coreconfig.Credentials{AccessToken: "bearer the-access-token", RefreshToken: "the-refresh-token"}
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("CF_CREDENTIAL_PASSPHRASE")
+			os.Unsetenv("CF_CREDENTIAL_KEY")
+		})
+
+		It("requires a passphrase or a key", func() {
+			_, err := coreconfig.NewEncryptedFileCredentialStore(configPath)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("CF_CREDENTIAL_PASSPHRASE"))
+		})
+
+		It("rejects keys of the wrong length", func() {
+			os.Setenv("CF_CREDENTIAL_KEY", "abcd")
+
+			_, err := coreconfig.NewEncryptedFileCredentialStore(configPath)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("64 hex digits"))
+		})
+
+		Context("with a passphrase", func() {
+			BeforeEach(func() {
+				os.Setenv("CF_CREDENTIAL_PASSPHRASE", "correct horse battery staple")
+			})
+
+			It("stores credentials encrypted next to the config file", func() {
+				store, err := coreconfig.NewEncryptedFileCredentialStore(configPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(store.Store("session", credentials)).To(Succeed())
+
+				contents, err := ioutil.ReadFile(filepath.Join(dir, "credentials.enc"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(contents)).NotTo(ContainSubstring("the-access-token"))
+				Expect(string(contents)).NotTo(ContainSubstring("the-refresh-token"))
+
+				store, err = coreconfig.NewEncryptedFileCredentialStore(configPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(store.Get("session")).To(Equal(credentials))
+			})
+
+			It("returns empty credentials for unknown keys", func() {
+				store, err := coreconfig.NewEncryptedFileCredentialStore(configPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(store.Get("session")).To(Equal(coreconfig.Credentials{}))
+			})
+
+			It("erases credentials", func() {
+				store, err := coreconfig.NewEncryptedFileCredentialStore(configPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(store.Store("session", credentials)).To(Succeed())
+				Expect(store.Store("target:other", credentials)).To(Succeed())
+				Expect(store.Erase("session")).To(Succeed())
+
+				store, err = coreconfig.NewEncryptedFileCredentialStore(configPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(store.Get("session")).To(Equal(coreconfig.Credentials{}))
+				Expect(store.Get("target:other")).To(Equal(credentials))
+			})
+
+			It("fails to read credentials with a different passphrase", func() {
+				store, err := coreconfig.NewEncryptedFileCredentialStore(configPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(store.Store("session", credentials)).To(Succeed())
+
+				os.Setenv("CF_CREDENTIAL_PASSPHRASE", "incorrect horse")
+				store, err = coreconfig.NewEncryptedFileCredentialStore(configPath)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = store.Get("session")
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Unable to decrypt"))
+			})
+		})
+
+		Context("with a key", func() {
+			BeforeEach(func() {
+				os.Setenv("CF_CREDENTIAL_KEY", strings.Repeat("0123456789abcdef", 4))
+			})
+
+			It("stores and reads back credentials", func() {
+				store, err := coreconfig.NewEncryptedFileCredentialStore(configPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(store.Store("session", credentials)).To(Succeed())
+
+				store, err = coreconfig.NewEncryptedFileCredentialStore(configPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(store.Get("session")).To(Equal(credentials))
+			})
+		})
+	})
+
+	Describe("HelperCredentialStore", func() {
+		var (
+			helperPath string
+			store      coreconfig.HelperCredentialStore
+		)
+
+		BeforeEach(func() {
+			var err error
+			helperPath, err = gexec.Build("github.com/cloudfoundry/cli/fixtures/config/credential-helper")
+			Expect(err).NotTo(HaveOccurred())
+
+			os.Setenv("CREDENTIAL_HELPER_FILE", filepath.Join(dir, "helper.json"))
+			store = coreconfig.NewHelperCredentialStore(helperPath, configPath)
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("CREDENTIAL_HELPER_FILE")
+			gexec.CleanupBuildArtifacts()
+		})
+
+		It("stores, gets and erases credentials through the helper", func() {
+			credentials := coreconfig.Credentials{AccessToken: "bearer the-access-token", RefreshToken: "the-refresh-token"}
+
+			Expect(store.Get("session")).To(Equal(coreconfig.Credentials{}))
+
+			Expect(store.Store("session", credentials)).To(Succeed())
+			Expect(store.Get("session")).To(Equal(credentials))
+			Expect(store.Get("target:other")).To(Equal(coreconfig.Credentials{}))
+
+			Expect(store.Erase("session")).To(Succeed())
+			Expect(store.Get("session")).To(Equal(coreconfig.Credentials{}))
+		})
+
+		It("tells the helper which config the credentials belong to", func() {
+			Expect(store.Store("session", coreconfig.Credentials{AccessToken: "token"})).To(Succeed())
+
+			otherStore := coreconfig.NewHelperCredentialStore(helperPath, filepath.Join(dir, "other", "config.json"))
+			Expect(otherStore.Get("session")).To(Equal(coreconfig.Credentials{}))
+		})
+
+		It("returns what the helper reports when it fails", func() {
+			_, err := store.Get("broken")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to get credentials: the keychain is locked"))
+		})
+
+		It("looks up helpers given by name on the PATH", func() {
+			store = coreconfig.NewHelperCredentialStore("does-not-exist", configPath)
+
+			_, err := store.Get("session")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cf-credential-does-not-exist"))
+		})
+	})
+})
@@ -0,0 +1,227 @@
+package coreconfig
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/configuration"
+)
+
+const (
+	CredentialKeyEnvVar        = "CF_CREDENTIAL_KEY"
+	CredentialPassphraseEnvVar = "CF_CREDENTIAL_PASSPHRASE"
+
+	credentialKeyLength           = 32
+	credentialSaltLength          = 16
+	credentialKeyDerivationRounds = 100000
+)
+
+// EncryptedFileCredentialStore keeps credentials in a file next to the config
+// file, encrypted with AES-256-GCM. The key is read from CF_CREDENTIAL_KEY as
+// 64 hex digits, or derived from CF_CREDENTIAL_PASSPHRASE.
+type EncryptedFileCredentialStore struct {
+	persistor   configuration.Persistor
+	key         []byte
+	passphrase  string
+	file        *encryptedCredentials
+	credentials map[string]Credentials
+}
+
+func NewEncryptedFileCredentialStore(configPath string) (*EncryptedFileCredentialStore, error) {
+	if configPath == "" {
+		return nil, errors.New("The encrypted-file credential store needs a config file")
+	}
+
+	store := &EncryptedFileCredentialStore{
+		persistor: configuration.NewDiskPersistor(filepath.Join(filepath.Dir(configPath), "credentials.enc")),
+		file:      new(encryptedCredentials),
+	}
+
+	if key := os.Getenv(CredentialKeyEnvVar); key != "" {
+		decoded, err := hex.DecodeString(key)
+		if err != nil || len(decoded) != credentialKeyLength {
+			return nil, fmt.Errorf("%s must be %d hex digits", CredentialKeyEnvVar, credentialKeyLength*2)
+		}
+		store.key = decoded
+	} else if passphrase := os.Getenv(CredentialPassphraseEnvVar); passphrase != "" {
+		store.passphrase = passphrase
+	} else {
+		return nil, fmt.Errorf("Set %s or %s to use the %s credential store", CredentialPassphraseEnvVar, CredentialKeyEnvVar, EncryptedFileCredentialStoreName)
+	}
+
+	return store, nil
+}
+
+func (s *EncryptedFileCredentialStore) Get(key string) (Credentials, error) {
+	err := s.load()
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	return s.credentials[key], nil
+}
+
+func (s *EncryptedFileCredentialStore) Store(key string, credentials Credentials) error {
+	err := s.load()
+	if err != nil {
+		return err
+	}
+
+	s.credentials[key] = credentials
+	return s.save()
+}
+
+func (s *EncryptedFileCredentialStore) Erase(key string) error {
+	err := s.load()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := s.credentials[key]; !ok {
+		return nil
+	}
+
+	delete(s.credentials, key)
+	return s.save()
+}
+
+func (s *EncryptedFileCredentialStore) load() error {
+	if s.credentials != nil {
+		return nil
+	}
+
+	if !s.persistor.Exists() {
+		s.credentials = map[string]Credentials{}
+		return nil
+	}
+
+	err := s.persistor.Load(s.file)
+	if err != nil {
+		return err
+	}
+
+	aead, err := s.cipher()
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := aead.Open(nil, s.file.Nonce, s.file.Ciphertext, nil)
+	if err != nil {
+		return fmt.Errorf("Unable to decrypt the stored credentials. Check %s or %s.", CredentialPassphraseEnvVar, CredentialKeyEnvVar)
+	}
+
+	credentials := map[string]Credentials{}
+	err = json.Unmarshal(plaintext, &credentials)
+	if err != nil {
+		return err
+	}
+
+	s.credentials = credentials
+	return nil
+}
+
+func (s *EncryptedFileCredentialStore) save() error {
+	if len(s.file.Salt) == 0 {
+		s.file.Salt = make([]byte, credentialSaltLength)
+		_, err := rand.Read(s.file.Salt)
+		if err != nil {
+			return err
+		}
+	}
+
+	aead, err := s.cipher()
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(s.credentials)
+	if err != nil {
+		return err
+	}
+
+	s.file.Nonce = make([]byte, aead.NonceSize())
+	_, err = rand.Read(s.file.Nonce)
+	if err != nil {
+		return err
+	}
+
+	s.file.Ciphertext = aead.Seal(nil, s.file.Nonce, plaintext, nil)
+	return s.persistor.Save(s.file)
+}
+
+func (s *EncryptedFileCredentialStore) cipher() (cipher.AEAD, error) {
+	key := s.key
+	if key == nil {
+		key = pbkdf2SHA256([]byte(s.passphrase), s.file.Salt, credentialKeyDerivationRounds, credentialKeyLength)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptedCredentials is the on-disk form of the encrypted-file store.
+type encryptedCredentials struct {
+	Salt       []byte
+	Nonce      []byte
+	Ciphertext []byte
+}
+
+func (e *encryptedCredentials) JSONMarshalV3() ([]byte, error) {
+	return json.MarshalIndent(e, "", "  ")
+}
+
+func (e *encryptedCredentials) JSONUnmarshalV3(input []byte) error {
+	file := encryptedCredentials{}
+	err := json.Unmarshal(input, &file)
+	if err != nil {
+		return err
+	}
+
+	*e = file
+	return nil
+}
+
+// pbkdf2SHA256 derives a key from a passphrase as described in RFC 2898.
+func pbkdf2SHA256(passphrase, salt []byte, rounds, keyLength int) []byte {
+	prf := hmac.New(sha256.New, passphrase)
+
+	key := []byte{}
+	for block := uint32(1); len(key) < keyLength; block++ {
+		counter := make([]byte, 4)
+		binary.BigEndian.PutUint32(counter, block)
+
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(counter)
+		u := prf.Sum(nil)
+
+		t := make([]byte, len(u))
+		copy(t, u)
+		for i := 1; i < rounds; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(nil)
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+
+		key = append(key, t...)
+	}
+
+	return key[:keyLength]
+}
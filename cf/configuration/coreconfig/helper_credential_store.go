@@ -0,0 +1,106 @@
+package coreconfig
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// HelperCredentialStore hands credentials to an external program, in the
+// manner of git credential helpers. The program is run as
+//
+//	cf-credential-NAME (get | store | erase)
+//
+// with "name=value" lines on its standard input, ended by a blank line:
+// "config" (the path of the config file), "key" and, when storing,
+// "access_token" and "refresh_token". For get it prints the "access_token"
+// and "refresh_token" lines it has for the key, or nothing at all. A helper
+// given as a path rather than a name is run as is.
+type HelperCredentialStore struct {
+	command    string
+	configPath string
+}
+
+func NewHelperCredentialStore(name string, configPath string) HelperCredentialStore {
+	command := name
+	if !strings.ContainsAny(name, `/\`) {
+		command = "cf-credential-" + name
+	}
+
+	return HelperCredentialStore{
+		command:    command,
+		configPath: configPath,
+	}
+}
+
+func (s HelperCredentialStore) Get(key string) (Credentials, error) {
+	output, err := s.run("get", key, nil)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	credentials := Credentials{}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		parts := strings.SplitN(strings.TrimRight(scanner.Text(), "\r"), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		switch parts[0] {
+		case "access_token":
+			credentials.AccessToken = parts[1]
+		case "refresh_token":
+			credentials.RefreshToken = parts[1]
+		}
+	}
+
+	return credentials, scanner.Err()
+}
+
+func (s HelperCredentialStore) Store(key string, credentials Credentials) error {
+	_, err := s.run("store", key, []string{
+		"access_token=" + credentials.AccessToken,
+		"refresh_token=" + credentials.RefreshToken,
+	})
+	return err
+}
+
+func (s HelperCredentialStore) Erase(key string) error {
+	_, err := s.run("erase", key, nil)
+	return err
+}
+
+func (s HelperCredentialStore) run(action string, key string, lines []string) ([]byte, error) {
+	lines = append([]string{"config=" + s.configPath, "key=" + key}, lines...)
+
+	input := new(bytes.Buffer)
+	for _, line := range lines {
+		if strings.ContainsAny(line, "\r\n") {
+			return nil, fmt.Errorf("Credential helper %s cannot be given values containing line breaks", s.command)
+		}
+		fmt.Fprintln(input, line)
+	}
+	fmt.Fprintln(input)
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+
+	cmd := exec.Command(s.command, action)
+	cmd.Stdin = input
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, fmt.Errorf("Credential helper %s failed to %s credentials: %s", s.command, action, message)
+	}
+
+	return stdout.Bytes(), nil
+}
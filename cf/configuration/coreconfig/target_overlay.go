@@ -0,0 +1,80 @@
+package coreconfig
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/cli/cf/models"
+)
+
+// TargetOverlay is a Repository whose targeted org and space live only in
+// memory. It is used when the org or space is overridden for a single
+// invocation, so that the persisted config is never rewritten. Everything
+// else is read from and written to the wrapped Repository.
+type TargetOverlay struct {
+	Repository
+
+	mutex *sync.RWMutex
+	org   models.OrganizationFields
+	space models.SpaceFields
+}
+
+// NewTargetOverlay returns an overlay over repo. When overrideOrg is true the
+// persisted org and space are hidden; otherwise the persisted org is kept and
+// only the space is hidden.
+func NewTargetOverlay(repo Repository, overrideOrg bool) *TargetOverlay {
+	overlay := &TargetOverlay{
+		Repository: repo,
+		mutex:      new(sync.RWMutex),
+	}
+
+	if !overrideOrg {
+		overlay.org = repo.OrganizationFields()
+	}
+
+	return overlay
+}
+
+func (o *TargetOverlay) OrganizationFields() models.OrganizationFields {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+	return o.org
+}
+
+func (o *TargetOverlay) HasOrganization() bool {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+	return o.org.GUID != "" && o.org.Name != ""
+}
+
+func (o *TargetOverlay) SpaceFields() models.SpaceFields {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+	return o.space
+}
+
+func (o *TargetOverlay) HasSpace() bool {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+	return o.space.GUID != "" && o.space.Name != ""
+}
+
+func (o *TargetOverlay) SetOrganizationFields(org models.OrganizationFields) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.org = org
+}
+
+func (o *TargetOverlay) SetSpaceFields(space models.SpaceFields) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.space = space
+}
+
+func (o *TargetOverlay) ClearSession() {
+	o.Repository.ClearSession()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.org = models.OrganizationFields{}
+	o.space = models.SpaceFields{}
+}
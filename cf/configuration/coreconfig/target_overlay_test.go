@@ -0,0 +1,83 @@
+package coreconfig_test
+
+import (
+	"github.com/cloudfoundry/cli/cf/configuration/configurationfakes"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TargetOverlay", func() {
+	var (
+		persistor *configurationfakes.FakePersistor
+		config    coreconfig.Repository
+		overlay   *coreconfig.TargetOverlay
+	)
+
+	BeforeEach(func() {
+		persistor = new(configurationfakes.FakePersistor)
+		persistor.ExistsReturns(true)
+		config = coreconfig.NewRepositoryFromPersistor(persistor, func(err error) { panic(err) })
+		config.SetAPIEndpoint("https://api.example.com")
+		config.SetOrganizationFields(models.OrganizationFields{Name: "saved-org", GUID: "saved-org-guid"})
+		config.SetSpaceFields(models.SpaceFields{Name: "saved-space", GUID: "saved-space-guid"})
+	})
+
+	Context("when the org is overridden", func() {
+		BeforeEach(func() {
+			overlay = coreconfig.NewTargetOverlay(config, true)
+		})
+
+		It("hides the saved org and space", func() {
+			Expect(overlay.HasOrganization()).To(BeFalse())
+			Expect(overlay.HasSpace()).To(BeFalse())
+		})
+
+		It("keeps the org and space it is given in memory only", func() {
+			saves := persistor.SaveCallCount()
+
+			overlay.SetOrganizationFields(models.OrganizationFields{Name: "other-org", GUID: "other-org-guid"})
+			overlay.SetSpaceFields(models.SpaceFields{Name: "other-space", GUID: "other-space-guid"})
+
+			Expect(overlay.OrganizationFields().Name).To(Equal("other-org"))
+			Expect(overlay.SpaceFields().Name).To(Equal("other-space"))
+			Expect(overlay.HasOrganization()).To(BeTrue())
+			Expect(overlay.HasSpace()).To(BeTrue())
+
+			Expect(persistor.SaveCallCount()).To(Equal(saves))
+			Expect(config.OrganizationFields().Name).To(Equal("saved-org"))
+			Expect(config.SpaceFields().Name).To(Equal("saved-space"))
+		})
+
+		It("reads everything else from the wrapped repository", func() {
+			Expect(overlay.APIEndpoint()).To(Equal("https://api.example.com"))
+
+			overlay.SetAPIEndpoint("https://api.other.example.com")
+			Expect(config.APIEndpoint()).To(Equal("https://api.other.example.com"))
+		})
+	})
+
+	Context("when only the space is overridden", func() {
+		BeforeEach(func() {
+			overlay = coreconfig.NewTargetOverlay(config, false)
+		})
+
+		It("keeps the saved org and hides the saved space", func() {
+			Expect(overlay.OrganizationFields().Name).To(Equal("saved-org"))
+			Expect(overlay.HasSpace()).To(BeFalse())
+		})
+	})
+
+	It("clears the in-memory org and space along with the session", func() {
+		overlay = coreconfig.NewTargetOverlay(config, false)
+		overlay.SetSpaceFields(models.SpaceFields{Name: "other-space", GUID: "other-space-guid"})
+
+		overlay.ClearSession()
+
+		Expect(overlay.HasOrganization()).To(BeFalse())
+		Expect(overlay.HasSpace()).To(BeFalse())
+		Expect(config.HasOrganization()).To(BeFalse())
+	})
+})
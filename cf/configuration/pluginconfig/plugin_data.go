@@ -27,5 +27,12 @@ func (pd *PluginData) JSONMarshalV3() (output []byte, err error) {
 }
 
 func (pd *PluginData) JSONUnmarshalV3(input []byte) (err error) {
-	return json.Unmarshal(input, pd)
+	data := NewData()
+	err = json.Unmarshal(input, data)
+	if err != nil {
+		return err
+	}
+
+	*pd = *data
+	return nil
 }
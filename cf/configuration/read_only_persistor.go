@@ -0,0 +1,30 @@
+package configuration
+
+// ReadOnlyPersistor loads from another persistor but never changes it, for
+// sessions whose changes must not outlive them, like replayed ones.
+type ReadOnlyPersistor struct {
+	persistor Persistor
+}
+
+func NewReadOnlyPersistor(persistor Persistor) ReadOnlyPersistor {
+	return ReadOnlyPersistor{persistor: persistor}
+}
+
+func (p ReadOnlyPersistor) Exists() bool {
+	return p.persistor.Exists()
+}
+
+func (p ReadOnlyPersistor) Delete() {}
+
+// Load leaves data with its defaults when there is nothing to load, instead
+// of creating the file.
+func (p ReadOnlyPersistor) Load(data DataInterface) error {
+	if !p.persistor.Exists() {
+		return nil
+	}
+	return p.persistor.Load(data)
+}
+
+func (p ReadOnlyPersistor) Save(data DataInterface) error {
+	return nil
+}
@@ -0,0 +1,46 @@
+package configuration_test
+
+import (
+	. "github.com/cloudfoundry/cli/cf/configuration"
+	"github.com/cloudfoundry/cli/cf/configuration/configurationfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReadOnlyPersistor", func() {
+	var (
+		persistor *configurationfakes.FakePersistor
+		readOnly  ReadOnlyPersistor
+		data      *configurationfakes.FakeDataInterface
+	)
+
+	BeforeEach(func() {
+		persistor = new(configurationfakes.FakePersistor)
+		readOnly = NewReadOnlyPersistor(persistor)
+		data = new(configurationfakes.FakeDataInterface)
+	})
+
+	It("loads what the other persistor holds", func() {
+		persistor.ExistsReturns(true)
+
+		Expect(readOnly.Exists()).To(BeTrue())
+		Expect(readOnly.Load(data)).To(Succeed())
+		Expect(persistor.LoadCallCount()).To(Equal(1))
+		Expect(persistor.LoadArgsForCall(0)).To(Equal(data))
+	})
+
+	It("does not create missing files", func() {
+		persistor.ExistsReturns(false)
+
+		Expect(readOnly.Load(data)).To(Succeed())
+		Expect(persistor.LoadCallCount()).To(BeZero())
+	})
+
+	It("never saves or deletes", func() {
+		Expect(readOnly.Save(data)).To(Succeed())
+		readOnly.Delete()
+
+		Expect(persistor.SaveCallCount()).To(BeZero())
+		Expect(persistor.DeleteCallCount()).To(BeZero())
+	})
+})
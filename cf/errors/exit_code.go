@@ -0,0 +1,52 @@
+package errors
+
+// Exit codes of cf by the category of the failure. Scripts rely on them, so
+// they never change meaning; they are listed in `cf help`.
+const (
+	ExitCodeFailure       = 1   // any other failure
+	ExitCodeUsage         = 2   // incorrect usage, invalid options, or a prompt in non-interactive mode
+	ExitCodeNotLoggedIn   = 3   // not logged in, or the session has expired
+	ExitCodeRequirement   = 4   // no API endpoint, org or space targeted, or an API too old for the command
+	ExitCodeNotFound      = 5   // the app, service or other resource does not exist
+	ExitCodeNetwork       = 6   // the API could not be reached; worth retrying
+	ExitCodeAPIError      = 7   // the API rejected the request
+	ExitCodeServerError   = 8   // the API failed with a 5xx status; may be worth retrying
+	ExitCodeStagingFailed = 9   // the app failed to stage
+	ExitCodeStartFailed   = 10  // the app's instances crashed or did not start in time
+	ExitCodeInterrupted   = 130 // interrupted while prompting for a password, as 128 + SIGINT
+)
+
+// ExitCoder is implemented by errors that know the category they belong
+// to, such as errors defined outside of this package.
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// ExitCode returns the exit code for err, or ExitCodeFailure when err does
+// not belong to any category.
+func ExitCode(err error) int {
+	switch err := err.(type) {
+	case nil:
+		return 0
+	case ExitCoder:
+		return err.ExitCode()
+	case *ModelNotFoundError, *HTTPNotFoundError:
+		return ExitCodeNotFound
+	case *InvalidTokenError:
+		return ExitCodeNotLoggedIn
+	case *NotAuthorizedError, *AccessDeniedError:
+		return ExitCodeAPIError
+	case HTTPError:
+		switch {
+		case err.StatusCode() == 401:
+			return ExitCodeNotLoggedIn
+		case err.StatusCode() == 404:
+			return ExitCodeNotFound
+		case err.StatusCode() >= 500:
+			return ExitCodeServerError
+		case err.StatusCode() >= 400:
+			return ExitCodeAPIError
+		}
+	}
+	return ExitCodeFailure
+}
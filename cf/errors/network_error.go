@@ -0,0 +1,20 @@
+package errors
+
+// NetworkError is returned when a request does not reach the API, for
+// example because the host cannot be resolved or the connection is refused.
+type NetworkError struct {
+	message string
+	Err     error
+}
+
+func NewNetworkError(message string, err error) *NetworkError {
+	return &NetworkError{message: message, Err: err}
+}
+
+func (err *NetworkError) Error() string {
+	return err.message
+}
+
+func (err *NetworkError) ExitCode() int {
+	return ExitCodeNetwork
+}
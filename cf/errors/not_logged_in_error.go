@@ -0,0 +1,18 @@
+package errors
+
+// NotLoggedInError is returned when a command needs a user to be logged in.
+type NotLoggedInError struct {
+	message string
+}
+
+func NewNotLoggedInError(message string) *NotLoggedInError {
+	return &NotLoggedInError{message: message}
+}
+
+func (err *NotLoggedInError) Error() string {
+	return err.message
+}
+
+func (err *NotLoggedInError) ExitCode() int {
+	return ExitCodeNotLoggedIn
+}
@@ -0,0 +1,19 @@
+package errors
+
+// StagingFailedError is returned when an app fails to stage, or does not
+// stage within the staging timeout.
+type StagingFailedError struct {
+	message string
+}
+
+func NewStagingFailedError(message string) *StagingFailedError {
+	return &StagingFailedError{message: message}
+}
+
+func (err *StagingFailedError) Error() string {
+	return err.message
+}
+
+func (err *StagingFailedError) ExitCode() int {
+	return ExitCodeStagingFailed
+}
@@ -0,0 +1,19 @@
+package errors
+
+// StartFailedError is returned when the instances of a staged app crash, or
+// none of them is running within the startup timeout.
+type StartFailedError struct {
+	message string
+}
+
+func NewStartFailedError(message string) *StartFailedError {
+	return &StartFailedError{message: message}
+}
+
+func (err *StartFailedError) Error() string {
+	return err.message
+}
+
+func (err *StartFailedError) ExitCode() int {
+	return ExitCodeStartFailed
+}
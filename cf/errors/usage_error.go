@@ -0,0 +1,19 @@
+package errors
+
+// UsageError is returned when a command is given the wrong arguments or
+// options.
+type UsageError struct {
+	message string
+}
+
+func NewUsageError(message string) *UsageError {
+	return &UsageError{message: message}
+}
+
+func (err *UsageError) Error() string {
+	return err.message
+}
+
+func (err *UsageError) ExitCode() int {
+	return ExitCodeUsage
+}
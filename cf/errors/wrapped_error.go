@@ -0,0 +1,22 @@
+package errors
+
+// WrappedError describes what failed when err was returned, while keeping
+// the exit code of err.
+type WrappedError struct {
+	message string
+	Err     error
+}
+
+// NewWrappedError returns an error with message, which usually includes the
+// message of err, and the exit code of err.
+func NewWrappedError(message string, err error) *WrappedError {
+	return &WrappedError{message: message, Err: err}
+}
+
+func (err *WrappedError) Error() string {
+	return err.message
+}
+
+func (err *WrappedError) ExitCode() int {
+	return ExitCode(err.Err)
+}
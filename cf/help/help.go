@@ -119,6 +119,11 @@ func newAppPresenter() appPresenter {
 				}, {
 					presentCommand("api"),
 					presentCommand("auth"),
+				}, {
+					presentCommand("targets"),
+					presentCommand("save-target"),
+					presentCommand("switch-target"),
+					presentCommand("delete-target"),
 				},
 			},
 		}, {
@@ -153,6 +158,7 @@ func newAppPresenter() appPresenter {
 					presentCommand("copy-source"),
 				}, {
 					presentCommand("create-app-manifest"),
+					presentCommand("validate-manifest"),
 				}, {
 					presentCommand("get-health-check"),
 					presentCommand("set-health-check"),
@@ -17,16 +17,57 @@ func GetHelpTemplate() string {
 {{end}}{{end}}{{end}}
 {{.Title "` + T("ENVIRONMENT VARIABLES:") + `"}}
    CF_COLOR=false                     ` + T("Do not colorize output") + `
+   CF_COLUMNS=name,state              ` + T("Print only these columns of tables") + `
+   CF_CREDENTIAL_KEY=hex-key          ` + T("Key for the encrypted-file credential store, as 64 hex digits") + `
+   CF_CREDENTIAL_PASSPHRASE=secret    ` + T("Passphrase for the encrypted-file credential store") + `
+   CF_CREDENTIAL_STORE=encrypted-file ` + T("Override where session tokens are stored (file, encrypted-file or helper:NAME)") + `
+   CF_FILTER=state=started            ` + T("Print only the rows of tables whose COLUMN matches a glob PATTERN") + `
+   CF_FORMAT=template                 ` + T("Print list and show results with a Go template") + `
    CF_HOME=path/to/dir/               ` + T("Override path to default config directory") + `
+   CF_JSONPATH=template               ` + T("Print list and show results with a JSONPath template") + `
+   CF_NON_INTERACTIVE=true            ` + T("Fail instead of prompting, naming the flag that answers the prompt") + `
+   CF_ORG=my-org                      ` + T("Override the targeted org without changing the config") + `
+   CF_OUTPUT=json                     ` + T("Print list and show results as json, yaml or csv records") + `
    CF_PLUGIN_HOME=path/to/dir/        ` + T("Override path to default plugin config directory") + `
+   CF_RECORD=path/to/cassette.json    ` + T("Record API requests and responses to a cassette, sanitized like CF_TRACE") + `
+   CF_REPLAY=path/to/cassette.json    ` + T("Answer API requests from a recorded cassette instead of the network") + `
+   CF_RETRY_ATTEMPTS=3                ` + T("Max attempts for API requests that fail with a network error, 429, 502, 503 or 504") + `
+   CF_RETRY_BACKOFF=500ms             ` + T("Wait before the first retry of an API request, doubling with each retry") + `
+   CF_SORT_BY=name                    ` + T("Sort the rows of tables by a column, or by -COLUMN in descending order") + `
+   CF_SPACE=my-space                  ` + T("Override the targeted space without changing the config") + `
    CF_STAGING_TIMEOUT=15              ` + T("Max wait time for buildpack staging, in minutes") + `
    CF_STARTUP_TIMEOUT=5               ` + T("Max wait time for app instance startup, in minutes") + `
    CF_TRACE=true                      ` + T("Print API request diagnostics to stdout") + `
    CF_TRACE=path/to/trace.log         ` + T("Append API request diagnostics to a log file") + `
+   CF_TRACE_FORMAT=json               ` + T("Trace each API request as a JSON line with its timing, and summarize the time per endpoint") + `
+   CF_TRACE_REDACT="*_CERT,MY_VAR"    ` + T("Also hide the values of these JSON keys in traces, besides credentials, app environment variables and keys like *_SECRET") + `
    https_proxy=proxy.example.com:8080 ` + T("Enable HTTP proxying for API requests") + `
 
 {{.Title "` + T("GLOBAL OPTIONS:") + `"}}
+   --columns COLUMNS                  ` + T("Print only these columns of tables") + `
+   --filter COLUMN=PATTERN            ` + T("Print only the rows of tables whose COLUMN matches a glob PATTERN") + `
+   --format TEMPLATE                  ` + T("Print list and show results with a Go template") + `
    --help, -h                         ` + T("Show help") + `
+   --jsonpath TEMPLATE                ` + T("Print list and show results with a JSONPath template, such as {[*].name} for lists") + `
+   --non-interactive                  ` + T("Fail instead of prompting, naming the flag that answers the prompt") + `
+   --org ORG                          ` + T("Run the command against ORG without changing the config") + `
+   --output FORMAT                    ` + T("Print list and show results as json, yaml or csv records instead of tables") + `
+   --sort-by COLUMN                   ` + T("Sort the rows of tables by a column, or by -COLUMN in descending order") + `
+   --space SPACE                      ` + T("Run the command against SPACE without changing the config") + `
    -v                                 ` + T("Print API request diagnostics to stdout") + `
+
+{{.Title "` + T("EXIT CODES:") + `"}}
+   0                                  ` + T("Success") + `
+   1                                  ` + T("Any other failure") + `
+   2                                  ` + T("Incorrect usage, invalid options, or a prompt in non-interactive mode") + `
+   3                                  ` + T("Not logged in, the session has expired, or authentication failed") + `
+   4                                  ` + T("No API endpoint, org or space targeted, or the API is too old for the command") + `
+   5                                  ` + T("The app, service or other resource was not found") + `
+   6                                  ` + T("The API could not be reached; worth retrying") + `
+   7                                  ` + T("The API rejected the request") + `
+   8                                  ` + T("The API failed with a server error; may be worth retrying") + `
+   9                                  ` + T("The app failed to stage") + `
+   10                                 ` + T("The app failed to start") + `
+   130                                ` + T("Interrupted") + `
 `
 }
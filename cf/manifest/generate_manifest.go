@@ -28,31 +28,48 @@ type App interface {
 	GetContents() []models.Application
 	Stack(string, string)
 	AppPorts(string, []int)
+	HealthCheckType(string, string)
+	DockerImage(string, string)
+	ServiceInstance(string, string, string, []string, interface{})
+	UserProvidedServiceInstance(string, interface{})
 	Save(f io.Writer) error
 }
 
 type Application struct {
-	Name      string                 `yaml:"name"`
-	Instances int                    `yaml:"instances,omitempty"`
-	Memory    string                 `yaml:"memory,omitempty"`
-	DiskQuota string                 `yaml:"disk_quota,omitempty"`
-	AppPorts  []int                  `yaml:"app-ports,omitempty"`
-	Routes    []map[string]string    `yaml:"routes,omitempty"`
-	NoRoute   bool                   `yaml:"no-route,omitempty"`
-	Buildpack string                 `yaml:"buildpack,omitempty"`
-	Command   string                 `yaml:"command,omitempty"`
-	Env       map[string]interface{} `yaml:"env,omitempty"`
-	Services  []string               `yaml:"services,omitempty"`
-	Stack     string                 `yaml:"stack,omitempty"`
-	Timeout   int                    `yaml:"timeout,omitempty"`
+	Name            string                 `yaml:"name"`
+	Instances       int                    `yaml:"instances,omitempty"`
+	Memory          string                 `yaml:"memory,omitempty"`
+	DiskQuota       string                 `yaml:"disk_quota,omitempty"`
+	AppPorts        []int                  `yaml:"app-ports,omitempty"`
+	Routes          []map[string]string    `yaml:"routes,omitempty"`
+	NoRoute         bool                   `yaml:"no-route,omitempty"`
+	Buildpack       string                 `yaml:"buildpack,omitempty"`
+	DockerImage     string                 `yaml:"docker-image,omitempty"`
+	Command         string                 `yaml:"command,omitempty"`
+	Env             map[string]interface{} `yaml:"env,omitempty"`
+	Services        []string               `yaml:"services,omitempty"`
+	Stack           string                 `yaml:"stack,omitempty"`
+	HealthCheckType string                 `yaml:"health-check-type,omitempty"`
+	Timeout         int                    `yaml:"timeout,omitempty"`
+}
+
+type ServiceInstance struct {
+	Name        string      `yaml:"name"`
+	Offering    string      `yaml:"offering,omitempty"`
+	Plan        string      `yaml:"plan,omitempty"`
+	Tags        []string    `yaml:"tags,omitempty"`
+	Parameters  interface{} `yaml:"parameters,omitempty"`
+	Credentials interface{} `yaml:"credentials,omitempty"`
 }
 
 type Applications struct {
-	Applications []Application `yaml:"applications"`
+	Applications     []Application     `yaml:"applications"`
+	ServiceInstances []ServiceInstance `yaml:"service_instances,omitempty"`
 }
 
 type appManifest struct {
-	contents []models.Application
+	contents         []models.Application
+	serviceInstances []ServiceInstance
 }
 
 func NewGenerator() App {
@@ -127,6 +144,33 @@ func (m *appManifest) AppPorts(appName string, appPorts []int) {
 	m.contents[i].AppPorts = appPorts
 }
 
+func (m *appManifest) HealthCheckType(appName string, healthCheckType string) {
+	i := m.findOrCreateApplication(appName)
+	m.contents[i].HealthCheckType = healthCheckType
+}
+
+func (m *appManifest) DockerImage(appName string, image string) {
+	i := m.findOrCreateApplication(appName)
+	m.contents[i].DockerImage = image
+}
+
+func (m *appManifest) ServiceInstance(name, offering, plan string, tags []string, parameters interface{}) {
+	m.serviceInstances = append(m.serviceInstances, ServiceInstance{
+		Name:       name,
+		Offering:   offering,
+		Plan:       plan,
+		Tags:       tags,
+		Parameters: parameters,
+	})
+}
+
+func (m *appManifest) UserProvidedServiceInstance(name string, credentials interface{}) {
+	m.serviceInstances = append(m.serviceInstances, ServiceInstance{
+		Name:        name,
+		Credentials: credentials,
+	})
+}
+
 func (m *appManifest) GetContents() []models.Application {
 	return m.contents
 }
@@ -158,18 +202,20 @@ func generateAppMap(app models.Application) (Application, error) {
 		routes = append(routes, buildRoute(routeSummary))
 	}
 	m := Application{
-		Name:      app.Name,
-		Services:  services,
-		Buildpack: app.BuildpackURL,
-		Memory:    fmt.Sprintf("%dM", app.Memory),
-		Command:   app.Command,
-		Env:       app.EnvironmentVars,
-		Timeout:   app.HealthCheckTimeout,
-		Instances: app.InstanceCount,
-		DiskQuota: fmt.Sprintf("%dM", app.DiskQuota),
-		Stack:     app.Stack.Name,
-		AppPorts:  app.AppPorts,
-		Routes:    routes,
+		Name:            app.Name,
+		Services:        services,
+		Buildpack:       app.BuildpackURL,
+		DockerImage:     app.DockerImage,
+		Memory:          fmt.Sprintf("%dM", app.Memory),
+		Command:         app.Command,
+		Env:             app.EnvironmentVars,
+		HealthCheckType: app.HealthCheckType,
+		Timeout:         app.HealthCheckTimeout,
+		Instances:       app.InstanceCount,
+		DiskQuota:       fmt.Sprintf("%dM", app.DiskQuota),
+		Stack:           app.Stack.Name,
+		AppPorts:        app.AppPorts,
+		Routes:          routes,
 	}
 
 	if len(app.Routes) == 0 {
@@ -181,7 +227,7 @@ func generateAppMap(app models.Application) (Application, error) {
 }
 
 func (m *appManifest) Save(f io.Writer) error {
-	apps := Applications{}
+	apps := Applications{ServiceInstances: m.serviceInstances}
 
 	for _, app := range m.contents {
 		appMap, mapErr := generateAppMap(app)
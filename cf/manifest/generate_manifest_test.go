@@ -124,6 +124,52 @@ var _ = Describe("generate_manifest", func() {
 				})
 			})
 
+			Context("when an application has a health check type", func() {
+				BeforeEach(func() {
+					m.HealthCheckType("app1", "none")
+				})
+
+				It("includes the health check type for that app", func() {
+					err := m.Save(f)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(getYaml(f).Applications[0].HealthCheckType).To(Equal("none"))
+				})
+			})
+
+			Context("when an application has a docker image", func() {
+				BeforeEach(func() {
+					m.DockerImage("app1", "cloudfoundry/lattice-app")
+				})
+
+				It("includes the docker image for that app", func() {
+					err := m.Save(f)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(getYaml(f).Applications[0].DockerImage).To(Equal("cloudfoundry/lattice-app"))
+				})
+			})
+
+			Context("when the manifest has service instances", func() {
+				BeforeEach(func() {
+					m.ServiceInstance("db", "postgres", "small", []string{"sql"}, "((db.parameters))")
+					m.UserProvidedServiceInstance("smtp", "((smtp.credentials))")
+				})
+
+				It("includes them in a top-level service_instances key", func() {
+					err := m.Save(f)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(getYaml(f).ServiceInstances).To(Equal([]map[string]interface{}{
+						{"name": "db", "offering": "postgres", "plan": "small", "tags": []interface{}{"sql"}, "parameters": "((db.parameters))"},
+						{"name": "smtp", "credentials": "((smtp.credentials))"},
+					}))
+				})
+			})
+
+			It("does not include service_instances when there are none", func() {
+				err := m.Save(f)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(f.String()).NotTo(ContainSubstring("service_instances"))
+			})
+
 			Context("when an application has a start command", func() {
 				BeforeEach(func() {
 					m.StartCommand("app1", "start-command")
@@ -307,7 +353,8 @@ var _ = Describe("generate_manifest", func() {
 })
 
 type YManifest struct {
-	Applications []YApplication `yaml:"applications"`
+	Applications     []YApplication           `yaml:"applications"`
+	ServiceInstances []map[string]interface{} `yaml:"service_instances"`
 }
 
 type YApplication struct {
@@ -324,6 +371,9 @@ type YApplication struct {
 	DiskQuota string                 `yaml:"disk_quota"`
 	Stack     string                 `yaml:"stack"`
 	AppPorts  []int                  `yaml:"app-ports"`
+
+	HealthCheckType string `yaml:"health-check-type"`
+	DockerImage     string `yaml:"docker-image"`
 }
 
 func getYaml(f *bytes.Buffer) YManifest {
@@ -60,8 +60,52 @@ func (m Manifest) Applications() ([]models.AppParams, error) {
 	return apps, nil
 }
 
+// ServiceInstances returns the service instances in the service_instances
+// section of the manifest.
+func (m Manifest) ServiceInstances() ([]models.ServiceInstanceParams, error) {
+	if !m.Data.Has("service_instances") {
+		return nil, nil
+	}
+
+	rawData, err := expandProperties(m.Data.Get("service_instances"), generator.NewWordGenerator())
+	if err != nil {
+		return nil, err
+	}
+
+	serviceInstanceMaps, ok := rawData.([]interface{})
+	if !ok {
+		return nil, errors.New(T("Expected service_instances to be a list"))
+	}
+
+	var serviceInstances []models.ServiceInstanceParams
+	var errs []error
+	for _, serviceInstanceData := range serviceInstanceMaps {
+		if serviceInstanceData == nil || !generic.IsMappable(serviceInstanceData) {
+			errs = append(errs, errors.New(T("Expected service instance to be a list of key/value pairs")))
+			continue
+		}
+
+		serviceInstance, err := mapToServiceInstanceParams(generic.NewMap(serviceInstanceData))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		serviceInstances = append(serviceInstances, serviceInstance)
+	}
+
+	if len(errs) > 0 {
+		message := ""
+		for i := range errs {
+			message = message + fmt.Sprintf("%s\n", errs[i].Error())
+		}
+		return nil, errors.New(message)
+	}
+
+	return serviceInstances, nil
+}
+
 func (m Manifest) getAppMaps(data generic.Map) ([]generic.Map, error) {
-	globalProperties := data.Except([]interface{}{"applications"})
+	globalProperties := data.Except([]interface{}{"applications", "service_instances"})
 
 	var apps []generic.Map
 	var errs []error
@@ -72,7 +116,7 @@ func (m Manifest) getAppMaps(data generic.Map) ([]generic.Map, error) {
 		}
 
 		for _, appData := range appMaps {
-			if !generic.IsMappable(appData) {
+			if appData == nil || !generic.IsMappable(appData) {
 				errs = append(errs, fmt.Errorf(T("Expected application to be a list of key/value pairs\nError occurred in manifest near:\n'{{.YmlSnippet}}'",
 					map[string]interface{}{"YmlSnippet": appData})))
 				continue
@@ -202,8 +246,10 @@ func mapToAppParams(basePath string, yamlMap generic.Map) (models.AppParams, err
 	appParams.NoHostname = boolOrNil(yamlMap, "no-hostname", &errs)
 	appParams.UseRandomRoute = boolVal(yamlMap, "random-route", &errs)
 	appParams.ServicesToBind = sliceOrNil(yamlMap, "services", &errs)
+	appParams.DependsOn = sliceOrNil(yamlMap, "depends_on", &errs)
 	appParams.EnvironmentVars = envVarOrEmptyMap(yamlMap, &errs)
 	appParams.HealthCheckType = stringVal(yamlMap, "health-check-type", &errs)
+	appParams.DockerImage = stringVal(yamlMap, "docker-image", &errs)
 	appParams.AppPorts = intSliceVal(yamlMap, "app-ports", &errs)
 	appParams.Routes = parseRoutes(yamlMap, &errs)
 
@@ -228,6 +274,86 @@ func mapToAppParams(basePath string, yamlMap generic.Map) (models.AppParams, err
 	return appParams, nil
 }
 
+func mapToServiceInstanceParams(yamlMap generic.Map) (models.ServiceInstanceParams, error) {
+	var errs []error
+	name := stringVal(yamlMap, "name", &errs)
+	offering := stringVal(yamlMap, "offering", &errs)
+	plan := stringVal(yamlMap, "plan", &errs)
+
+	params := models.ServiceInstanceParams{
+		Parameters:  jsonObjectVal(yamlMap, "parameters", &errs),
+		Tags:        sliceOrNil(yamlMap, "tags", &errs),
+		Credentials: jsonObjectVal(yamlMap, "credentials", &errs),
+	}
+	if len(errs) == 0 {
+		switch {
+		case name == nil:
+			errs = append(errs, errors.New(T("Each service instance must have a name")))
+		case params.Credentials != nil && (offering != nil || plan != nil || params.Parameters != nil):
+			errs = append(errs, errors.New(T("Service instance {{.Name}} must not have both credentials and an offering, plan or parameters",
+				map[string]interface{}{"Name": *name})))
+		case params.Credentials == nil && (offering == nil || plan == nil):
+			errs = append(errs, errors.New(T("Service instance {{.Name}} must have either credentials or an offering and plan",
+				map[string]interface{}{"Name": *name})))
+		}
+	}
+
+	if len(errs) > 0 {
+		messages := make([]string, len(errs))
+		for i, err := range errs {
+			messages[i] = err.Error()
+		}
+		return models.ServiceInstanceParams{}, errors.New(strings.Join(messages, "\n"))
+	}
+
+	params.Name = *name
+	if offering != nil {
+		params.Offering = *offering
+		params.Plan = *plan
+	}
+	return params, nil
+}
+
+// jsonObjectVal returns the map at key with string keys throughout, as
+// encoding/json needs.
+func jsonObjectVal(yamlMap generic.Map, key string, errs *[]error) map[string]interface{} {
+	if !yamlMap.Has(key) {
+		return nil
+	}
+
+	value := yamlMap.Get(key)
+	if value == nil {
+		*errs = append(*errs, fmt.Errorf(T("{{.PropertyName}} should not be null", map[string]interface{}{"PropertyName": key})))
+		return nil
+	}
+
+	if !generic.IsMappable(value) {
+		*errs = append(*errs, fmt.Errorf(T("Expected {{.Name}} to be a set of key => value, but it was a {{.Type}}.",
+			map[string]interface{}{"Name": key, "Type": typeName(value)})))
+		return nil
+	}
+
+	return toJSONValue(value).(map[string]interface{})
+}
+
+func toJSONValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case []interface{}:
+		values := make([]interface{}, len(value))
+		for i, item := range value {
+			values[i] = toJSONValue(item)
+		}
+		return values
+	case map[interface{}]interface{}, map[string]interface{}, generic.Map:
+		values := map[string]interface{}{}
+		generic.Each(generic.NewMap(value), func(key, item interface{}) {
+			values[fmt.Sprint(key)] = toJSONValue(item)
+		})
+		return values
+	}
+	return value
+}
+
 func removeDuplicatedValue(ary []string) []string {
 	if ary == nil {
 		return nil
@@ -17,6 +17,7 @@ import (
 
 type Repository interface {
 	ReadManifest(string) (*Manifest, error)
+	ValidateManifest(string) error
 }
 
 type DiskRepository struct{}
@@ -45,6 +46,41 @@ func (repo DiskRepository) ReadManifest(inputPath string) (*Manifest, error) {
 	return m, nil
 }
 
+// ValidateManifest checks the manifest at inputPath, and the manifests it
+// inherits from, for unknown keys, values of the wrong type and invalid
+// byte sizes. The error is ValidationErrors when there are such problems.
+func (repo DiskRepository) ValidateManifest(inputPath string) error {
+	manifestPath, err := repo.manifestPath(inputPath)
+	if err != nil {
+		return fmt.Errorf("%s: %s", T("Error finding manifest"), err.Error())
+	}
+
+	var errs ValidationErrors
+	validated := map[string]bool{}
+
+	for path := filepath.Clean(manifestPath); path != "" && !validated[path]; {
+		validated[path] = true
+
+		source, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		fileErrs, inheritedPath := validateManifest(path, source)
+		errs = append(errs, fileErrs...)
+
+		if inheritedPath != "" && !filepath.IsAbs(inheritedPath) {
+			inheritedPath = filepath.Join(filepath.Dir(path), inheritedPath)
+		}
+		path = inheritedPath
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
 func (repo DiskRepository) readAllYAMLFiles(path string) (mergedMap generic.Map, err error) {
 	file, err := os.Open(filepath.Clean(path))
 	if err != nil {
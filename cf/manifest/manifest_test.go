@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/cloudfoundry/cli/cf/manifest"
+	"github.com/cloudfoundry/cli/cf/models"
 	"github.com/cloudfoundry/cli/utils/generic"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -111,6 +112,15 @@ var _ = Describe("Manifests", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	It("does not allow null applications", func() {
+		m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+			"applications": []interface{}{nil},
+		}))
+
+		_, err := m.Applications()
+		Expect(err).To(MatchError(ContainSubstring("Expected application to be a list of key/value pairs")))
+	})
+
 	It("does not allow nil values for environment variables", func() {
 		m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
 			"env": generic.NewMap(map[interface{}]interface{}{
@@ -508,6 +518,38 @@ var _ = Describe("Manifests", func() {
 		})
 	})
 
+	Context("parsing depends_on", func() {
+		It("reads the names of the apps to push first", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					map[interface{}]interface{}{
+						"name":       "web",
+						"depends_on": []interface{}{"api", "auth"},
+					},
+				},
+			}))
+
+			apps, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apps[0].DependsOn).To(Equal([]string{"api", "auth"}))
+		})
+
+		It("returns an error when it is not a list", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					map[interface{}]interface{}{
+						"name":       "web",
+						"depends_on": "api",
+					},
+				},
+			}))
+
+			_, err := m.Applications()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("depends_on"))
+		})
+	})
+
 	Context("when routes are provided", func() {
 		var manifest *manifest.Manifest
 
@@ -683,4 +725,102 @@ var _ = Describe("Manifests", func() {
 			})
 		})
 	})
+
+	Describe("ServiceInstances", func() {
+		It("returns nothing when the manifest has no service_instances", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{map[interface{}]interface{}{"name": "my-app"}},
+			}))
+
+			serviceInstances, err := m.ServiceInstances()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serviceInstances).To(BeEmpty())
+		})
+
+		It("parses managed and user-provided service instances", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"service_instances": []interface{}{
+					map[interface{}]interface{}{
+						"name":     "db",
+						"offering": "postgres",
+						"plan":     "small",
+						"parameters": map[interface{}]interface{}{
+							"backups": map[interface{}]interface{}{"enabled": true},
+						},
+						"tags": []interface{}{"sql"},
+					},
+					map[interface{}]interface{}{
+						"name":        "smtp",
+						"credentials": map[interface{}]interface{}{"host": "smtp.example.com", "port": 25},
+					},
+				},
+				"applications": []interface{}{map[interface{}]interface{}{"name": "my-app"}},
+			}))
+
+			serviceInstances, err := m.ServiceInstances()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serviceInstances).To(Equal([]models.ServiceInstanceParams{
+				{
+					Name:       "db",
+					Offering:   "postgres",
+					Plan:       "small",
+					Parameters: map[string]interface{}{"backups": map[string]interface{}{"enabled": true}},
+					Tags:       []string{"sql"},
+				},
+				{
+					Name:        "smtp",
+					Credentials: map[string]interface{}{"host": "smtp.example.com", "port": 25},
+				},
+			}))
+			Expect(serviceInstances[0].IsUserProvided()).To(BeFalse())
+			Expect(serviceInstances[1].IsUserProvided()).To(BeTrue())
+
+			apps, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apps).To(HaveLen(1))
+		})
+
+		It("returns an error for service instances without a name, plan or credentials", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"service_instances": []interface{}{
+					map[interface{}]interface{}{"offering": "postgres", "plan": "small"},
+					map[interface{}]interface{}{"name": "db", "offering": "postgres"},
+					map[interface{}]interface{}{"name": "smtp", "plan": "small", "credentials": map[interface{}]interface{}{}},
+				},
+			}))
+
+			_, err := m.ServiceInstances()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Each service instance must have a name"))
+			Expect(err.Error()).To(ContainSubstring("Service instance db must have either credentials or an offering and plan"))
+			Expect(err.Error()).To(ContainSubstring("Service instance smtp must not have both credentials and an offering, plan or parameters"))
+		})
+
+		It("returns an error for null service instances, parameters and credentials", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"service_instances": []interface{}{
+					nil,
+					map[interface{}]interface{}{"name": "db", "offering": "postgres", "plan": "small", "parameters": nil},
+					map[interface{}]interface{}{"name": "smtp", "credentials": nil},
+				},
+			}))
+
+			_, err := m.ServiceInstances()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected service instance to be a list of key/value pairs"))
+			Expect(err.Error()).To(ContainSubstring("parameters should not be null"))
+			Expect(err.Error()).To(ContainSubstring("credentials should not be null"))
+		})
+
+		It("names the type of parameters that are not key/value pairs", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"service_instances": []interface{}{
+					map[interface{}]interface{}{"name": "db", "offering": "postgres", "plan": "small", "parameters": "backups"},
+				},
+			}))
+
+			_, err := m.ServiceInstances()
+			Expect(err).To(MatchError(ContainSubstring("Expected parameters to be a set of key => value, but it was a string.")))
+		})
+	})
 })
@@ -94,6 +94,33 @@ type FakeApp struct {
 	saveReturns struct {
 		result1 error
 	}
+	HealthCheckTypeStub        func(string, string)
+	healthCheckTypeMutex       sync.RWMutex
+	healthCheckTypeArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	DockerImageStub        func(string, string)
+	dockerImageMutex       sync.RWMutex
+	dockerImageArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	ServiceInstanceStub        func(string, string, string, []string, interface{})
+	serviceInstanceMutex       sync.RWMutex
+	serviceInstanceArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 []string
+		arg5 interface{}
+	}
+	UserProvidedServiceInstanceStub        func(string, interface{})
+	userProvidedServiceInstanceMutex       sync.RWMutex
+	userProvidedServiceInstanceArgsForCall []struct {
+		arg1 string
+		arg2 interface{}
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -440,6 +467,114 @@ func (fake *FakeApp) SaveReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeApp) HealthCheckType(arg1 string, arg2 string) {
+	fake.healthCheckTypeMutex.Lock()
+	fake.healthCheckTypeArgsForCall = append(fake.healthCheckTypeArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("HealthCheckType", []interface{}{arg1, arg2})
+	fake.healthCheckTypeMutex.Unlock()
+	if fake.HealthCheckTypeStub != nil {
+		fake.HealthCheckTypeStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeApp) HealthCheckTypeCallCount() int {
+	fake.healthCheckTypeMutex.RLock()
+	defer fake.healthCheckTypeMutex.RUnlock()
+	return len(fake.healthCheckTypeArgsForCall)
+}
+
+func (fake *FakeApp) HealthCheckTypeArgsForCall(i int) (string, string) {
+	fake.healthCheckTypeMutex.RLock()
+	defer fake.healthCheckTypeMutex.RUnlock()
+	return fake.healthCheckTypeArgsForCall[i].arg1, fake.healthCheckTypeArgsForCall[i].arg2
+}
+
+func (fake *FakeApp) DockerImage(arg1 string, arg2 string) {
+	fake.dockerImageMutex.Lock()
+	fake.dockerImageArgsForCall = append(fake.dockerImageArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DockerImage", []interface{}{arg1, arg2})
+	fake.dockerImageMutex.Unlock()
+	if fake.DockerImageStub != nil {
+		fake.DockerImageStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeApp) DockerImageCallCount() int {
+	fake.dockerImageMutex.RLock()
+	defer fake.dockerImageMutex.RUnlock()
+	return len(fake.dockerImageArgsForCall)
+}
+
+func (fake *FakeApp) DockerImageArgsForCall(i int) (string, string) {
+	fake.dockerImageMutex.RLock()
+	defer fake.dockerImageMutex.RUnlock()
+	return fake.dockerImageArgsForCall[i].arg1, fake.dockerImageArgsForCall[i].arg2
+}
+
+func (fake *FakeApp) ServiceInstance(arg1 string, arg2 string, arg3 string, arg4 []string, arg5 interface{}) {
+	var arg4Copy []string
+	if arg4 != nil {
+		arg4Copy = make([]string, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.serviceInstanceMutex.Lock()
+	fake.serviceInstanceArgsForCall = append(fake.serviceInstanceArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 []string
+		arg5 interface{}
+	}{arg1, arg2, arg3, arg4Copy, arg5})
+	fake.recordInvocation("ServiceInstance", []interface{}{arg1, arg2, arg3, arg4Copy, arg5})
+	fake.serviceInstanceMutex.Unlock()
+	if fake.ServiceInstanceStub != nil {
+		fake.ServiceInstanceStub(arg1, arg2, arg3, arg4, arg5)
+	}
+}
+
+func (fake *FakeApp) ServiceInstanceCallCount() int {
+	fake.serviceInstanceMutex.RLock()
+	defer fake.serviceInstanceMutex.RUnlock()
+	return len(fake.serviceInstanceArgsForCall)
+}
+
+func (fake *FakeApp) ServiceInstanceArgsForCall(i int) (string, string, string, []string, interface{}) {
+	fake.serviceInstanceMutex.RLock()
+	defer fake.serviceInstanceMutex.RUnlock()
+	return fake.serviceInstanceArgsForCall[i].arg1, fake.serviceInstanceArgsForCall[i].arg2, fake.serviceInstanceArgsForCall[i].arg3, fake.serviceInstanceArgsForCall[i].arg4, fake.serviceInstanceArgsForCall[i].arg5
+}
+
+func (fake *FakeApp) UserProvidedServiceInstance(arg1 string, arg2 interface{}) {
+	fake.userProvidedServiceInstanceMutex.Lock()
+	fake.userProvidedServiceInstanceArgsForCall = append(fake.userProvidedServiceInstanceArgsForCall, struct {
+		arg1 string
+		arg2 interface{}
+	}{arg1, arg2})
+	fake.recordInvocation("UserProvidedServiceInstance", []interface{}{arg1, arg2})
+	fake.userProvidedServiceInstanceMutex.Unlock()
+	if fake.UserProvidedServiceInstanceStub != nil {
+		fake.UserProvidedServiceInstanceStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeApp) UserProvidedServiceInstanceCallCount() int {
+	fake.userProvidedServiceInstanceMutex.RLock()
+	defer fake.userProvidedServiceInstanceMutex.RUnlock()
+	return len(fake.userProvidedServiceInstanceArgsForCall)
+}
+
+func (fake *FakeApp) UserProvidedServiceInstanceArgsForCall(i int) (string, interface{}) {
+	fake.userProvidedServiceInstanceMutex.RLock()
+	defer fake.userProvidedServiceInstanceMutex.RUnlock()
+	return fake.userProvidedServiceInstanceArgsForCall[i].arg1, fake.userProvidedServiceInstanceArgsForCall[i].arg2
+}
+
 func (fake *FakeApp) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -469,6 +604,16 @@ func (fake *FakeApp) Invocations() map[string][][]interface{} {
 	defer fake.appPortsMutex.RUnlock()
 	fake.saveMutex.RLock()
 	defer fake.saveMutex.RUnlock()
+	fake.healthCheckTypeMutex.RLock()
+	defer fake.healthCheckTypeMutex.RUnlock()
+	fake.dockerImageMutex.RLock()
+	defer fake.dockerImageMutex.RUnlock()
+	fake.serviceInstanceMutex.RLock()
+	defer fake.serviceInstanceMutex.RUnlock()
+	fake.userProvidedServiceInstanceMutex.RLock()
+	defer fake.userProvidedServiceInstanceMutex.RUnlock()
+	fake.serviceInstanceMutex.RLock()
+	defer fake.serviceInstanceMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -17,6 +17,14 @@ type FakeRepository struct {
 		result1 *manifest.Manifest
 		result2 error
 	}
+	ValidateManifestStub        func(string) error
+	validateManifestMutex       sync.RWMutex
+	validateManifestArgsForCall []struct {
+		arg1 string
+	}
+	validateManifestReturns struct {
+		result1 error
+	}
 }
 
 func (fake *FakeRepository) ReadManifest(arg1 string) (*manifest.Manifest, error) {
@@ -52,4 +60,36 @@ func (fake *FakeRepository) ReadManifestReturns(result1 *manifest.Manifest, resu
 	}{result1, result2}
 }
 
+func (fake *FakeRepository) ValidateManifest(arg1 string) error {
+	fake.validateManifestMutex.Lock()
+	fake.validateManifestArgsForCall = append(fake.validateManifestArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.validateManifestMutex.Unlock()
+	if fake.ValidateManifestStub != nil {
+		return fake.ValidateManifestStub(arg1)
+	} else {
+		return fake.validateManifestReturns.result1
+	}
+}
+
+func (fake *FakeRepository) ValidateManifestCallCount() int {
+	fake.validateManifestMutex.RLock()
+	defer fake.validateManifestMutex.RUnlock()
+	return len(fake.validateManifestArgsForCall)
+}
+
+func (fake *FakeRepository) ValidateManifestArgsForCall(i int) string {
+	fake.validateManifestMutex.RLock()
+	defer fake.validateManifestMutex.RUnlock()
+	return fake.validateManifestArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) ValidateManifestReturns(result1 error) {
+	fake.ValidateManifestStub = nil
+	fake.validateManifestReturns = struct {
+		result1 error
+	}{result1}
+}
+
 var _ manifest.Repository = new(FakeRepository)
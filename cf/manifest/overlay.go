@@ -0,0 +1,206 @@
+package manifest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/errors"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/utils/generic"
+	"gopkg.in/yaml.v2"
+)
+
+// OverlayOperation changes the value at Path in a manifest. Paths are
+// slash-separated keys, list indexes and KEY=VALUE selectors of the list
+// item with that value, such as /applications/name=my-app/instances; ~1
+// and ~0 stand for / and ~ in keys.
+//
+// replace sets the value, adding it when the last key is missing. remove
+// deletes it. append adds Value, or each item of Value when it is a list,
+// to the list at Path, which is created when missing.
+type OverlayOperation struct {
+	Type  string      `yaml:"type"`
+	Path  string      `yaml:"path"`
+	Value interface{} `yaml:"value"`
+}
+
+// Overlay is a list of operations, read from a file given with cf push
+// --overlay, that customise a manifest for an environment.
+type Overlay struct {
+	Path       string
+	Operations []OverlayOperation
+}
+
+// ReadOverlay reads the overlay at path and checks its operations.
+func ReadOverlay(path string) (*Overlay, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.New(T("Error reading overlay file {{.Path}}: {{.Err}}",
+			map[string]interface{}{"Path": path, "Err": err.Error()}))
+	}
+
+	overlay := &Overlay{Path: path}
+	err = yaml.Unmarshal(contents, &overlay.Operations)
+	if err != nil {
+		return nil, errors.New(T("Invalid overlay file {{.Path}}: expected a list of operations with type, path and value",
+			map[string]interface{}{"Path": path}))
+	}
+
+	for index, operation := range overlay.Operations {
+		var err error
+		switch {
+		case operation.Type != "replace" && operation.Type != "remove" && operation.Type != "append":
+			err = errors.New(T("Invalid operation type '{{.Type}}'. Use replace, remove or append.",
+				map[string]interface{}{"Type": operation.Type}))
+		case !strings.HasPrefix(operation.Path, "/") || operation.Path == "/":
+			err = errors.New(T("Invalid path '{{.Path}}'. Paths start with / and name a key or list item, such as /applications/name=my-app/instances.",
+				map[string]interface{}{"Path": operation.Path}))
+		case operation.Type != "remove" && operation.Value == nil:
+			err = errors.New(T("Expected a value to {{.Type}}", map[string]interface{}{"Type": operation.Type}))
+		}
+
+		if err != nil {
+			return nil, overlay.operationError(index, err)
+		}
+	}
+
+	return overlay, nil
+}
+
+// ApplyOverlay changes the manifest with the operations of overlay, in
+// order.
+func (m *Manifest) ApplyOverlay(overlay *Overlay) error {
+	for index, operation := range overlay.Operations {
+		segments := strings.Split(strings.TrimPrefix(operation.Path, "/"), "/")
+		for i, segment := range segments {
+			segments[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+		}
+
+		data, err := applyOperation(m.Data, segments, operation)
+		if err != nil {
+			return overlay.operationError(index, err)
+		}
+		m.Data = generic.NewMap(data)
+	}
+
+	return nil
+}
+
+func (overlay *Overlay) operationError(index int, err error) error {
+	return errors.New(T("Error in overlay {{.Path}}, operation {{.Number}}: {{.Err}}",
+		map[string]interface{}{"Path": overlay.Path, "Number": index + 1, "Err": err.Error()}))
+}
+
+// applyOperation returns node with the operation applied at the path of
+// segments within it.
+func applyOperation(node interface{}, segments []string, operation OverlayOperation) (interface{}, error) {
+	segment := segments[0]
+	last := len(segments) == 1
+
+	if node != nil && generic.IsMappable(node) {
+		values := generic.NewMap(node)
+		if !values.Has(segment) {
+			if last && operation.Type != "remove" {
+				values.Set(segment, appendOrReplace(nil, operation))
+				return values, nil
+			}
+			return nil, pathNotFoundError(operation.Path, segment)
+		}
+
+		switch {
+		case !last:
+			child, err := applyOperation(values.Get(segment), segments[1:], operation)
+			if err != nil {
+				return nil, err
+			}
+			values.Set(segment, child)
+		case operation.Type == "remove":
+			values.Delete(segment)
+		default:
+			child, err := appendOrReplaceIn(values.Get(segment), operation)
+			if err != nil {
+				return nil, err
+			}
+			values.Set(segment, child)
+		}
+		return values, nil
+	}
+
+	items, ok := node.([]interface{})
+	if !ok {
+		return nil, pathNotFoundError(operation.Path, segment)
+	}
+
+	index, found := listIndex(items, segment)
+	if !found {
+		return nil, pathNotFoundError(operation.Path, segment)
+	}
+
+	switch {
+	case !last:
+		child, err := applyOperation(items[index], segments[1:], operation)
+		if err != nil {
+			return nil, err
+		}
+		items[index] = child
+	case operation.Type == "remove":
+		items = append(items[:index:index], items[index+1:]...)
+	default:
+		child, err := appendOrReplaceIn(items[index], operation)
+		if err != nil {
+			return nil, err
+		}
+		items[index] = child
+	}
+	return items, nil
+}
+
+// listIndex finds the item of items that segment names, either by its
+// index or as KEY=VALUE.
+func listIndex(items []interface{}, segment string) (int, bool) {
+	parts := strings.SplitN(segment, "=", 2)
+	if len(parts) == 1 {
+		index, err := strconv.Atoi(segment)
+		return index, err == nil && index >= 0 && index < len(items)
+	}
+
+	for index, item := range items {
+		if item != nil && generic.IsMappable(item) {
+			values := generic.NewMap(item)
+			if values.Has(parts[0]) && fmt.Sprint(values.Get(parts[0])) == parts[1] {
+				return index, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func appendOrReplaceIn(existing interface{}, operation OverlayOperation) (interface{}, error) {
+	if operation.Type == "append" && existing != nil {
+		if _, ok := existing.([]interface{}); !ok {
+			return nil, errors.New(T("Cannot append to {{.Path}} because it is not a list",
+				map[string]interface{}{"Path": operation.Path}))
+		}
+	}
+	return appendOrReplace(existing, operation), nil
+}
+
+func appendOrReplace(existing interface{}, operation OverlayOperation) interface{} {
+	if operation.Type == "replace" {
+		return operation.Value
+	}
+
+	items, _ := existing.([]interface{})
+	items = append([]interface{}{}, items...)
+	if values, ok := operation.Value.([]interface{}); ok {
+		return append(items, values...)
+	}
+	return append(items, operation.Value)
+}
+
+func pathNotFoundError(path, segment string) error {
+	return errors.New(T("Path {{.Path}} not found: no {{.Segment}}",
+		map[string]interface{}{"Path": path, "Segment": segment}))
+}
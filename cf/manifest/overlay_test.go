@@ -0,0 +1,174 @@
+package manifest_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/manifest"
+	"github.com/cloudfoundry/cli/utils/generic"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Overlays", func() {
+	var (
+		dir string
+		m   *manifest.Manifest
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "manifest-overlays")
+		Expect(err).NotTo(HaveOccurred())
+
+		m = NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+			"memory": "256M",
+			"applications": []interface{}{
+				map[interface{}]interface{}{
+					"name":      "web",
+					"instances": 1,
+					"services":  []interface{}{"logs"},
+					"env":       map[interface{}]interface{}{"DEBUG": "true", "REGION": "eu"},
+				},
+				map[interface{}]interface{}{
+					"name": "worker",
+				},
+			},
+		}))
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	readOverlay := func(contents string) (*manifest.Overlay, error) {
+		path := filepath.Join(dir, "overlay.yml")
+		err := ioutil.WriteFile(path, []byte(contents), 0600)
+		Expect(err).NotTo(HaveOccurred())
+		return manifest.ReadOverlay(path)
+	}
+
+	apply := func(contents string) error {
+		overlay, err := readOverlay(contents)
+		Expect(err).NotTo(HaveOccurred())
+		return m.ApplyOverlay(overlay)
+	}
+
+	applications := func() map[string]interface{} {
+		apps, err := m.Applications()
+		Expect(err).NotTo(HaveOccurred())
+
+		byName := map[string]interface{}{}
+		for _, app := range apps {
+			byName[*app.Name] = app
+		}
+		return byName
+	}
+
+	It("replaces, removes and appends values of apps addressed by name", func() {
+		err := apply(`
+- type: replace
+  path: /applications/name=web/instances
+  value: 4
+- type: replace
+  path: /applications/name=worker/memory
+  value: 1G
+- type: remove
+  path: /applications/name=web/env/DEBUG
+- type: append
+  path: /applications/name=web/services
+  value: prod-db
+- type: append
+  path: /applications/name=worker/services
+  value: [prod-db, prod-queue]
+`)
+		Expect(err).NotTo(HaveOccurred())
+
+		apps, err := m.Applications()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*apps[0].InstanceCount).To(Equal(4))
+		Expect(*apps[0].Memory).To(Equal(int64(256)))
+		Expect(*apps[0].EnvironmentVars).To(Equal(map[string]interface{}{"REGION": "eu"}))
+		Expect(apps[0].ServicesToBind).To(Equal([]string{"logs", "prod-db"}))
+		Expect(*apps[1].Memory).To(Equal(int64(1024)))
+		Expect(apps[1].ServicesToBind).To(Equal([]string{"prod-db", "prod-queue"}))
+	})
+
+	It("removes apps and adds new ones", func() {
+		err := apply(`
+- type: remove
+  path: /applications/name=worker
+- type: append
+  path: /applications
+  value:
+    name: scheduler
+    no-route: true
+`)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(applications()).To(HaveLen(2))
+		Expect(applications()).To(HaveKey("web"))
+		Expect(applications()).To(HaveKey("scheduler"))
+	})
+
+	It("addresses list items by index", func() {
+		Expect(apply("- type: replace\n  path: /applications/1/name\n  value: background\n")).To(Succeed())
+		Expect(applications()).To(HaveKey("background"))
+	})
+
+	It("returns an error naming the operation when a path is not found", func() {
+		err := apply("- type: replace\n  path: /applications/name=web/instances\n  value: 2\n- type: remove\n  path: /applications/name=api/env\n")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("operation 2"))
+		Expect(err.Error()).To(ContainSubstring("/applications/name=api/env"))
+	})
+
+	It("returns an error when a path goes through a null value", func() {
+		m = NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+			"applications": []interface{}{
+				nil,
+				map[interface{}]interface{}{
+					"name": "web",
+					"env":  nil,
+				},
+			},
+		}))
+
+		err := apply("- type: replace\n  path: /applications/name=web/env/FOO\n  value: bar\n")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("/applications/name=web/env/FOO"))
+
+		err = apply("- type: replace\n  path: /applications/name=api/instances\n  value: 2\n")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("/applications/name=api/instances"))
+	})
+
+	It("returns an error when appending to values that are not lists", func() {
+		err := apply("- type: append\n  path: /applications/name=web/instances\n  value: 2\n")
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("ReadOverlay", func() {
+		It("returns an error for files that are not lists of operations", func() {
+			_, err := readOverlay("type: replace\n")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns an error for unknown operations", func() {
+			_, err := readOverlay("- type: merge\n  path: /memory\n  value: 1G\n")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("merge"))
+		})
+
+		It("returns an error for paths that do not start with /", func() {
+			_, err := readOverlay("- type: replace\n  path: memory\n  value: 1G\n")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns an error for operations without values", func() {
+			_, err := readOverlay("- type: append\n  path: /applications/name=web/services\n")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
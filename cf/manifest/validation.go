@@ -0,0 +1,478 @@
+package manifest
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/formatters"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/utils/generic"
+	"gopkg.in/yaml.v2"
+)
+
+// ValidationError is a problem in a manifest file, at the line and column
+// of the key or list item it is about. Line and Column are 0 when they are
+// not known.
+type ValidationError struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (err ValidationError) Error() string {
+	switch {
+	case err.Line == 0:
+		return fmt.Sprintf("%s: %s", err.File, err.Message)
+	case err.Column == 0:
+		return fmt.Sprintf("%s:%d: %s", err.File, err.Line, err.Message)
+	default:
+		return fmt.Sprintf("%s:%d:%d: %s", err.File, err.Line, err.Column, err.Message)
+	}
+}
+
+// ValidationErrors are the problems in a manifest and the manifests it
+// inherits from, one per line.
+type ValidationErrors []ValidationError
+
+func (errs ValidationErrors) Error() string {
+	lines := make([]string, len(errs))
+	for i, err := range errs {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+type valueKind int
+
+const (
+	stringKind valueKind = iota
+	nullableStringKind
+	bytesKind
+	intKind
+	boolKind
+	stringListKind
+	intListKind
+	envKind
+	routesKind
+	applicationsKind
+	serviceInstancesKind
+	objectKind
+)
+
+// appKeys are the properties of applications, which can also be given at
+// the top of the manifest for all of them.
+var appKeys = map[string]valueKind{
+	"buildpack":         nullableStringKind,
+	"command":           nullableStringKind,
+	"depends_on":        stringListKind,
+	"disk_quota":        bytesKind,
+	"docker-image":      stringKind,
+	"domain":            stringKind,
+	"domains":           stringListKind,
+	"env":               envKind,
+	"health-check-type": stringKind,
+	"host":              stringKind,
+	"hosts":             stringListKind,
+	"instances":         intKind,
+	"memory":            bytesKind,
+	"name":              stringKind,
+	"no-hostname":       boolKind,
+	"no-route":          boolKind,
+	"path":              stringKind,
+	"random-route":      boolKind,
+	"routes":            routesKind,
+	"services":          stringListKind,
+	"stack":             stringKind,
+	"timeout":           intKind,
+	"app-ports":         intListKind,
+}
+
+var topLevelKeys = map[string]valueKind{
+	"applications":      applicationsKind,
+	"inherit":           stringKind,
+	"service_instances": serviceInstancesKind,
+}
+
+var routeKeys = map[string]valueKind{
+	"route": stringKind,
+}
+
+var serviceInstanceKeys = map[string]valueKind{
+	"name":        stringKind,
+	"offering":    stringKind,
+	"plan":        stringKind,
+	"parameters":  objectKind,
+	"tags":        stringListKind,
+	"credentials": objectKind,
+}
+
+var yamlErrorRegex = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// validateManifest checks the manifest in source, read from file, and
+// returns its problems and the path of the manifest it inherits from.
+func validateManifest(file string, source []byte) (ValidationErrors, string) {
+	v := &validator{file: file}
+
+	data := make(map[interface{}]interface{})
+	err := yaml.Unmarshal(source, &data)
+	if err != nil {
+		validationErr := ValidationError{File: file, Message: err.Error()}
+		if match := yamlErrorRegex.FindStringSubmatch(err.Error()); match != nil {
+			validationErr.Line, _ = strconv.Atoi(match[1])
+			validationErr.Message = match[2]
+		}
+		return ValidationErrors{validationErr}, ""
+	}
+
+	if len(data) == 0 {
+		return ValidationErrors{{File: file, Message: T("Invalid manifest. Expected a map")}}, ""
+	}
+
+	v.positions = keyPositions(source)
+	v.validateMap("", data, appKeys, topLevelKeys)
+
+	sort.Stable(byPosition(v.errs))
+
+	inherit, _ := data["inherit"].(string)
+	return v.errs, inherit
+}
+
+type validator struct {
+	file      string
+	positions map[string]position
+	errs      ValidationErrors
+}
+
+func (v *validator) fail(path string, message string) {
+	err := ValidationError{File: v.file, Message: message}
+
+	for lookup := path; lookup != ""; lookup = parentPath(lookup) {
+		if pos, found := v.positions[lookup]; found {
+			err.Line, err.Column = pos.line, pos.column
+			break
+		}
+	}
+
+	v.errs = append(v.errs, err)
+}
+
+func (v *validator) validateMap(path string, data map[interface{}]interface{}, keySets ...map[string]valueKind) {
+	for key, value := range data {
+		name := fmt.Sprint(key)
+		keyPath := childPath(path, name)
+
+		kind, found := lookupKind(name, keySets)
+		if !found {
+			v.fail(keyPath, T("Unknown key {{.PropertyName}}", map[string]interface{}{"PropertyName": keyPath}))
+			continue
+		}
+
+		v.validateValue(keyPath, kind, value)
+	}
+}
+
+func lookupKind(name string, keySets []map[string]valueKind) (valueKind, bool) {
+	for _, keys := range keySets {
+		if kind, found := keys[name]; found {
+			return kind, true
+		}
+	}
+	return 0, false
+}
+
+func (v *validator) validateValue(path string, kind valueKind, value interface{}) {
+	if value == nil {
+		if kind != nullableStringKind {
+			v.fail(path, T("{{.PropertyName}} should not be null", map[string]interface{}{"PropertyName": path}))
+		}
+		return
+	}
+
+	if isPlaceholder(value) {
+		return
+	}
+
+	switch kind {
+	case stringKind:
+		if _, ok := value.(string); !ok {
+			v.fail(path, T("{{.PropertyName}} must be a string value", map[string]interface{}{"PropertyName": path}))
+		}
+	case nullableStringKind:
+		if _, ok := value.(string); !ok {
+			v.fail(path, T("{{.PropertyName}} must be a string or null value", map[string]interface{}{"PropertyName": path}))
+		}
+	case bytesKind:
+		if _, err := formatters.ToMegabytes(coerceToString(value)); err != nil {
+			v.fail(path, T("Invalid byte size {{.Value}} for {{.PropertyName}}: {{.Err}}",
+				map[string]interface{}{"Value": coerceToString(value), "PropertyName": path, "Err": err.Error()}))
+		}
+	case intKind:
+		if !isInt(value) {
+			v.fail(path, T("Expected {{.PropertyName}} to be a number, but it was a {{.PropertyType}}.",
+				map[string]interface{}{"PropertyName": path, "PropertyType": typeName(value)}))
+		}
+	case boolKind:
+		if !isBool(value) {
+			v.fail(path, T("Expected {{.PropertyName}} to be a boolean.", map[string]interface{}{"PropertyName": path}))
+		}
+	case stringListKind:
+		v.validateList(path, value, T("Expected {{.PropertyName}} to be a list of strings.", map[string]interface{}{"PropertyName": path}),
+			func(item interface{}) bool {
+				_, ok := item.(string)
+				return ok
+			})
+	case intListKind:
+		v.validateList(path, value, T("Expected {{.PropertyName}} to be a list of integers.", map[string]interface{}{"PropertyName": path}),
+			func(item interface{}) bool {
+				_, ok := item.(int)
+				return ok
+			})
+	case envKind:
+		v.validateEnv(path, value)
+	case routesKind:
+		v.validateMaps(path, value, routeKeys, "route", T("each route in 'routes' must have a 'route' property"))
+	case applicationsKind:
+		v.validateMaps(path, value, appKeys, "", "")
+	case serviceInstancesKind:
+		v.validateMaps(path, value, serviceInstanceKeys, "name", T("Each service instance must have a name"))
+	case objectKind:
+		if !generic.IsMappable(value) {
+			v.fail(path, T("Expected {{.Name}} to be a set of key => value, but it was a {{.Type}}.",
+				map[string]interface{}{"Name": path, "Type": typeName(value)}))
+		}
+	}
+}
+
+func (v *validator) validateList(path string, value interface{}, message string, valid func(interface{}) bool) {
+	items, ok := value.([]interface{})
+	if !ok {
+		v.fail(path, message)
+		return
+	}
+
+	for i, item := range items {
+		if !valid(item) && !isPlaceholder(item) {
+			v.fail(itemPath(path, i), message)
+		}
+	}
+}
+
+func (v *validator) validateEnv(path string, value interface{}) {
+	vars, ok := value.(map[interface{}]interface{})
+	if !ok {
+		v.fail(path, T("Expected {{.Name}} to be a set of key => value, but it was a {{.Type}}.",
+			map[string]interface{}{"Name": path, "Type": typeName(value)}))
+		return
+	}
+
+	for name, value := range vars {
+		if value == nil {
+			v.fail(childPath(path, fmt.Sprint(name)), T("env var '{{.PropertyName}}' should not be null",
+				map[string]interface{}{"PropertyName": name}))
+		}
+	}
+}
+
+// validateMaps checks a list of maps with keys, such as applications, in
+// which the required key, if any, must be given or missingMessage is
+// reported.
+func (v *validator) validateMaps(path string, value interface{}, keys map[string]valueKind, required string, missingMessage string) {
+	items, ok := value.([]interface{})
+	if !ok {
+		v.fail(path, T("Expected {{.PropertyName}} to be a list of key/value pairs", map[string]interface{}{"PropertyName": path}))
+		return
+	}
+
+	for i, item := range items {
+		if item == nil || !generic.IsMappable(item) {
+			v.fail(itemPath(path, i), T("Expected {{.PropertyName}} to be a list of key/value pairs", map[string]interface{}{"PropertyName": path}))
+			continue
+		}
+
+		itemMap, _ := item.(map[interface{}]interface{})
+		v.validateMap(itemPath(path, i), itemMap, keys)
+
+		if _, found := itemMap[required]; required != "" && !found {
+			v.fail(itemPath(path, i), missingMessage)
+		}
+	}
+}
+
+func isPlaceholder(value interface{}) bool {
+	s, ok := value.(string)
+	if !ok {
+		return false
+	}
+	match := variableRegex.FindString(s)
+	return match != "" && match == s
+}
+
+func isInt(value interface{}) bool {
+	switch value := value.(type) {
+	case int, int64:
+		return true
+	case string:
+		_, err := strconv.Atoi(value)
+		return err == nil
+	}
+	return false
+}
+
+// typeName names the YAML type of value for error messages.
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case int, int64, float64:
+		return "number"
+	case []interface{}:
+		return "list"
+	case map[interface{}]interface{}:
+		return "map"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func isBool(value interface{}) bool {
+	switch value := value.(type) {
+	case bool:
+		return true
+	case string:
+		return value == "true" || value == "false"
+	}
+	return false
+}
+
+func childPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func itemPath(path string, index int) string {
+	return fmt.Sprintf("%s[%d]", path, index)
+}
+
+func parentPath(path string) string {
+	index := strings.LastIndexAny(path, ".[")
+	if index < 0 {
+		return ""
+	}
+	return path[:index]
+}
+
+type byPosition ValidationErrors
+
+func (errs byPosition) Len() int      { return len(errs) }
+func (errs byPosition) Swap(i, j int) { errs[i], errs[j] = errs[j], errs[i] }
+func (errs byPosition) Less(i, j int) bool {
+	if errs[i].Line != errs[j].Line {
+		return errs[i].Line < errs[j].Line
+	}
+	if errs[i].Column != errs[j].Column {
+		return errs[i].Column < errs[j].Column
+	}
+	return errs[i].Message < errs[j].Message
+}
+
+// position is the line and column, both starting at 1, of a key or list
+// item in a manifest.
+type position struct {
+	line   int
+	column int
+}
+
+type scanFrame struct {
+	column int
+	path   string
+	item   bool
+	items  int
+}
+
+var yamlKeyRegex = regexp.MustCompile(`^("(?:[^"\\]|\\.)*"|'[^']*'|[^\s#'"\[\]{},][^#]*?)\s*:(?:\s|$)`)
+
+// keyPositions finds the positions of the keys and list items of the block
+// YAML in source, by paths such as applications[0].env.NAME. YAML parsed
+// into Go values does not keep positions, and manifests hardly use more
+// than block mappings, block sequences and scalars. Keys within flow
+// collections are not found, so errors about them are reported at the
+// nearest key that is.
+func keyPositions(source []byte) map[string]position {
+	positions := map[string]position{}
+	stack := []*scanFrame{{column: -1}}
+	blockScalarColumn := -1
+
+	for number, line := range strings.Split(string(source), "\n") {
+		line = strings.TrimRight(line, "\r")
+		content := strings.TrimLeft(line, " ")
+		column := len(line) - len(content)
+
+		if blockScalarColumn >= 0 {
+			if strings.TrimSpace(content) == "" || column > blockScalarColumn {
+				continue
+			}
+			blockScalarColumn = -1
+		}
+
+		if content == "" || strings.HasPrefix(content, "#") || strings.HasPrefix(content, "---") || strings.HasPrefix(content, "...") {
+			continue
+		}
+
+		for content != "" {
+			if content == "-" || strings.HasPrefix(content, "- ") {
+				for top := stack[len(stack)-1]; top.column > column || (top.column == column && top.item); top = stack[len(stack)-1] {
+					stack = stack[:len(stack)-1]
+				}
+
+				parent := stack[len(stack)-1]
+				path := itemPath(parent.path, parent.items)
+				parent.items++
+
+				rest := strings.TrimLeft(content[1:], " ")
+				itemColumn := column + len(content) - len(rest)
+				positions[path] = position{line: number + 1, column: itemColumn + 1}
+				stack = append(stack, &scanFrame{column: column, path: path, item: true})
+
+				content, column = rest, itemColumn
+				continue
+			}
+
+			match := yamlKeyRegex.FindStringSubmatch(content)
+			if match == nil {
+				break
+			}
+
+			for top := stack[len(stack)-1]; top.column >= column; top = stack[len(stack)-1] {
+				stack = stack[:len(stack)-1]
+			}
+
+			path := childPath(stack[len(stack)-1].path, unquoteKey(strings.TrimSpace(match[1])))
+			positions[path] = position{line: number + 1, column: column + 1}
+			stack = append(stack, &scanFrame{column: column, path: path})
+
+			value := strings.TrimSpace(content[len(match[0]):])
+			if strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+				blockScalarColumn = column
+			}
+			break
+		}
+	}
+
+	return positions
+}
+
+func unquoteKey(key string) string {
+	if strings.HasPrefix(key, `"`) {
+		if unquoted, err := strconv.Unquote(key); err == nil {
+			return unquoted
+		}
+	}
+	return strings.Trim(key, `"'`)
+}
@@ -0,0 +1,156 @@
+package manifest_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/manifest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateManifest", func() {
+	var (
+		dir  string
+		repo manifest.Repository
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "manifest-validation")
+		Expect(err).NotTo(HaveOccurred())
+
+		repo = manifest.NewDiskRepository()
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	writeManifest := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		err := ioutil.WriteFile(path, []byte(contents), 0600)
+		Expect(err).NotTo(HaveOccurred())
+		return path
+	}
+
+	validationErrors := func(path string) manifest.ValidationErrors {
+		err := repo.ValidateManifest(path)
+		Expect(err).To(BeAssignableToTypeOf(manifest.ValidationErrors{}))
+		return err.(manifest.ValidationErrors)
+	}
+
+	It("accepts valid manifests", func() {
+		path := writeManifest("manifest.yml", `---
+memory: 512M
+applications:
+- name: my-app
+  instances: 2
+  disk_quota: 1G
+  no-route: true
+  buildpack: null
+  command: |
+    bundle exec rackup
+    not-a-key: here
+  hosts:
+  - my-app
+  routes:
+  - route: my-app.example.com
+  env:
+    LOG_LEVEL: debug
+  app-ports: [8080, 9090]
+  timeout: ((timeout))
+- name: my-worker
+  docker-image: example/worker
+  health-check-type: none
+  depends_on: [my-app]
+`)
+
+		Expect(repo.ValidateManifest(path)).To(Succeed())
+	})
+
+	It("reports unknown keys, wrong types and invalid byte sizes with their lines and columns", func() {
+		path := writeManifest("manifest.yml", `---
+applications:
+- name: my-app
+  instance: 2
+  memory: 512X
+  routes:
+  - route: my-app.example.com
+  - host: my-app
+- name: other-app
+  no-route: maybe
+  services: [db, 3]
+  env:
+    SECRET:
+  instances: many
+`)
+
+		Expect(validationErrors(path)).To(Equal(manifest.ValidationErrors{
+			{File: path, Line: 4, Column: 3, Message: "Unknown key applications[0].instance"},
+			{File: path, Line: 5, Column: 3, Message: "Invalid byte size 512X for applications[0].memory: Byte quantity must be an integer with a unit of measurement like M, MB, G, or GB"},
+			{File: path, Line: 8, Column: 5, Message: "Unknown key applications[0].routes[1].host"},
+			{File: path, Line: 8, Column: 5, Message: "each route in 'routes' must have a 'route' property"},
+			{File: path, Line: 10, Column: 3, Message: "Expected applications[1].no-route to be a boolean."},
+			{File: path, Line: 11, Column: 3, Message: "Expected applications[1].services to be a list of strings."},
+			{File: path, Line: 13, Column: 5, Message: "env var 'SECRET' should not be null"},
+			{File: path, Line: 14, Column: 3, Message: "Expected applications[1].instances to be a number, but it was a string."},
+		}))
+	})
+
+	It("checks the service instances", func() {
+		path := writeManifest("manifest.yml", `---
+service_instances:
+- name: db
+  offering: postgres
+  plan: small
+  parameters:
+    backups: true
+- offering: redis
+  plan: [small]
+- name: smtp
+  credentials: smtp.example.com
+applications:
+- name: my-app
+  services: [db, smtp]
+`)
+
+		Expect(validationErrors(path)).To(Equal(manifest.ValidationErrors{
+			{File: path, Line: 8, Column: 3, Message: "Each service instance must have a name"},
+			{File: path, Line: 9, Column: 3, Message: "service_instances[1].plan must be a string value"},
+			{File: path, Line: 11, Column: 3, Message: "Expected service_instances[2].credentials to be a set of key => value, but it was a string."},
+		}))
+	})
+
+	It("reports null items in lists of key/value pairs", func() {
+		path := writeManifest("manifest.yml", "applications:\n-\nservice_instances:\n-\n")
+
+		errs := validationErrors(path)
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0].Message).To(Equal("Expected applications to be a list of key/value pairs"))
+		Expect(errs[1].Message).To(Equal("Expected service_instances to be a list of key/value pairs"))
+	})
+
+	It("reports YAML syntax errors with their lines", func() {
+		path := writeManifest("manifest.yml", "applications:\n- name: my-app\n  memory: [512M\n")
+
+		errs := validationErrors(path)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Line).NotTo(BeZero())
+	})
+
+	It("validates the manifests it inherits from", func() {
+		parent := writeManifest("base.yml", "memory: lots\n")
+		path := writeManifest("manifest.yml", "inherit: base.yml\napplications:\n- name: my-app\n")
+
+		Expect(validationErrors(path)).To(Equal(manifest.ValidationErrors{
+			{File: parent, Line: 1, Column: 1, Message: "Invalid byte size lots for memory: Byte quantity must be an integer with a unit of measurement like M, MB, G, or GB"},
+		}))
+	})
+
+	It("formats errors with the file, line and column", func() {
+		err := manifest.ValidationError{File: "manifest.yml", Line: 4, Column: 3, Message: "Unknown key instance"}
+		Expect(err.Error()).To(Equal("manifest.yml:4:3: Unknown key instance"))
+	})
+})
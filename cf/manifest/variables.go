@@ -0,0 +1,157 @@
+package manifest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/errors"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/utils/generic"
+	"gopkg.in/yaml.v2"
+)
+
+// Variables hold the values of the ((name)) placeholders in manifests,
+// given with cf push --vars-file and --var.
+type Variables map[string]interface{}
+
+func NewVariables() Variables {
+	return Variables{}
+}
+
+// ReadFile adds the variables of the YAML file at path, replacing those
+// that are already set.
+func (vars Variables) ReadFile(path string) error {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.New(T("Error reading variables file {{.Path}}: {{.Err}}",
+			map[string]interface{}{"Path": path, "Err": err.Error()}))
+	}
+
+	fileVars := map[string]interface{}{}
+	err = yaml.Unmarshal(contents, &fileVars)
+	if err != nil {
+		return errors.New(T("Invalid variables file {{.Path}}: {{.Err}}",
+			map[string]interface{}{"Path": path, "Err": err.Error()}))
+	}
+
+	for name, value := range fileVars {
+		vars[name] = value
+	}
+	return nil
+}
+
+// Set adds the variable given as NAME=VALUE.
+func (vars Variables) Set(assignment string) error {
+	parts := strings.SplitN(assignment, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return errors.New(T("Invalid variable {{.Variable}}. Use NAME=VALUE.",
+			map[string]interface{}{"Variable": assignment}))
+	}
+
+	vars[parts[0]] = parts[1]
+	return nil
+}
+
+// lookup finds the variable name, or with a name such as db.host, the host
+// of the map in the variable db.
+func (vars Variables) lookup(name string) (interface{}, bool) {
+	if value, found := vars[name]; found {
+		return value, true
+	}
+
+	path := strings.Split(name, ".")
+	value, found := vars[path[0]]
+	for _, key := range path[1:] {
+		if !found || value == nil || !generic.IsMappable(value) {
+			return nil, false
+		}
+
+		values := generic.NewMap(value)
+		if !values.Has(key) {
+			return nil, false
+		}
+		value = values.Get(key)
+	}
+	return value, found
+}
+
+var variableRegex = regexp.MustCompile(`\(\(([\w.-]+)\)\)`)
+
+// Interpolate replaces the ((name)) placeholders in the values of the
+// manifest with vars. A value that is only a placeholder takes the value of
+// the variable, whatever its type; placeholders within strings are replaced
+// with the text of the variable. The error for placeholders without
+// variables lists all of them.
+func (m *Manifest) Interpolate(vars Variables) error {
+	if m.Data == nil {
+		return nil
+	}
+
+	missing := map[string]bool{}
+	data := interpolate(m.Data, vars, missing)
+
+	if len(missing) > 0 {
+		names := []string{}
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return errors.New(T("Expected to find variables: {{.Variables}}",
+			map[string]interface{}{"Variables": strings.Join(names, ", ")}))
+	}
+
+	m.Data = data.(generic.Map)
+	return nil
+}
+
+func interpolate(input interface{}, vars Variables, missing map[string]bool) interface{} {
+	switch input := input.(type) {
+	case string:
+		return interpolateString(input, vars, missing)
+	case []interface{}:
+		output := make([]interface{}, len(input))
+		for index, item := range input {
+			output[index] = interpolate(item, vars, missing)
+		}
+		return output
+	case map[interface{}]interface{}:
+		output := make(map[interface{}]interface{})
+		for key, value := range input {
+			output[key] = interpolate(value, vars, missing)
+		}
+		return output
+	case generic.Map:
+		output := generic.NewMap()
+		generic.Each(input, func(key, value interface{}) {
+			output.Set(key, interpolate(value, vars, missing))
+		})
+		return output
+	}
+
+	return input
+}
+
+func interpolateString(input string, vars Variables, missing map[string]bool) interface{} {
+	if match := variableRegex.FindStringSubmatch(input); match != nil && match[0] == input {
+		value, found := vars.lookup(match[1])
+		if !found {
+			missing[match[1]] = true
+			return input
+		}
+		return value
+	}
+
+	return variableRegex.ReplaceAllStringFunc(input, func(placeholder string) string {
+		name := variableRegex.FindStringSubmatch(placeholder)[1]
+		value, found := vars.lookup(name)
+		if !found {
+			missing[name] = true
+			return placeholder
+		}
+		return fmt.Sprint(value)
+	})
+}
@@ -0,0 +1,149 @@
+package manifest_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/manifest"
+	"github.com/cloudfoundry/cli/utils/generic"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Variables", func() {
+	var (
+		dir  string
+		vars manifest.Variables
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "manifest-variables")
+		Expect(err).NotTo(HaveOccurred())
+
+		vars = manifest.NewVariables()
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	writeFile := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		err := ioutil.WriteFile(path, []byte(contents), 0600)
+		Expect(err).NotTo(HaveOccurred())
+		return path
+	}
+
+	Describe("Set", func() {
+		It("sets variables given as NAME=VALUE", func() {
+			Expect(vars.Set("route=app.example.com")).To(Succeed())
+			Expect(vars.Set("empty=")).To(Succeed())
+			Expect(vars.Set("query=a=b")).To(Succeed())
+
+			Expect(vars).To(Equal(manifest.Variables{"route": "app.example.com", "empty": "", "query": "a=b"}))
+		})
+
+		It("returns an error for assignments without a name", func() {
+			Expect(vars.Set("route")).NotTo(Succeed())
+			Expect(vars.Set("=app.example.com")).NotTo(Succeed())
+		})
+	})
+
+	Describe("ReadFile", func() {
+		It("lets later files override earlier ones", func() {
+			Expect(vars.ReadFile(writeFile("base.yml", "instances: 1\nmemory: 256M\n"))).To(Succeed())
+			Expect(vars.ReadFile(writeFile("prod.yml", "instances: 4\n"))).To(Succeed())
+
+			Expect(vars).To(Equal(manifest.Variables{"instances": 4, "memory": "256M"}))
+		})
+
+		It("returns an error for missing or invalid files", func() {
+			Expect(vars.ReadFile(filepath.Join(dir, "missing.yml"))).NotTo(Succeed())
+			Expect(vars.ReadFile(writeFile("invalid.yml", "- not\n- a map\n"))).NotTo(Succeed())
+		})
+	})
+
+	Describe("Interpolate", func() {
+		It("replaces placeholders with the values of the variables", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					map[interface{}]interface{}{
+						"name":      "((name))",
+						"instances": "((instances))",
+						"host":      "((name))-((space))",
+						"env": map[interface{}]interface{}{
+							"DB_HOST": "((db.host))",
+						},
+					},
+				},
+			}))
+
+			vars = manifest.Variables{
+				"name":      "my-app",
+				"space":     "staging",
+				"instances": 3,
+				"db":        map[interface{}]interface{}{"host": "db.example.com"},
+			}
+			Expect(m.Interpolate(vars)).To(Succeed())
+
+			apps, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*apps[0].Name).To(Equal("my-app"))
+			Expect(*apps[0].InstanceCount).To(Equal(3))
+			Expect(apps[0].Hosts).To(Equal([]string{"my-app-staging"}))
+			Expect(*apps[0].EnvironmentVars).To(Equal(map[string]interface{}{"DB_HOST": "db.example.com"}))
+		})
+
+		It("lists every variable it cannot find", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					map[interface{}]interface{}{
+						"name":   "((name))",
+						"memory": "((memory))",
+						"host":   "((host))-((name))",
+					},
+				},
+			}))
+
+			err := m.Interpolate(manifest.Variables{"name": "my-app"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("host, memory"))
+		})
+
+		It("reports variables under null values as missing", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					map[interface{}]interface{}{
+						"name": "((db.host))",
+					},
+				},
+			}))
+
+			path := writeFile("vars.yml", "db:\n")
+			Expect(vars.ReadFile(path)).To(Succeed())
+
+			err := m.Interpolate(vars)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("db.host"))
+		})
+
+		It("interpolates manifests after inheriting from their parents", func() {
+			writeFile("base.yml", "memory: ((memory))\n")
+			path := writeFile("manifest.yml", "inherit: base.yml\napplications:\n- name: ((name))\n")
+
+			m, err := manifest.NewDiskRepository().ReadManifest(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(vars.Set("name=my-app")).To(Succeed())
+			Expect(vars.ReadFile(writeFile("vars.yml", "memory: 1G\n"))).To(Succeed())
+			Expect(m.Interpolate(vars)).To(Succeed())
+
+			apps, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*apps[0].Name).To(Equal("my-app"))
+			Expect(*apps[0].Memory).To(Equal(int64(1024)))
+		})
+	})
+})
@@ -45,3 +45,20 @@ type ServiceInstance struct {
 func (inst ServiceInstance) IsUserProvided() bool {
 	return inst.ServicePlan.GUID == ""
 }
+
+// ServiceInstanceParams describe a service instance in the
+// service_instances section of a manifest, which push creates when it does
+// not exist. Instances with Credentials are user-provided; the others are
+// created from the Plan of the service Offering.
+type ServiceInstanceParams struct {
+	Name        string
+	Offering    string
+	Plan        string
+	Parameters  map[string]interface{}
+	Tags        []string
+	Credentials map[string]interface{}
+}
+
+func (params ServiceInstanceParams) IsUserProvided() bool {
+	return params.Credentials != nil
+}
@@ -0,0 +1,310 @@
+package net
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/cloudfoundry/cli/cf/errors"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/trace"
+)
+
+// Cassette holds the requests and responses of CLI sessions, one JSON
+// interaction per line. With CF_RECORD=file every request gateways make is
+// appended to the cassette, sanitized like CF_TRACE output; with
+// CF_REPLAY=file gateways answer each request with the first unused recorded
+// response for the same method, path and query, without using the network.
+// The host is not compared, so that a session can be replayed against any
+// API endpoint.
+type Cassette struct {
+	Interactions []Interaction
+
+	path      string
+	replaying bool
+	used      []bool
+	mutex     sync.Mutex
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"headers,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"status"`
+	Header     http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// CassetteMissError is returned when a replayed session makes a request
+// that was not recorded.
+type CassetteMissError struct {
+	Method string
+	URL    string
+	Path   string
+}
+
+func (err *CassetteMissError) Error() string {
+	return T("No response for {{.Method}} {{.URL}} is recorded in {{.Path}}",
+		map[string]interface{}{"Method": err.Method, "URL": err.URL, "Path": err.Path})
+}
+
+var (
+	envCassette     *Cassette
+	envCassetteErr  error
+	envCassetteOnce sync.Once
+)
+
+// CassetteFromEnv returns the cassette named by CF_REPLAY or CF_RECORD, or
+// nil if neither is set. It is built on the first call and shared by all the
+// later ones, so that every gateway of the process uses the same cassette.
+func CassetteFromEnv() (*Cassette, error) {
+	envCassetteOnce.Do(func() {
+		envCassette, envCassetteErr = NewCassette(os.Getenv("CF_RECORD"), os.Getenv("CF_REPLAY"))
+	})
+	return envCassette, envCassetteErr
+}
+
+// NewCassette replays the session from replayPath if it is given, records it
+// to recordPath if that is given, and returns nil otherwise.
+func NewCassette(recordPath string, replayPath string) (*Cassette, error) {
+	switch {
+	case replayPath != "":
+		return NewReplayingCassette(replayPath)
+	case recordPath != "":
+		return NewRecordingCassette(recordPath)
+	}
+	return nil, nil
+}
+
+// NewRecordingCassette records to the file at path, after any interactions
+// it already holds.
+func NewRecordingCassette(path string) (*Cassette, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.New(T("Error writing cassette {{.Path}}: {{.Err}}",
+			map[string]interface{}{"Path": path, "Err": err.Error()}))
+	}
+	file.Close()
+
+	return &Cassette{path: path}, nil
+}
+
+// NewReplayingCassette replays the interactions recorded in the file at path.
+func NewReplayingCassette(path string) (*Cassette, error) {
+	cassette := &Cassette{path: path, replaying: true}
+
+	err := cassette.load()
+	if err != nil {
+		return nil, err
+	}
+
+	cassette.used = make([]bool, len(cassette.Interactions))
+	return cassette, nil
+}
+
+func (cassette *Cassette) load() error {
+	file, err := os.Open(cassette.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	for {
+		var interaction Interaction
+		err = decoder.Decode(&interaction)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.New(T("Invalid cassette {{.Path}}: {{.Err}}",
+				map[string]interface{}{"Path": cassette.path, "Err": err.Error()}))
+		}
+		cassette.Interactions = append(cassette.Interactions, interaction)
+	}
+}
+
+// Replaying is true for cassettes that answer requests instead of recording
+// them. Replayed sessions must not change the config, whose tokens would
+// otherwise be replaced with the sanitized ones from the cassette.
+func (cassette *Cassette) Replaying() bool {
+	return cassette != nil && cassette.replaying
+}
+
+// Client records the requests client makes, or answers them from the
+// cassette when it is replaying.
+func (cassette *Cassette) Client(client HTTPClientInterface) HTTPClientInterface {
+	return &cassetteClient{HTTPClientInterface: client, cassette: cassette}
+}
+
+type cassetteClient struct {
+	HTTPClientInterface
+	cassette *Cassette
+}
+
+func (client *cassetteClient) Do(request *http.Request) (*http.Response, error) {
+	if client.cassette.replaying {
+		return client.cassette.replay(request)
+	}
+
+	recorded := recordRequest(request)
+
+	// bodies that cannot be read again, such as uploaded files, are recorded
+	// as they are sent
+	var sent *bytes.Buffer
+	if request.GetBody == nil && request.Body != nil && recorded.Body == "" {
+		sent = &bytes.Buffer{}
+		request.Body = &teeReadCloser{Reader: io.TeeReader(request.Body, sent), Closer: request.Body}
+	}
+
+	response, err := client.HTTPClientInterface.Do(request)
+	if sent != nil {
+		recorded.Body = trace.Sanitize(sent.String())
+	}
+	if err != nil {
+		return response, err
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return response, err
+	}
+
+	return response, client.cassette.record(Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: response.StatusCode,
+			Header:     sanitizeHeader(response.Header),
+			Body:       trace.Sanitize(string(body)),
+		},
+	})
+}
+
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func recordRequest(request *http.Request) RecordedRequest {
+	recorded := RecordedRequest{
+		Method: request.Method,
+		URL:    request.URL.String(),
+		Header: sanitizeHeader(request.Header),
+	}
+
+	if strings.Contains(request.Header.Get("Content-Type"), "multipart/form-data") {
+		recorded.Body = T("[MULTIPART/FORM-DATA CONTENT HIDDEN]")
+	} else if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err == nil {
+			data, _ := ioutil.ReadAll(body)
+			recorded.Body = trace.Sanitize(string(data))
+		}
+	}
+
+	return recorded
+}
+
+func sanitizeHeader(header http.Header) http.Header {
+	sanitized := http.Header{}
+	for key, values := range header {
+		for _, value := range values {
+			line := trace.Sanitize(fmt.Sprintf("%s: %s", key, value))
+			sanitized.Add(key, strings.TrimPrefix(line, key+": "))
+		}
+	}
+	return sanitized
+}
+
+func (cassette *Cassette) record(interaction Interaction) error {
+	cassette.mutex.Lock()
+	defer cassette.mutex.Unlock()
+
+	cassette.Interactions = append(cassette.Interactions, interaction)
+
+	// every interaction is appended as soon as it is made, since the CLI may
+	// exit at any point
+	data, err := json.Marshal(interaction)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(cassette.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err == nil {
+		_, err = file.Write(append(data, '\n'))
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		return errors.New(T("Error writing cassette {{.Path}}: {{.Err}}",
+			map[string]interface{}{"Path": cassette.path, "Err": err.Error()}))
+	}
+	return nil
+}
+
+func (cassette *Cassette) replay(request *http.Request) (*http.Response, error) {
+	cassette.mutex.Lock()
+	defer cassette.mutex.Unlock()
+
+	requestURI := request.URL.RequestURI()
+	for i, interaction := range cassette.Interactions {
+		if cassette.used[i] || interaction.Request.Method != request.Method || recordedRequestURI(interaction.Request.URL) != requestURI {
+			continue
+		}
+		cassette.used[i] = true
+
+		if request.Body != nil {
+			request.Body.Close()
+		}
+
+		recorded := interaction.Response
+		header := http.Header{}
+		for key, values := range recorded.Header {
+			header[key] = values
+		}
+		// the body may have been sanitized to a different length
+		header.Del("Content-Length")
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(recorded.Body)),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       request,
+		}, nil
+	}
+
+	return nil, &CassetteMissError{Method: request.Method, URL: request.URL.String(), Path: cassette.path}
+}
+
+// recordedRequestURI returns the path and query of a recorded URL.
+func recordedRequestURI(recordedURL string) string {
+	parsed, err := url.Parse(recordedURL)
+	if err != nil {
+		return recordedURL
+	}
+	return parsed.RequestURI()
+}
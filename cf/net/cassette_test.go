@@ -0,0 +1,204 @@
+package net_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/errors"
+	. "github.com/cloudfoundry/cli/cf/net"
+	"github.com/cloudfoundry/cli/cf/terminal/terminalfakes"
+	"github.com/cloudfoundry/cli/cf/trace/tracefakes"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cassette", func() {
+	var (
+		dir       string
+		path      string
+		apiServer *httptest.Server
+		gateway   Gateway
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "cassette")
+		Expect(err).NotTo(HaveOccurred())
+		path = filepath.Join(dir, "cassette.json")
+
+		apiServer = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			switch request.URL.Path {
+			case "/oauth/token":
+				fmt.Fprintln(writer, `{"access_token": "the-access-token", "token_type": "bearer"}`)
+			case "/v2/apps":
+				writer.Header().Set("X-Cf-Warnings", "recorded-warning")
+				fmt.Fprintf(writer, `{"name": "app-%s"}`, request.URL.Query().Get("page"))
+			default:
+				writer.WriteHeader(http.StatusNotFound)
+			}
+		}))
+
+		gateway = NewCloudControllerGateway(testconfig.NewRepository(), time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+		gateway.RetryPolicy.Sleep = func(time.Duration) {}
+	})
+
+	AfterEach(func() {
+		apiServer.Close()
+		os.RemoveAll(dir)
+	})
+
+	get := func(url string) (string, error) {
+		request, err := gateway.NewRequest("GET", url, "bearer the-access-token", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		body, _, err := gateway.PerformRequestForTextResponse(request)
+		return body, err
+	}
+
+	record := func() {
+		cassette, err := NewRecordingCassette(path)
+		Expect(err).NotTo(HaveOccurred())
+		gateway.Cassette = cassette
+
+		body, err := get(apiServer.URL + "/v2/apps?page=1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(ContainSubstring("app-1"))
+
+		request, err := gateway.NewRequest("POST", apiServer.URL+"/oauth/token", "", strings.NewReader("grant_type=password&password=secret&username=user"))
+		Expect(err).NotTo(HaveOccurred())
+		body, _, err = gateway.PerformRequestForTextResponse(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(ContainSubstring("the-access-token"))
+	}
+
+	Describe("recording", func() {
+		It("writes every request and response to the cassette", func() {
+			record()
+
+			cassette, err := NewReplayingCassette(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cassette.Interactions).To(HaveLen(2))
+
+			interaction := cassette.Interactions[0]
+			Expect(interaction.Request.Method).To(Equal("GET"))
+			Expect(interaction.Request.URL).To(Equal(apiServer.URL + "/v2/apps?page=1"))
+			Expect(interaction.Response.StatusCode).To(Equal(http.StatusOK))
+			Expect(interaction.Response.Header.Get("X-Cf-Warnings")).To(Equal("recorded-warning"))
+			Expect(interaction.Response.Body).To(Equal(`{"name": "app-1"}`))
+		})
+
+		It("sanitizes the cassette", func() {
+			record()
+
+			data, err := ioutil.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).NotTo(ContainSubstring("the-access-token"))
+			Expect(string(data)).NotTo(ContainSubstring("secret"))
+			Expect(string(data)).To(ContainSubstring("[PRIVATE DATA HIDDEN]"))
+		})
+
+		It("appends to an existing cassette", func() {
+			record()
+			first, err := ioutil.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			record()
+
+			data, err := ioutil.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(HavePrefix(string(first)))
+
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			Expect(lines).To(HaveLen(4))
+			for _, line := range lines {
+				var interaction Interaction
+				Expect(json.Unmarshal([]byte(line), &interaction)).To(Succeed())
+			}
+		})
+
+		It("records bodies that cannot be read again", func() {
+			cassette, err := NewRecordingCassette(path)
+			Expect(err).NotTo(HaveOccurred())
+			gateway.Cassette = cassette
+
+			request, err := gateway.NewRequest("PUT", apiServer.URL+"/v2/apps", "bearer the-access-token", nil)
+			Expect(err).NotTo(HaveOccurred())
+			request.HTTPReq.Body = ioutil.NopCloser(strings.NewReader(`{"name": "uploaded"}`))
+			request.HTTPReq.ContentLength = int64(len(`{"name": "uploaded"}`))
+			Expect(request.HTTPReq.GetBody).To(BeNil())
+
+			_, _, err = gateway.PerformRequestForTextResponse(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			cassette, err = NewReplayingCassette(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cassette.Interactions).To(HaveLen(1))
+			Expect(cassette.Interactions[0].Request.Body).To(Equal(`{"name": "uploaded"}`))
+		})
+
+		It("returns an error when the cassette cannot be written", func() {
+			_, err := NewRecordingCassette(filepath.Join(dir, "missing", "cassette.json"))
+			Expect(err).To(MatchError(ContainSubstring("Error writing cassette")))
+		})
+	})
+
+	Describe("replaying", func() {
+		BeforeEach(func() {
+			record()
+			apiServer.Close()
+
+			cassette, err := NewReplayingCassette(path)
+			Expect(err).NotTo(HaveOccurred())
+			gateway.Cassette = cassette
+		})
+
+		It("answers requests from the cassette without the network", func() {
+			body, err := get(apiServer.URL + "/v2/apps?page=1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(Equal(`{"name": "app-1"}`))
+			Expect(gateway.Warnings()).To(ContainElement("recorded-warning"))
+		})
+
+		It("matches requests on their path and query only", func() {
+			body, err := get("https://other-api.example.com/v2/apps?page=1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(Equal(`{"name": "app-1"}`))
+		})
+
+		It("uses each recorded response once", func() {
+			_, err := get(apiServer.URL + "/v2/apps?page=1")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = get(apiServer.URL + "/v2/apps?page=1")
+			Expect(err).To(BeAssignableToTypeOf(&errors.NetworkError{}))
+			Expect(err.(*errors.NetworkError).Err).To(Equal(&CassetteMissError{
+				Method: "GET",
+				URL:    apiServer.URL + "/v2/apps?page=1",
+				Path:   path,
+			}))
+		})
+
+		It("fails requests that were not recorded", func() {
+			_, err := get(apiServer.URL + "/v2/apps?page=2")
+			Expect(err).To(BeAssignableToTypeOf(&errors.NetworkError{}))
+			Expect(err.(*errors.NetworkError).Err).To(BeAssignableToTypeOf(&CassetteMissError{}))
+		})
+	})
+
+	It("returns an error when the cassette to replay cannot be read", func() {
+		_, err := NewReplayingCassette(filepath.Join(dir, "missing.json"))
+		Expect(err).To(HaveOccurred())
+
+		Expect(ioutil.WriteFile(path, []byte("not json"), 0600)).To(Succeed())
+		_, err = NewReplayingCassette(path)
+		Expect(err).To(MatchError(ContainSubstring("Invalid cassette")))
+	})
+})
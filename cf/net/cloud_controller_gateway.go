@@ -3,6 +3,7 @@ package net
 import (
 	"encoding/json"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
@@ -35,10 +36,14 @@ func NewCloudControllerGateway(config coreconfig.Reader, clock func() time.Time,
 		config:          config,
 		PollingThrottle: DefaultPollingThrottle,
 		warnings:        &[]string{},
+		warningsMutex:   &sync.Mutex{},
+		tokenRefresh:    &tokenRefresh{},
 		Clock:           clock,
 		ui:              ui,
 		logger:          logger,
 		PollingEnabled:  true,
 		DialTimeout:     dialTimeout(envDialTimeout),
+		PageConcurrency: DefaultPageConcurrency,
+		RetryPolicy:     DefaultRetryPolicy(),
 	}
 }
@@ -14,6 +14,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cloudfoundry/cli/cf"
@@ -29,6 +30,7 @@ const (
 	JobFailed              = "failed"
 	DefaultPollingThrottle = 5 * time.Second
 	DefaultDialTimeout     = 5 * time.Second
+	DefaultPageConcurrency = 4
 )
 
 type JobResource struct {
@@ -52,9 +54,22 @@ type tokenRefresher interface {
 	RefreshAuthToken() (string, error)
 }
 
+// tokenRefresh serializes the token refreshes of a gateway and its copies,
+// so that requests rejected with the same expired token, such as pages
+// fetched concurrently, refresh it once and all retry with the new token.
+type tokenRefresh struct {
+	mutex    sync.Mutex
+	oldToken string
+	newToken string
+}
+
 type Request struct {
 	HTTPReq      *http.Request
 	SeekableBody io.ReadSeeker
+
+	// SafeToRetry marks requests that can be repeated even though their verb
+	// is not idempotent.
+	SafeToRetry bool
 }
 
 type Gateway struct {
@@ -65,11 +80,17 @@ type Gateway struct {
 	trustedCerts    []tls.Certificate
 	config          coreconfig.Reader
 	warnings        *[]string
+	warningsMutex   *sync.Mutex
+	tokenRefresh    *tokenRefresh
 	Clock           func() time.Time
 	transport       *http.Transport
 	ui              terminal.UI
 	logger          trace.Printer
 	DialTimeout     time.Duration
+	PageConcurrency int
+	RetryPolicy     RetryPolicy
+	Cassette        *Cassette
+	Tracer          *JSONTracer
 }
 
 func (gateway *Gateway) AsyncTimeout() time.Duration {
@@ -80,6 +101,15 @@ func (gateway *Gateway) AsyncTimeout() time.Duration {
 	return 0
 }
 
+// RequestDumper returns the dumper that traces the requests of the gateway,
+// as text or, with a Tracer, as JSON lines.
+func (gateway Gateway) RequestDumper() RequestDumper {
+	if gateway.Tracer != nil {
+		return NewJSONRequestDumper(gateway.Tracer)
+	}
+	return NewRequestDumper(gateway.logger)
+}
+
 func (gateway *Gateway) SetTokenRefresher(auth tokenRefresher) {
 	gateway.authenticator = auth
 }
@@ -132,37 +162,161 @@ func (gateway Gateway) DeleteResource(endpoint, apiURL string) error {
 	return gateway.createUpdateOrDeleteResource("DELETE", endpoint, apiURL, nil, false, &AsyncResource{})
 }
 
+// ListPaginatedResources passes the resources of every page of a listing to
+// cb, in order, until cb returns false. The remaining pages are numbered from
+// the first page's total_pages and fetched up to PageConcurrency at a time;
+// listings whose next_url does not number its pages are followed one page at
+// a time.
 func (gateway Gateway) ListPaginatedResources(
 	target string,
 	path string,
 	resource interface{},
 	cb func(interface{}) bool,
 ) error {
+	resources, pagination, err := gateway.listPage(target, path, resource)
+	if err != nil {
+		return err
+	}
+
+	if !sendResources(resources, cb) {
+		return nil
+	}
+
+	paths := remainingPagePaths(pagination)
+	if paths == nil || gateway.PageConcurrency <= 1 {
+		return gateway.listPagesInSequence(target, pagination.NextURL, resource, cb)
+	}
+
+	return gateway.listPagesConcurrently(target, paths, resource, cb)
+}
+
+func (gateway Gateway) listPage(target string, path string, resource interface{}) ([]interface{}, PaginatedResources, error) {
+	pagination := NewPaginatedResources(resource)
+
+	apiErr := gateway.GetResource(fmt.Sprintf("%s%s", target, path), &pagination)
+	if apiErr != nil {
+		return nil, pagination, apiErr
+	}
+
+	resources, err := pagination.Resources()
+	if err != nil {
+		return nil, pagination, fmt.Errorf("%s: %s", T("Error parsing JSON"), err.Error())
+	}
+
+	return resources, pagination, nil
+}
+
+func (gateway Gateway) listPagesInSequence(target string, path string, resource interface{}, cb func(interface{}) bool) error {
 	for path != "" {
-		pagination := NewPaginatedResources(resource)
+		resources, pagination, err := gateway.listPage(target, path, resource)
+		if err != nil {
+			return err
+		}
 
-		apiErr := gateway.GetResource(fmt.Sprintf("%s%s", target, path), &pagination)
-		if apiErr != nil {
-			return apiErr
+		if !sendResources(resources, cb) {
+			return nil
 		}
 
-		resources, err := pagination.Resources()
-		if err != nil {
-			return fmt.Errorf("%s: %s", T("Error parsing JSON"), err.Error())
+		path = pagination.NextURL
+	}
+
+	return nil
+}
+
+type pageResult struct {
+	resources []interface{}
+	err       error
+}
+
+// listPagesConcurrently fetches the pages at paths with a pool of at most
+// PageConcurrency workers. Each page has its own buffered result, so workers
+// never wait on cb, and pages are passed to cb in order as they arrive. When
+// cb stops the listing or a page fails, no further pages are requested.
+func (gateway Gateway) listPagesConcurrently(target string, paths []string, resource interface{}, cb func(interface{}) bool) error {
+	results := make([]chan pageResult, len(paths))
+	for i := range results {
+		results[i] = make(chan pageResult, 1)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	pages := make(chan int)
+	go func() {
+		defer close(pages)
+		for i := range paths {
+			select {
+			case pages <- i:
+			case <-done:
+				return
+			}
 		}
+	}()
+
+	workers := gateway.PageConcurrency
+	if workers > len(paths) {
+		workers = len(paths)
+	}
 
-		for _, resource := range resources {
-			if !cb(resource) {
-				return nil
+	for w := 0; w < workers; w++ {
+		go func() {
+			for i := range pages {
+				resources, _, err := gateway.listPage(target, paths[i], resource)
+				results[i] <- pageResult{resources: resources, err: err}
 			}
+		}()
+	}
+
+	for i := range paths {
+		result := <-results[i]
+		if result.err != nil {
+			return result.err
 		}
 
-		path = pagination.NextURL
+		if !sendResources(result.resources, cb) {
+			return nil
+		}
 	}
 
 	return nil
 }
 
+func sendResources(resources []interface{}, cb func(interface{}) bool) bool {
+	for _, resource := range resources {
+		if !cb(resource) {
+			return false
+		}
+	}
+	return true
+}
+
+// remainingPagePaths numbers the pages that follow a page from its next_url
+// up to total_pages. It returns nil when next_url has no page parameter.
+func remainingPagePaths(pagination PaginatedResources) []string {
+	if pagination.NextURL == "" {
+		return nil
+	}
+
+	nextURL, err := url.Parse(pagination.NextURL)
+	if err != nil {
+		return nil
+	}
+
+	query := nextURL.Query()
+	nextPage, err := strconv.Atoi(query.Get("page"))
+	if err != nil || nextPage > pagination.TotalPages {
+		return nil
+	}
+
+	paths := []string{pagination.NextURL}
+	for page := nextPage + 1; page <= pagination.TotalPages; page++ {
+		query.Set("page", strconv.Itoa(page))
+		nextURL.RawQuery = query.Encode()
+		paths = append(paths, nextURL.String())
+	}
+	return paths
+}
+
 func (gateway Gateway) createUpdateOrDeleteResource(verb, endpoint, apiURL string, body io.ReadSeeker, sync bool, optionalResource ...interface{}) error {
 	var resource interface{}
 	if len(optionalResource) > 0 {
@@ -325,6 +479,8 @@ func (gateway Gateway) PerformPollingRequestForJSONResponse(endpoint string, req
 }
 
 func (gateway Gateway) Warnings() []string {
+	gateway.warningsMutex.Lock()
+	defer gateway.warningsMutex.Unlock()
 	return *gateway.warnings
 }
 
@@ -373,7 +529,7 @@ func (gateway Gateway) doRequestHandlingAuth(request *Request) (*http.Response,
 	case *errors.InvalidTokenError:
 		// refresh the auth token
 		var newToken string
-		newToken, err = gateway.authenticator.RefreshAuthToken()
+		newToken, err = gateway.refreshAuthToken(httpReq.Header.Get("Authorization"))
 		if err != nil {
 			return rawResponse, err
 		}
@@ -392,8 +548,29 @@ func (gateway Gateway) doRequestHandlingAuth(request *Request) (*http.Response,
 	return rawResponse, err
 }
 
+// refreshAuthToken returns a new token to replace oldToken. Only the first
+// request rejected with oldToken refreshes it; the others wait for it and
+// reuse the token it got.
+func (gateway Gateway) refreshAuthToken(oldToken string) (string, error) {
+	gateway.tokenRefresh.mutex.Lock()
+	defer gateway.tokenRefresh.mutex.Unlock()
+
+	if gateway.tokenRefresh.newToken != "" && gateway.tokenRefresh.oldToken == oldToken {
+		return gateway.tokenRefresh.newToken, nil
+	}
+
+	newToken, err := gateway.authenticator.RefreshAuthToken()
+	if err != nil {
+		return "", err
+	}
+
+	gateway.tokenRefresh.oldToken = oldToken
+	gateway.tokenRefresh.newToken = newToken
+	return newToken, nil
+}
+
 func (gateway Gateway) doRequestAndHandlerError(request *Request) (*http.Response, error) {
-	rawResponse, err := gateway.doRequest(request.HTTPReq)
+	rawResponse, err := gateway.doRequest(request)
 	if err != nil {
 		return rawResponse, WrapNetworkErrors(request.HTTPReq.URL.Host, err)
 	}
@@ -408,25 +585,66 @@ func (gateway Gateway) doRequestAndHandlerError(request *Request) (*http.Respons
 	return rawResponse, err
 }
 
-func (gateway Gateway) doRequest(request *http.Request) (*http.Response, error) {
+func (gateway Gateway) doRequest(request *Request) (*http.Response, error) {
 	var response *http.Response
 	var err error
 
 	if gateway.transport == nil {
-		makeHTTPTransport(&gateway)
+		err = makeHTTPTransport(&gateway)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	httpClient := NewHTTPClient(gateway.transport, NewRequestDumper(gateway.logger))
+	httpClient := NewHTTPClient(gateway.transport, gateway.RequestDumper())
+	if gateway.Cassette != nil {
+		httpClient = gateway.Cassette.Client(httpClient)
+	}
 
-	httpClient.DumpRequest(request)
+	retryable := gateway.RetryPolicy.allows(request)
+	for attempt := 1; ; attempt++ {
+		httpClient.DumpRequest(request.HTTPReq)
 
-	for i := 0; i < 3; i++ {
-		response, err = httpClient.Do(request)
-		if response == nil && err != nil {
-			continue
-		} else {
+		response, err = httpClient.Do(request.HTTPReq)
+		if err != nil && response == nil && gateway.Tracer != nil {
+			gateway.Tracer.DumpError(request.HTTPReq, err)
+		}
+
+		delay, retry := gateway.RetryPolicy.retryDelay(attempt, response, err)
+		if !retryable || !retry {
 			break
 		}
+
+		reason := ""
+		if response != nil {
+			httpClient.DumpResponse(response)
+			response.Body.Close()
+			reason = response.Status
+		} else {
+			reason = err.Error()
+		}
+
+		if gateway.Tracer != nil {
+			gateway.Tracer.DumpRetry(request.HTTPReq, attempt+1, delay, reason)
+		} else {
+			gateway.logger.Printf("\n%s [%s]\n%s\n", terminal.HeaderColor(T("RETRY:")), time.Now().Format(time.RFC3339),
+				T("Retrying {{.Method}} {{.URL}} in {{.Delay}} (attempt {{.Attempt}} of {{.MaxAttempts}}): {{.Reason}}",
+					map[string]interface{}{
+						"Method":      request.HTTPReq.Method,
+						"URL":         request.HTTPReq.URL.String(),
+						"Delay":       delay,
+						"Attempt":     attempt + 1,
+						"MaxAttempts": gateway.RetryPolicy.MaxAttempts,
+						"Reason":      reason,
+					}))
+		}
+
+		gateway.RetryPolicy.Sleep(delay)
+
+		if request.SeekableBody != nil {
+			_, _ = request.SeekableBody.Seek(0, 0)
+			request.HTTPReq.Body = ioutil.NopCloser(request.SeekableBody)
+		}
 	}
 
 	if err != nil {
@@ -437,20 +655,35 @@ func (gateway Gateway) doRequest(request *http.Request) (*http.Response, error)
 
 	header := http.CanonicalHeaderKey("X-Cf-Warnings")
 	rawWarnings := response.Header[header]
+	gateway.warningsMutex.Lock()
 	for _, rawWarning := range rawWarnings {
 		warning, _ := url.QueryUnescape(rawWarning)
 		*gateway.warnings = append(*gateway.warnings, warning)
 	}
+	gateway.warningsMutex.Unlock()
 
 	return response, err
 }
 
-func makeHTTPTransport(gateway *Gateway) {
+func makeHTTPTransport(gateway *Gateway) error {
+	tlsConfig, err := NewTLSConfigWithFiles(gateway.trustedCerts, gateway.config.IsSSLDisabled(), TLSFilesFromConfig(gateway.config))
+	if err != nil {
+		gateway.transport = nil
+		return err
+	}
+
+	proxy, err := ProxyFromConfig(gateway.config)
+	if err != nil {
+		gateway.transport = nil
+		return err
+	}
+
 	gateway.transport = &http.Transport{
 		Dial:            (&net.Dialer{Timeout: gateway.DialTimeout}).Dial,
-		TLSClientConfig: NewTLSConfig(gateway.trustedCerts, gateway.config.IsSSLDisabled()),
-		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
+		Proxy:           proxy.Func(),
 	}
+	return nil
 }
 
 func dialTimeout(envDialTimeout string) time.Duration {
@@ -463,5 +696,6 @@ func dialTimeout(envDialTimeout string) time.Duration {
 
 func (gateway *Gateway) SetTrustedCerts(certificates []tls.Certificate) {
 	gateway.trustedCerts = certificates
-	makeHTTPTransport(gateway)
+	// a TLS file that cannot be loaded is reported by the next request
+	_ = makeHTTPTransport(gateway)
 }
@@ -10,7 +10,9 @@ import (
 	"os"
 	"reflect"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cloudfoundry/cli/cf"
@@ -48,6 +50,7 @@ var _ = Describe("Gateway", func() {
 
 		ccGateway = NewCloudControllerGateway(config, clock, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
 		ccGateway.PollingThrottle = 3 * time.Millisecond
+		ccGateway.RetryPolicy.Sleep = func(time.Duration) {}
 		uaaGateway = NewUAAGateway(config, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
 	})
 
@@ -98,6 +101,151 @@ var _ = Describe("Gateway", func() {
 		})
 	})
 
+	Describe("retrying requests", func() {
+		var (
+			apiServer *httptest.Server
+			responses []int
+			headers   []http.Header
+			bodies    []string
+			delays    []time.Duration
+			logger    *tracefakes.FakePrinter
+		)
+
+		BeforeEach(func() {
+			responses = []int{}
+			headers = []http.Header{}
+			bodies = []string{}
+			delays = []time.Duration{}
+
+			apiServer = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				body, _ := ioutil.ReadAll(request.Body)
+				bodies = append(bodies, string(body))
+
+				status := http.StatusOK
+				if len(bodies) <= len(responses) {
+					status = responses[len(bodies)-1]
+				}
+				if len(bodies) <= len(headers) {
+					for key, values := range headers[len(bodies)-1] {
+						writer.Header()[key] = values
+					}
+				}
+
+				writer.WriteHeader(status)
+				fmt.Fprintln(writer, `{}`)
+			}))
+
+			logger = new(tracefakes.FakePrinter)
+			ccGateway = NewCloudControllerGateway(config, clock, new(terminalfakes.FakeUI), logger, "")
+			ccGateway.RetryPolicy = RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: 100 * time.Millisecond,
+				MaxBackoff:     time.Second,
+				Sleep:          func(delay time.Duration) { delays = append(delays, delay) },
+			}
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+		})
+
+		perform := func(method string, body string) error {
+			request, err := ccGateway.NewRequest(method, apiServer.URL+"/v2/apps", "BEARER my-access-token", strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = ccGateway.PerformRequest(request)
+			return err
+		}
+
+		It("retries idempotent requests that get a 429, 502, 503 or 504 with exponential backoff", func() {
+			responses = []int{http.StatusServiceUnavailable, http.StatusBadGateway}
+
+			err := perform("PUT", "the body")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bodies).To(Equal([]string{"the body", "the body", "the body"}))
+
+			Expect(delays).To(HaveLen(2))
+			Expect(delays[0]).To(BeNumerically(">=", 50*time.Millisecond))
+			Expect(delays[0]).To(BeNumerically("<=", 100*time.Millisecond))
+			Expect(delays[1]).To(BeNumerically(">=", 100*time.Millisecond))
+			Expect(delays[1]).To(BeNumerically("<=", 200*time.Millisecond))
+		})
+
+		It("gives up after MaxAttempts", func() {
+			responses = []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK}
+
+			err := perform("GET", "")
+			Expect(err).To(HaveOccurred())
+			Expect(bodies).To(HaveLen(3))
+		})
+
+		It("does not retry other failures", func() {
+			responses = []int{http.StatusInternalServerError}
+
+			err := perform("GET", "")
+			Expect(err).To(HaveOccurred())
+			Expect(bodies).To(HaveLen(1))
+		})
+
+		It("does not retry requests that are not idempotent", func() {
+			responses = []int{http.StatusServiceUnavailable}
+
+			err := perform("POST", "the body")
+			Expect(err).To(HaveOccurred())
+			Expect(bodies).To(HaveLen(1))
+		})
+
+		It("retries requests that are marked safe to retry", func() {
+			responses = []int{http.StatusServiceUnavailable}
+
+			request, err := ccGateway.NewRequest("POST", apiServer.URL+"/v2/apps", "BEARER my-access-token", strings.NewReader("the body"))
+			Expect(err).NotTo(HaveOccurred())
+			request.SafeToRetry = true
+
+			_, err = ccGateway.PerformRequest(request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bodies).To(Equal([]string{"the body", "the body"}))
+		})
+
+		It("waits as long as Retry-After asks", func() {
+			responses = []int{http.StatusServiceUnavailable}
+			headers = []http.Header{{"Retry-After": []string{"1"}}}
+
+			err := perform("GET", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(delays).To(Equal([]time.Duration{time.Second}))
+		})
+
+		It("does not retry when Retry-After asks for longer than MaxBackoff", func() {
+			responses = []int{http.StatusServiceUnavailable}
+			headers = []http.Header{{"Retry-After": []string{"60"}}}
+
+			err := perform("GET", "")
+			Expect(err).To(HaveOccurred())
+			Expect(bodies).To(HaveLen(1))
+		})
+
+		It("traces every retry", func() {
+			responses = []int{http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+			err := perform("GET", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			retries := []string{}
+			for i := 0; i < logger.PrintfCallCount(); i++ {
+				format, args := logger.PrintfArgsForCall(i)
+				if message := fmt.Sprintf(format, args...); strings.Contains(message, "RETRY:") {
+					retries = append(retries, message)
+				}
+			}
+
+			Expect(retries).To(HaveLen(2))
+			Expect(retries[0]).To(ContainSubstring("Retrying GET " + apiServer.URL + "/v2/apps"))
+			Expect(retries[0]).To(ContainSubstring("(attempt 2 of 3): 503 Service Unavailable"))
+			Expect(retries[1]).To(ContainSubstring("(attempt 3 of 3): 504 Gateway Timeout"))
+		})
+	})
+
 	Describe("NewRequest", func() {
 		var (
 			request *Request
@@ -583,6 +731,165 @@ var _ = Describe("Gateway", func() {
 
 	})
 
+	Describe("ListPaginatedResources", func() {
+		type thing struct {
+			Name string `json:"name"`
+		}
+
+		var (
+			apiServer    *httptest.Server
+			totalPages   int
+			failingPage  string
+			nextURLs     map[string]string
+			expiredToken bool
+
+			lock      sync.Mutex
+			requested []string
+			inFlight  int
+			maxFlight int
+		)
+
+		BeforeEach(func() {
+			totalPages = 5
+			failingPage = ""
+			nextURLs = nil
+			expiredToken = false
+			requested = []string{}
+			inFlight = 0
+			maxFlight = 0
+
+			apiServer = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				if expiredToken && request.Header.Get("Authorization") != "bearer new-access-token" {
+					writer.WriteHeader(http.StatusUnauthorized)
+					fmt.Fprintln(writer, `{"code": 1000, "description": "Invalid Auth Token"}`)
+					return
+				}
+
+				page := request.URL.Query().Get("page")
+				if page == "" {
+					page = "1"
+				}
+
+				lock.Lock()
+				requested = append(requested, page)
+				inFlight++
+				if inFlight > maxFlight {
+					maxFlight = inFlight
+				}
+				lock.Unlock()
+
+				defer func() {
+					lock.Lock()
+					inFlight--
+					lock.Unlock()
+				}()
+
+				if page != "1" {
+					time.Sleep(20 * time.Millisecond)
+				}
+
+				if page == failingPage {
+					writer.WriteHeader(http.StatusInternalServerError)
+					fmt.Fprintln(writer, `{"code": 10001, "description": "page failed"}`)
+					return
+				}
+
+				nextURL := "null"
+				if nextURLs != nil {
+					if url, ok := nextURLs[request.URL.Path]; ok {
+						nextURL = fmt.Sprintf("%q", url)
+					}
+				} else if number, _ := strconv.Atoi(page); number < totalPages {
+					nextURL = fmt.Sprintf(`"/v2/things?order-direction=asc&page=%d&results-per-page=2"`, number+1)
+				}
+
+				fmt.Fprintf(writer, `{
+					"total_pages": %d,
+					"next_url": %s,
+					"resources": [{"name": "thing-%s-a"}, {"name": "thing-%s-b"}]
+				}`, totalPages, nextURL, page, page)
+			}))
+
+			ccGateway.PageConcurrency = 2
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+		})
+
+		listNames := func(path string, limit int) ([]string, error) {
+			names := []string{}
+			err := ccGateway.ListPaginatedResources(apiServer.URL, path, thing{}, func(resource interface{}) bool {
+				names = append(names, resource.(thing).Name)
+				return len(names) != limit
+			})
+			return names, err
+		}
+
+		It("passes the resources of every page in order", func() {
+			names, err := listNames("/v2/things?order-direction=asc&results-per-page=2", 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(Equal([]string{
+				"thing-1-a", "thing-1-b",
+				"thing-2-a", "thing-2-b",
+				"thing-3-a", "thing-3-b",
+				"thing-4-a", "thing-4-b",
+				"thing-5-a", "thing-5-b",
+			}))
+			Expect(requested).To(ConsistOf("1", "2", "3", "4", "5"))
+		})
+
+		It("fetches no more than PageConcurrency pages at once", func() {
+			_, err := listNames("/v2/things?order-direction=asc&results-per-page=2", 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(maxFlight).To(BeNumerically("<=", 2))
+		})
+
+		It("stops fetching pages when the callback returns false", func() {
+			totalPages = 20
+
+			names, err := listNames("/v2/things?order-direction=asc&results-per-page=2", 3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(Equal([]string{"thing-1-a", "thing-1-b", "thing-2-a"}))
+
+			lock.Lock()
+			defer lock.Unlock()
+			Expect(len(requested)).To(BeNumerically("<", 20))
+		})
+
+		It("returns the error of a failed page", func() {
+			failingPage = "3"
+
+			names, err := listNames("/v2/things?order-direction=asc&results-per-page=2", 0)
+			Expect(err).To(MatchError(ContainSubstring("page failed")))
+			Expect(names).To(Equal([]string{"thing-1-a", "thing-1-b", "thing-2-a", "thing-2-b"}))
+		})
+
+		It("refreshes an expired token once for all the pages", func() {
+			expiredToken = true
+			refresher := &countingTokenRefresher{token: "bearer new-access-token"}
+			ccGateway.SetTokenRefresher(refresher)
+
+			names, err := listNames("/v2/things?order-direction=asc&results-per-page=2", 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(HaveLen(10))
+			Expect(refresher.count()).To(Equal(1))
+		})
+
+		It("follows next_url one page at a time when it does not number the pages", func() {
+			totalPages = 3
+			nextURLs = map[string]string{
+				"/v2/things":         "/v2/things/after-1",
+				"/v2/things/after-1": "/v2/things/after-2",
+			}
+
+			names, err := listNames("/v2/things", 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(HaveLen(6))
+			Expect(maxFlight).To(Equal(1))
+		})
+	})
+
 	Describe("collecting warnings", func() {
 		var (
 			apiServer  *httptest.Server
@@ -688,3 +995,24 @@ func createAuthenticationRepository(apiServer *httptest.Server, authServer *http
 
 	return config, authenticator
 }
+
+// countingTokenRefresher hands out a fixed token and counts how often it was
+// asked to refresh.
+type countingTokenRefresher struct {
+	token     string
+	mutex     sync.Mutex
+	refreshes int
+}
+
+func (refresher *countingTokenRefresher) RefreshAuthToken() (string, error) {
+	refresher.mutex.Lock()
+	defer refresher.mutex.Unlock()
+	refresher.refreshes++
+	return refresher.token, nil
+}
+
+func (refresher *countingTokenRefresher) count() int {
+	refresher.mutex.Lock()
+	defer refresher.mutex.Unlock()
+	return refresher.refreshes
+}
@@ -92,12 +92,12 @@ func WrapNetworkErrors(host string, err error) error {
 			return errors.NewInvalidSSLCert(host, "")
 		case *net.OpError:
 			if typedInnerErr.Op == "dial" {
-				return fmt.Errorf("%s: %s\n%s", T("Error performing request"), err.Error(), T("TIP: If you are behind a firewall and require an HTTP proxy, verify the https_proxy environment variable is correctly set. Else, check your network connection."))
+				return errors.NewNetworkError(fmt.Sprintf("%s: %s\n%s", T("Error performing request"), err.Error(), T("TIP: If you are behind a firewall and require an HTTP proxy, verify the https_proxy environment variable is correctly set. Else, check your network connection.")), err)
 			}
 		}
 	}
 
-	return fmt.Errorf("%s: %s", T("Error performing request"), err.Error())
+	return errors.NewNetworkError(fmt.Sprintf("%s: %s", T("Error performing request"), err.Error()), err)
 }
 
 func getBaseDomain(host string) string {
@@ -143,6 +143,7 @@ var _ = Describe("HTTP Client", func() {
 			underlyingErr := syscall.Errno(61)
 			err := WrapNetworkErrors("example.com", &url.Error{Err: &net.OpError{Err: underlyingErr}})
 			Expect(err.Error()).To(ContainSubstring("Error performing request"))
+			Expect(err).To(BeAssignableToTypeOf(&errors.NetworkError{}))
 		})
 
 		It("wraps other errors in a generic error type", func() {
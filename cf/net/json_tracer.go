@@ -0,0 +1,239 @@
+package net
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/errors"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/trace"
+)
+
+// JSONTracer prints the HTTP exchanges of gateways as JSON lines, for
+// CF_TRACE_FORMAT=json: one "request" line per response or failed request,
+// a "retry" line before a request is repeated, and a "summary" line with the
+// number of requests and the time spent per endpoint.
+type JSONTracer struct {
+	printer trace.Printer
+	clock   func() time.Time
+
+	mutex     sync.Mutex
+	started   map[*http.Request]time.Time
+	endpoints map[string]*EndpointSummary
+	requests  int
+	duration  time.Duration
+}
+
+type TracedExchange struct {
+	Type           string      `json:"type"`
+	Time           string      `json:"time"`
+	Method         string      `json:"method"`
+	URL            string      `json:"url"`
+	Status         int         `json:"status,omitempty"`
+	DurationMS     float64     `json:"duration_ms"`
+	RequestID      string      `json:"request_id,omitempty"`
+	RequestHeader  http.Header `json:"request_headers,omitempty"`
+	RequestBody    string      `json:"request_body,omitempty"`
+	ResponseHeader http.Header `json:"response_headers,omitempty"`
+	ResponseBody   string      `json:"response_body,omitempty"`
+	Error          string      `json:"error,omitempty"`
+}
+
+type TracedRetry struct {
+	Type    string  `json:"type"`
+	Time    string  `json:"time"`
+	Method  string  `json:"method"`
+	URL     string  `json:"url"`
+	Attempt int     `json:"attempt"`
+	DelayMS float64 `json:"delay_ms"`
+	Reason  string  `json:"reason"`
+}
+
+type TraceSummary struct {
+	Type       string             `json:"type"`
+	Requests   int                `json:"requests"`
+	DurationMS float64            `json:"duration_ms"`
+	Endpoints  []*EndpointSummary `json:"endpoints"`
+}
+
+type EndpointSummary struct {
+	Endpoint   string  `json:"endpoint"`
+	Requests   int     `json:"requests"`
+	DurationMS float64 `json:"duration_ms"`
+
+	duration time.Duration
+}
+
+// NewTracer returns a JSONTracer for CF_TRACE_FORMAT=json, and nil for the
+// default text format.
+func NewTracer(envFormat string, printer trace.Printer, clock func() time.Time) (*JSONTracer, error) {
+	switch strings.ToLower(envFormat) {
+	case "", "text":
+		return nil, nil
+	case "json":
+		return NewJSONTracer(printer, clock), nil
+	}
+
+	return nil, errors.New(T("Invalid CF_TRACE_FORMAT {{.Format}}. Use text or json.",
+		map[string]interface{}{"Format": envFormat}))
+}
+
+func NewJSONTracer(printer trace.Printer, clock func() time.Time) *JSONTracer {
+	return &JSONTracer{
+		printer:   printer,
+		clock:     clock,
+		started:   map[*http.Request]time.Time{},
+		endpoints: map[string]*EndpointSummary{},
+	}
+}
+
+func (tracer *JSONTracer) DumpRequest(request *http.Request) {
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+
+	tracer.started[request] = tracer.clock()
+}
+
+func (tracer *JSONTracer) DumpResponse(response *http.Response) {
+	exchange := tracer.exchange(response.Request)
+	exchange.Status = response.StatusCode
+	exchange.ResponseHeader = sanitizeHeader(response.Header)
+
+	if requestID := response.Header.Get("X-Vcap-Request-Id"); requestID != "" {
+		exchange.RequestID = requestID
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err == nil {
+		exchange.ResponseBody = trace.Sanitize(string(body))
+	}
+
+	tracer.print(exchange)
+}
+
+// DumpError traces a request that got no response.
+func (tracer *JSONTracer) DumpError(request *http.Request, err error) {
+	exchange := tracer.exchange(request)
+	exchange.Error = err.Error()
+
+	tracer.print(exchange)
+}
+
+func (tracer *JSONTracer) DumpRetry(request *http.Request, attempt int, delay time.Duration, reason string) {
+	tracer.print(TracedRetry{
+		Type:    "retry",
+		Time:    tracer.clock().Format(time.RFC3339),
+		Method:  request.Method,
+		URL:     request.URL.String(),
+		Attempt: attempt,
+		DelayMS: milliseconds(delay),
+		Reason:  reason,
+	})
+}
+
+// PrintSummary prints the number of requests traced and the time they took,
+// per endpoint with the slowest first.
+func (tracer *JSONTracer) PrintSummary() {
+	tracer.mutex.Lock()
+	summary := TraceSummary{
+		Type:       "summary",
+		Requests:   tracer.requests,
+		DurationMS: milliseconds(tracer.duration),
+		Endpoints:  []*EndpointSummary{},
+	}
+	for _, endpoint := range tracer.endpoints {
+		endpoint.DurationMS = milliseconds(endpoint.duration)
+		summary.Endpoints = append(summary.Endpoints, endpoint)
+	}
+	tracer.mutex.Unlock()
+
+	sort.Sort(endpointsByDuration(summary.Endpoints))
+
+	tracer.print(summary)
+}
+
+// exchange returns the trace of request, and counts the time since it was
+// sent towards its endpoint.
+func (tracer *JSONTracer) exchange(request *http.Request) TracedExchange {
+	now := tracer.clock()
+
+	tracer.mutex.Lock()
+	started, found := tracer.started[request]
+	if !found {
+		started = now
+	}
+	delete(tracer.started, request)
+
+	duration := now.Sub(started)
+	endpoint := endpointName(request)
+	if tracer.endpoints[endpoint] == nil {
+		tracer.endpoints[endpoint] = &EndpointSummary{Endpoint: endpoint}
+	}
+	tracer.endpoints[endpoint].Requests++
+	tracer.endpoints[endpoint].duration += duration
+	tracer.requests++
+	tracer.duration += duration
+	tracer.mutex.Unlock()
+
+	recorded := recordRequest(request)
+	return TracedExchange{
+		Type:          "request",
+		Time:          started.Format(time.RFC3339),
+		Method:        recorded.Method,
+		URL:           recorded.URL,
+		DurationMS:    milliseconds(duration),
+		RequestID:     request.Header.Get("X-Vcap-Request-Id"),
+		RequestHeader: recorded.Header,
+		RequestBody:   recorded.Body,
+	}
+}
+
+func (tracer *JSONTracer) print(line interface{}) {
+	data, err := json.Marshal(line)
+	if err != nil {
+		return
+	}
+	tracer.printer.Println(string(data))
+}
+
+var guidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// endpointName names the endpoint of request by its method, host and path,
+// with GUIDs in the path replaced so that requests for different entities
+// count towards the same endpoint.
+func endpointName(request *http.Request) string {
+	segments := strings.Split(request.URL.Path, "/")
+	for i, segment := range segments {
+		if guidPattern.MatchString(segment) {
+			segments[i] = ":guid"
+		}
+	}
+
+	return request.Method + " " + request.URL.Host + strings.Join(segments, "/")
+}
+
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
+}
+
+type endpointsByDuration []*EndpointSummary
+
+func (endpoints endpointsByDuration) Len() int { return len(endpoints) }
+func (endpoints endpointsByDuration) Swap(i, j int) {
+	endpoints[i], endpoints[j] = endpoints[j], endpoints[i]
+}
+func (endpoints endpointsByDuration) Less(i, j int) bool {
+	if endpoints[i].duration == endpoints[j].duration {
+		return endpoints[i].Endpoint < endpoints[j].Endpoint
+	}
+	return endpoints[i].duration > endpoints[j].duration
+}
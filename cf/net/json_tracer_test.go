@@ -0,0 +1,159 @@
+package net_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/cloudfoundry/cli/cf/net"
+	"github.com/cloudfoundry/cli/cf/terminal/terminalfakes"
+	"github.com/cloudfoundry/cli/cf/trace/tracefakes"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSONTracer", func() {
+	var (
+		apiServer *httptest.Server
+		printer   *tracefakes.FakePrinter
+		now       time.Time
+		tracer    *JSONTracer
+		gateway   Gateway
+	)
+
+	BeforeEach(func() {
+		apiServer = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			now = now.Add(250 * time.Millisecond)
+
+			writer.Header().Set("X-Vcap-Request-Id", "request-id")
+			switch request.URL.Path {
+			case "/v2/unavailable":
+				writer.WriteHeader(http.StatusServiceUnavailable)
+			default:
+				fmt.Fprint(writer, `{"access_token": "secret-token"}`)
+			}
+		}))
+
+		printer = new(tracefakes.FakePrinter)
+		now = time.Date(2016, 10, 16, 12, 0, 0, 0, time.UTC)
+		tracer = NewJSONTracer(printer, func() time.Time { return now })
+
+		config := testconfig.NewRepository()
+		config.SetAPIEndpoint(apiServer.URL)
+		gateway = NewCloudControllerGateway(config, time.Now, new(terminalfakes.FakeUI), printer, "")
+		gateway.Tracer = tracer
+		gateway.RetryPolicy.Sleep = func(time.Duration) {}
+	})
+
+	AfterEach(func() {
+		apiServer.Close()
+	})
+
+	tracedLines := func() []map[string]interface{} {
+		lines := []map[string]interface{}{}
+		for i := 0; i < printer.PrintlnCallCount(); i++ {
+			args := printer.PrintlnArgsForCall(i)
+			Expect(args).To(HaveLen(1))
+
+			line := map[string]interface{}{}
+			err := json.Unmarshal([]byte(args[0].(string)), &line)
+			Expect(err).NotTo(HaveOccurred())
+			lines = append(lines, line)
+		}
+		return lines
+	}
+
+	get := func(path string) error {
+		request, err := gateway.NewRequest("GET", apiServer.URL+path, "BEARER my-access-token", nil)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = gateway.PerformRequest(request)
+		return err
+	}
+
+	It("traces each request as one sanitized JSON line with its duration", func() {
+		err := get("/v2/apps/7b41e8e6-3a49-4ab1-a0a4-8bee27af2ba8")
+		Expect(err).NotTo(HaveOccurred())
+
+		lines := tracedLines()
+		Expect(lines).To(HaveLen(1))
+		Expect(lines[0]["type"]).To(Equal("request"))
+		Expect(lines[0]["time"]).To(Equal("2016-10-16T12:00:00Z"))
+		Expect(lines[0]["method"]).To(Equal("GET"))
+		Expect(lines[0]["url"]).To(Equal(apiServer.URL + "/v2/apps/7b41e8e6-3a49-4ab1-a0a4-8bee27af2ba8"))
+		Expect(lines[0]["status"]).To(Equal(float64(200)))
+		Expect(lines[0]["duration_ms"]).To(Equal(float64(250)))
+		Expect(lines[0]["request_id"]).To(Equal("request-id"))
+		Expect(lines[0]["request_headers"]).To(HaveKeyWithValue("Authorization", []interface{}{"[PRIVATE DATA HIDDEN]"}))
+		Expect(lines[0]["response_body"]).To(ContainSubstring("[PRIVATE DATA HIDDEN]"))
+		Expect(lines[0]["response_body"]).NotTo(ContainSubstring("secret-token"))
+	})
+
+	It("traces retries", func() {
+		gateway.RetryPolicy.MaxAttempts = 2
+
+		err := get("/v2/unavailable")
+		Expect(err).To(HaveOccurred())
+
+		lines := tracedLines()
+		Expect(lines).To(HaveLen(3))
+		Expect(lines[0]["status"]).To(Equal(float64(503)))
+		Expect(lines[1]["type"]).To(Equal("retry"))
+		Expect(lines[1]["attempt"]).To(Equal(float64(2)))
+		Expect(lines[2]["status"]).To(Equal(float64(503)))
+	})
+
+	It("traces requests that get no response", func() {
+		apiServer.Close()
+		gateway.RetryPolicy.MaxAttempts = 1
+
+		err := get("/v2/apps")
+		Expect(err).To(HaveOccurred())
+
+		lines := tracedLines()
+		Expect(lines).To(HaveLen(1))
+		Expect(lines[0]["type"]).To(Equal("request"))
+		Expect(lines[0]).NotTo(HaveKey("status"))
+		Expect(lines[0]["error"]).NotTo(BeEmpty())
+	})
+
+	It("summarizes the requests and time per endpoint, slowest first", func() {
+		Expect(get("/v2/apps/7b41e8e6-3a49-4ab1-a0a4-8bee27af2ba8")).To(Succeed())
+		Expect(get("/v2/apps/0a3fcc84-8d9e-4c23-a1f3-7b5ab8e3e0a5")).To(Succeed())
+		Expect(get("/v2/info")).To(Succeed())
+
+		tracer.PrintSummary()
+
+		lines := tracedLines()
+		Expect(lines).To(HaveLen(4))
+
+		host := apiServer.Listener.Addr().String()
+		Expect(lines[3]).To(Equal(map[string]interface{}{
+			"type":        "summary",
+			"requests":    float64(3),
+			"duration_ms": float64(750),
+			"endpoints": []interface{}{
+				map[string]interface{}{"endpoint": "GET " + host + "/v2/apps/:guid", "requests": float64(2), "duration_ms": float64(500)},
+				map[string]interface{}{"endpoint": "GET " + host + "/v2/info", "requests": float64(1), "duration_ms": float64(250)},
+			},
+		}))
+	})
+
+	Describe("NewTracer", func() {
+		It("returns no tracer for the text format", func() {
+			Expect(NewTracer("", printer, time.Now)).To(BeNil())
+			Expect(NewTracer("text", printer, time.Now)).To(BeNil())
+		})
+
+		It("returns a tracer for the json format", func() {
+			Expect(NewTracer("JSON", printer, time.Now)).NotTo(BeNil())
+		})
+
+		It("returns an error for unknown formats", func() {
+			_, err := NewTracer("xml", printer, time.Now)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -13,6 +13,7 @@ func NewPaginatedResources(exampleResource interface{}) PaginatedResources {
 
 type PaginatedResources struct {
 	NextURL        string          `json:"next_url"`
+	TotalPages     int             `json:"total_pages"`
 	ResourcesBytes json.RawMessage `json:"resources"`
 	resourceType   reflect.Type
 }
@@ -0,0 +1,362 @@
+package net_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	. "github.com/cloudfoundry/cli/cf/net"
+	"github.com/cloudfoundry/cli/cf/terminal/terminalfakes"
+	"github.com/cloudfoundry/cli/cf/trace/tracefakes"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// socks5Server is a SOCKS5 server without authentication that records the
+// addresses it is asked to connect to.
+type socks5Server struct {
+	listener net.Listener
+
+	mutex     sync.Mutex
+	addresses []string
+}
+
+func newSOCKS5Server() *socks5Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+
+	server := &socks5Server{listener: listener}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (server *socks5Server) serve(conn net.Conn) {
+	defer conn.Close()
+
+	greeting := make([]byte, 2)
+	if _, err := io.ReadFull(conn, greeting); err != nil {
+		return
+	}
+	if _, err := io.ReadFull(conn, make([]byte, greeting[1])); err != nil {
+		return
+	}
+	conn.Write([]byte{5, 0})
+
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return
+	}
+
+	var host string
+	switch header[3] {
+	case 1:
+		ip := make([]byte, 4)
+		io.ReadFull(conn, ip)
+		host = net.IP(ip).String()
+	case 3:
+		length := make([]byte, 1)
+		io.ReadFull(conn, length)
+		name := make([]byte, length[0])
+		io.ReadFull(conn, name)
+		host = string(name)
+	}
+	port := make([]byte, 2)
+	io.ReadFull(conn, port)
+	address := net.JoinHostPort(host, strconv.Itoa(int(port[0])<<8|int(port[1])))
+
+	server.mutex.Lock()
+	server.addresses = append(server.addresses, address)
+	server.mutex.Unlock()
+
+	target, err := net.Dial("tcp", address)
+	if err != nil {
+		conn.Write([]byte{5, 5, 0, 1, 0, 0, 0, 0, 0, 0})
+		return
+	}
+	defer target.Close()
+	conn.Write([]byte{5, 0, 0, 1, 127, 0, 0, 1, 0, 0})
+
+	go io.Copy(target, conn)
+	io.Copy(conn, target)
+}
+
+func (server *socks5Server) Addresses() []string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return append([]string{}, server.addresses...)
+}
+
+var _ = Describe("Proxy", func() {
+	Describe("NewProxy", func() {
+		It("returns no proxy without a URL", func() {
+			proxy, err := NewProxy("", "example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proxy).To(BeNil())
+		})
+
+		It("defaults the port of the proxy", func() {
+			proxy, err := NewProxy("socks5://bastion", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proxy.URL.Host).To(Equal("bastion:1080"))
+
+			proxy, err = NewProxy("http://proxy", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proxy.URL.Host).To(Equal("proxy:80"))
+		})
+
+		It("returns an error for unsupported proxies", func() {
+			_, err := NewProxy("ftp://proxy:21", "")
+			Expect(err).To(HaveOccurred())
+
+			_, err = NewProxy("bastion:1080", "")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns an error for invalid CIDR ranges", func() {
+			_, err := NewProxy("socks5://bastion:1080", "10.0.0.0/33")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Bypasses", func() {
+		It("matches hosts, domains and CIDR ranges", func() {
+			proxy, err := NewProxy("socks5://bastion:1080", "localhost, .internal.example.com,example.org,10.0.0.0/8")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(proxy.Bypasses("localhost:8080")).To(BeTrue())
+			Expect(proxy.Bypasses("api.internal.example.com")).To(BeTrue())
+			Expect(proxy.Bypasses("internal.example.com")).To(BeTrue())
+			Expect(proxy.Bypasses("uaa.example.org:443")).To(BeTrue())
+			Expect(proxy.Bypasses("10.1.2.3:2222")).To(BeTrue())
+
+			Expect(proxy.Bypasses("api.example.com")).To(BeFalse())
+			Expect(proxy.Bypasses("notexample.org")).To(BeFalse())
+			Expect(proxy.Bypasses("11.1.2.3")).To(BeFalse())
+		})
+	})
+
+	Describe("Func", func() {
+		It("uses the environment without a proxy", func() {
+			var proxy *Proxy
+			request, _ := http.NewRequest("GET", "https://api.example.com", nil)
+			_, err := proxy.Func()(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the proxy unless the host bypasses it", func() {
+			proxy, err := NewProxy("socks5://bastion:1080", "uaa.example.com")
+			Expect(err).NotTo(HaveOccurred())
+
+			request, _ := http.NewRequest("GET", "https://api.example.com/v2/info", nil)
+			Expect(proxy.Func()(request)).To(Equal(proxy.URL))
+
+			request, _ = http.NewRequest("GET", "https://uaa.example.com/oauth/token", nil)
+			Expect(proxy.Func()(request)).To(BeNil())
+		})
+	})
+
+	Describe("connecting through proxies", func() {
+		var (
+			apiServer *httptest.Server
+			socks     *socks5Server
+			config    coreconfig.Repository
+		)
+
+		BeforeEach(func() {
+			apiServer = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				fmt.Fprint(writer, `{"api_version": "2.54.0"}`)
+			}))
+			socks = newSOCKS5Server()
+			config = testconfig.NewRepository()
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+			socks.listener.Close()
+		})
+
+		It("sends gateway requests through the SOCKS5 proxy of the target", func() {
+			config.SetProxy("socks5://"+socks.listener.Addr().String(), "")
+			gateway := NewCloudControllerGateway(config, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+
+			request, err := gateway.NewRequest("GET", apiServer.URL+"/v2/info", "", nil)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = gateway.PerformRequest(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(socks.Addresses()).To(ConsistOf(apiServer.Listener.Addr().String()))
+		})
+
+		It("dials through a SOCKS5 proxy", func() {
+			proxy, err := NewProxy("socks5://"+socks.listener.Addr().String(), "")
+			Expect(err).NotTo(HaveOccurred())
+
+			conn, err := proxy.Dialer(DefaultDialTimeout)("tcp", apiServer.Listener.Addr().String())
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			fmt.Fprint(conn, "GET /v2/info HTTP/1.0\r\n\r\n")
+			response, err := http.ReadResponse(bufio.NewReader(conn), nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("dials through an HTTP proxy with CONNECT and credentials", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			defer listener.Close()
+
+			authorizations := make(chan string, 1)
+			go func() {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				reader := bufio.NewReader(conn)
+				request, err := http.ReadRequest(reader)
+				if err != nil || request.Method != "CONNECT" {
+					return
+				}
+				authorizations <- request.Header.Get("Proxy-Authorization")
+
+				target, err := net.Dial("tcp", request.Host)
+				if err != nil {
+					return
+				}
+				defer target.Close()
+
+				fmt.Fprint(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+				go io.Copy(target, reader)
+				io.Copy(conn, target)
+			}()
+
+			proxy, err := NewProxy("http://user:secret@"+listener.Addr().String(), "")
+			Expect(err).NotTo(HaveOccurred())
+
+			conn, err := proxy.Dialer(DefaultDialTimeout)("tcp", apiServer.Listener.Addr().String())
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			fmt.Fprint(conn, "GET /v2/info HTTP/1.0\r\n\r\n")
+			response, err := http.ReadResponse(bufio.NewReader(conn), nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(authorizations).To(Receive(Equal("Basic dXNlcjpzZWNyZXQ=")))
+		})
+
+		It("gives up on proxies that do not answer within the dial timeout", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			defer listener.Close()
+
+			go func() {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				ioutil.ReadAll(conn)
+			}()
+
+			proxy, err := NewProxy("http://"+listener.Addr().String(), "")
+			Expect(err).NotTo(HaveOccurred())
+
+			started := time.Now()
+			_, err = proxy.Dialer(100*time.Millisecond)("tcp", apiServer.Listener.Addr().String())
+			Expect(err).To(HaveOccurred())
+			Expect(time.Since(started)).To(BeNumerically("<", DefaultDialTimeout))
+		})
+
+		It("dials directly to hosts that bypass the proxy", func() {
+			proxy, err := NewProxy("socks5://"+socks.listener.Addr().String(), "127.0.0.1")
+			Expect(err).NotTo(HaveOccurred())
+
+			conn, err := proxy.Dialer(DefaultDialTimeout)("tcp", apiServer.Listener.Addr().String())
+			Expect(err).NotTo(HaveOccurred())
+			conn.Close()
+
+			Expect(socks.Addresses()).To(BeEmpty())
+		})
+
+		Describe("ConnectFunc", func() {
+			It("bridges SOCKS5 proxies for the endpoints of the log consumers", func() {
+				proxy, err := NewProxy("socks5://"+socks.listener.Addr().String(), "")
+				Expect(err).NotTo(HaveOccurred())
+
+				request, _ := http.NewRequest("GET", apiServer.URL, nil)
+				bridgeURL, err := proxy.ConnectFunc(DefaultDialTimeout, apiServer.URL)(request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bridgeURL.Scheme).To(Equal("http"))
+				Expect(bridgeURL.Host).To(HavePrefix("127.0.0.1:"))
+
+				client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(bridgeURL)}}
+				response, err := client.Get(apiServer.URL + "/v2/info")
+				Expect(err).NotTo(HaveOccurred())
+				defer response.Body.Close()
+
+				body, _ := ioutil.ReadAll(response.Body)
+				Expect(string(body)).To(ContainSubstring("2.54.0"))
+				Expect(socks.Addresses()).To(ConsistOf(apiServer.Listener.Addr().String()))
+			})
+
+			It("refuses to bridge other hosts", func() {
+				proxy, err := NewProxy("socks5://"+socks.listener.Addr().String(), "")
+				Expect(err).NotTo(HaveOccurred())
+
+				request, _ := http.NewRequest("GET", "https://doppler.example.com", nil)
+				bridgeURL, err := proxy.ConnectFunc(DefaultDialTimeout, "https://doppler.example.com")(request)
+				Expect(err).NotTo(HaveOccurred())
+
+				client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(bridgeURL)}}
+				response, err := client.Get(apiServer.URL + "/v2/info")
+				Expect(err).NotTo(HaveOccurred())
+				response.Body.Close()
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(socks.Addresses()).To(BeEmpty())
+			})
+
+			It("stops the bridge when the proxy is closed", func() {
+				proxy, err := NewProxy("socks5://"+socks.listener.Addr().String(), "")
+				Expect(err).NotTo(HaveOccurred())
+
+				request, _ := http.NewRequest("GET", apiServer.URL, nil)
+				bridgeURL, err := proxy.ConnectFunc(DefaultDialTimeout, apiServer.URL)(request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(proxy.Close()).To(Succeed())
+
+				_, err = net.Dial("tcp", bridgeURL.Host)
+				Expect(err).To(HaveOccurred())
+				_, err = proxy.ConnectFunc(DefaultDialTimeout, apiServer.URL)(request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("leaves HTTP proxies without credentials to the consumers", func() {
+				proxy, err := NewProxy("http://proxy:3128", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				request, _ := http.NewRequest("GET", "https://doppler.example.com", nil)
+				Expect(proxy.ConnectFunc(DefaultDialTimeout, "https://doppler.example.com")(request)).To(Equal(proxy.URL))
+			})
+		})
+	})
+})
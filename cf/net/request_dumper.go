@@ -20,13 +20,25 @@ type RequestDumperInterface interface {
 
 type RequestDumper struct {
 	printer trace.Printer
+	tracer  *JSONTracer
 }
 
 func NewRequestDumper(printer trace.Printer) RequestDumper {
 	return RequestDumper{printer: printer}
 }
 
+// NewJSONRequestDumper dumps requests and responses as JSON lines through
+// tracer.
+func NewJSONRequestDumper(tracer *JSONTracer) RequestDumper {
+	return RequestDumper{tracer: tracer}
+}
+
 func (p RequestDumper) DumpRequest(req *http.Request) {
+	if p.tracer != nil {
+		p.tracer.DumpRequest(req)
+		return
+	}
+
 	shouldDisplayBody := !strings.Contains(req.Header.Get("Content-Type"), "multipart/form-data")
 	dumpedRequest, err := httputil.DumpRequest(req, shouldDisplayBody)
 	if err != nil {
@@ -40,6 +52,11 @@ func (p RequestDumper) DumpRequest(req *http.Request) {
 }
 
 func (p RequestDumper) DumpResponse(res *http.Response) {
+	if p.tracer != nil {
+		p.tracer.DumpResponse(res)
+		return
+	}
+
 	dumpedResponse, err := httputil.DumpResponse(res, true)
 	if err != nil {
 		p.printer.Printf(T("Error dumping response\n{{.Err}}\n", map[string]interface{}{"Err": err}))
@@ -0,0 +1,149 @@
+package net
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/errors"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+const (
+	DefaultRetryAttempts   = 3
+	DefaultRetryBackoff    = 500 * time.Millisecond
+	DefaultRetryMaxBackoff = 30 * time.Second
+)
+
+// RetryPolicy decides whether a gateway repeats a request that got no
+// response, or a 429, 502, 503 or 504 response, and how long it waits
+// first. Only requests with idempotent verbs, or marked SafeToRetry, are
+// repeated, and never when the server's certificate is not trusted.
+//
+// The wait doubles from InitialBackoff with each attempt, up to MaxBackoff,
+// and is jittered so that many clients do not retry in step. A Retry-After
+// header is waited for as given; if it asks for longer than MaxBackoff the
+// response is returned instead.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Sleep          func(time.Duration)
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    DefaultRetryAttempts,
+		InitialBackoff: DefaultRetryBackoff,
+		MaxBackoff:     DefaultRetryMaxBackoff,
+		Sleep:          time.Sleep,
+	}
+}
+
+// NewRetryPolicy returns the default policy with the number of attempts and
+// the initial backoff given by CF_RETRY_ATTEMPTS and CF_RETRY_BACKOFF, such
+// as 3 and 500ms, and an error for values that cannot be parsed.
+func NewRetryPolicy(envAttempts string, envBackoff string) (RetryPolicy, error) {
+	policy := DefaultRetryPolicy()
+
+	if envAttempts != "" {
+		attempts, err := strconv.Atoi(envAttempts)
+		if err != nil || attempts < 1 {
+			return policy, errors.New(T("Invalid CF_RETRY_ATTEMPTS {{.Attempts}}. Use a number of at least 1.",
+				map[string]interface{}{"Attempts": envAttempts}))
+		}
+		policy.MaxAttempts = attempts
+	}
+
+	if envBackoff != "" {
+		backoff, err := time.ParseDuration(envBackoff)
+		if err != nil || backoff < 0 {
+			return policy, errors.New(T("Invalid CF_RETRY_BACKOFF {{.Backoff}}. Use a duration such as 500ms or 2s.",
+				map[string]interface{}{"Backoff": envBackoff}))
+		}
+		policy.InitialBackoff = backoff
+	}
+
+	return policy, nil
+}
+
+func (policy RetryPolicy) allows(request *Request) bool {
+	if request.SafeToRetry {
+		return true
+	}
+
+	switch request.HTTPReq.Method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// retryDelay returns how long to wait before repeating a request that has
+// been sent attempt times, and false if it should not be repeated.
+func (policy RetryPolicy) retryDelay(attempt int, response *http.Response, err error) (time.Duration, bool) {
+	if attempt >= policy.MaxAttempts {
+		return 0, false
+	}
+
+	if response == nil {
+		if _, missing := err.(*CassetteMissError); err == nil || missing {
+			return 0, false
+		}
+
+		// a certificate that is not trusted will not be trusted next time
+		_, invalidCert := WrapNetworkErrors("", err).(*errors.InvalidSSLCert)
+		return policy.backoff(attempt), !invalidCert
+	}
+
+	switch response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+	default:
+		return 0, false
+	}
+
+	if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+		return retryAfter, retryAfter <= policy.MaxBackoff
+	}
+
+	return policy.backoff(attempt), true
+}
+
+// backoff waits between half and all of the exponential backoff, so that
+// clients that failed together spread their retries.
+func (policy RetryPolicy) backoff(attempt int) time.Duration {
+	backoff := policy.InitialBackoff
+	for i := 1; i < attempt && backoff < policy.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > policy.MaxBackoff {
+		backoff = policy.MaxBackoff
+	}
+
+	if backoff <= 1 {
+		return backoff
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)))
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(time.Now())
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
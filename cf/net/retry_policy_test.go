@@ -0,0 +1,43 @@
+package net_test
+
+import (
+	"time"
+
+	. "github.com/cloudfoundry/cli/cf/net"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RetryPolicy", func() {
+	Describe("NewRetryPolicy", func() {
+		It("uses the defaults when nothing is given", func() {
+			policy, err := NewRetryPolicy("", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy.MaxAttempts).To(Equal(DefaultRetryAttempts))
+			Expect(policy.InitialBackoff).To(Equal(DefaultRetryBackoff))
+			Expect(policy.MaxBackoff).To(Equal(DefaultRetryMaxBackoff))
+			Expect(policy.Sleep).NotTo(BeNil())
+		})
+
+		It("reads the number of attempts and the initial backoff", func() {
+			policy, err := NewRetryPolicy("5", "2s")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy.MaxAttempts).To(Equal(5))
+			Expect(policy.InitialBackoff).To(Equal(2 * time.Second))
+		})
+
+		It("returns an error for values that cannot be parsed", func() {
+			_, err := NewRetryPolicy("many", "")
+			Expect(err).To(MatchError("Invalid CF_RETRY_ATTEMPTS many. Use a number of at least 1."))
+
+			_, err = NewRetryPolicy("0", "")
+			Expect(err).To(HaveOccurred())
+
+			_, err = NewRetryPolicy("", "soon")
+			Expect(err).To(MatchError("Invalid CF_RETRY_BACKOFF soon. Use a duration such as 500ms or 2s."))
+
+			_, err = NewRetryPolicy("", "-1s")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -3,6 +3,7 @@ package net
 import (
 	"encoding/json"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
@@ -32,10 +33,14 @@ func NewRoutingAPIGateway(config coreconfig.Reader, clock func() time.Time, ui t
 		config:          config,
 		PollingThrottle: DefaultPollingThrottle,
 		warnings:        &[]string{},
+		warningsMutex:   &sync.Mutex{},
+		tokenRefresh:    &tokenRefresh{},
 		Clock:           clock,
 		ui:              ui,
 		logger:          logger,
 		PollingEnabled:  true,
 		DialTimeout:     dialTimeout(envDialTimeout),
+		PageConcurrency: DefaultPageConcurrency,
+		RetryPolicy:     DefaultRetryPolicy(),
 	}
 }
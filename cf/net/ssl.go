@@ -3,6 +3,11 @@ package net
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"io/ioutil"
+
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
+	. "github.com/cloudfoundry/cli/cf/i18n"
 )
 
 func NewTLSConfig(trustedCerts []tls.Certificate, disableSSL bool) (TLSConfig *tls.Config) {
@@ -23,3 +28,62 @@ func NewTLSConfig(trustedCerts []tls.Certificate, disableSSL bool) (TLSConfig *t
 
 	return
 }
+
+// TLSFiles name the PEM files set with cf api: certificate authorities to
+// trust besides the system's, and a client certificate and key for mutual
+// TLS. Any of them may be empty.
+type TLSFiles struct {
+	CACert     string
+	ClientCert string
+	ClientKey  string
+}
+
+func TLSFilesFromConfig(config coreconfig.Reader) TLSFiles {
+	return TLSFiles{
+		CACert:     config.CACertFile(),
+		ClientCert: config.ClientCertFile(),
+		ClientKey:  config.ClientKeyFile(),
+	}
+}
+
+// NewTLSConfigWithFiles is NewTLSConfig that also trusts the certificate
+// authorities in files.CACert and presents the client certificate in
+// files.ClientCert and files.ClientKey.
+func NewTLSConfigWithFiles(trustedCerts []tls.Certificate, disableSSL bool, files TLSFiles) (*tls.Config, error) {
+	config := NewTLSConfig(trustedCerts, disableSSL)
+
+	if files.CACert != "" {
+		pemCerts, err := ioutil.ReadFile(files.CACert)
+		if err != nil {
+			return nil, errors.New(T("Error reading CA certificate {{.Path}}: {{.Err}}",
+				map[string]interface{}{"Path": files.CACert, "Err": err.Error()}))
+		}
+
+		if config.RootCAs == nil {
+			config.RootCAs, err = x509.SystemCertPool()
+			if err != nil {
+				config.RootCAs = x509.NewCertPool()
+			}
+		}
+
+		if !config.RootCAs.AppendCertsFromPEM(pemCerts) {
+			return nil, errors.New(T("No PEM certificates found in CA certificate {{.Path}}",
+				map[string]interface{}{"Path": files.CACert}))
+		}
+	}
+
+	if files.ClientCert != "" || files.ClientKey != "" {
+		if files.ClientCert == "" || files.ClientKey == "" {
+			return nil, errors.New(T("A client certificate needs both a certificate and a key"))
+		}
+
+		certificate, err := tls.LoadX509KeyPair(files.ClientCert, files.ClientKey)
+		if err != nil {
+			return nil, errors.New(T("Error loading client certificate {{.Path}}: {{.Err}}",
+				map[string]interface{}{"Path": files.ClientCert, "Err": err.Error()}))
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
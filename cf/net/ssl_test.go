@@ -0,0 +1,138 @@
+package net_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	. "github.com/cloudfoundry/cli/cf/net"
+	"github.com/cloudfoundry/cli/cf/terminal/terminalfakes"
+	"github.com/cloudfoundry/cli/cf/trace/tracefakes"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TLS files", func() {
+	var (
+		dir       string
+		apiServer *httptest.Server
+		config    coreconfig.Repository
+		gateway   Gateway
+
+		caCertFile     string
+		clientCertFile string
+		clientKeyFile  string
+	)
+
+	writePEM := func(name string, blockType string, bytes []byte) string {
+		path := filepath.Join(dir, name)
+		err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bytes}), 0600)
+		Expect(err).NotTo(HaveOccurred())
+		return path
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "tls-files")
+		Expect(err).NotTo(HaveOccurred())
+
+		apiServer = httptest.NewUnstartedServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			fmt.Fprintf(writer, `{"client_certificates": %d}`, len(request.TLS.PeerCertificates))
+		}))
+		apiServer.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+		apiServer.StartTLS()
+
+		serverCert := apiServer.TLS.Certificates[0]
+		caCertFile = writePEM("ca.pem", "CERTIFICATE", serverCert.Certificate[0])
+		clientCertFile = writePEM("client.crt", "CERTIFICATE", serverCert.Certificate[0])
+		keyBytes, err := x509.MarshalPKCS8PrivateKey(serverCert.PrivateKey)
+		Expect(err).NotTo(HaveOccurred())
+		clientKeyFile = writePEM("client.key", "PRIVATE KEY", keyBytes)
+
+		config = testconfig.NewRepository()
+		gateway = NewCloudControllerGateway(config, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+		gateway.RetryPolicy.MaxAttempts = 1
+	})
+
+	AfterEach(func() {
+		apiServer.Close()
+		os.RemoveAll(dir)
+	})
+
+	get := func() (string, error) {
+		request, err := gateway.NewRequest("GET", apiServer.URL+"/v2/info", "", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		body, _, err := gateway.PerformRequestForTextResponse(request)
+		return body, err
+	}
+
+	It("does not trust the server without its certificate authority", func() {
+		_, err := get()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("trusts the certificate authorities in the CA certificate file", func() {
+		config.SetCACertFile(caCertFile)
+
+		body, err := get()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(MatchJSON(`{"client_certificates": 0}`))
+	})
+
+	It("presents the client certificate", func() {
+		config.SetCACertFile(caCertFile)
+		config.SetClientCertificate(clientCertFile, clientKeyFile)
+
+		body, err := get()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(MatchJSON(`{"client_certificates": 1}`))
+	})
+
+	It("fails requests when the CA certificate file cannot be read", func() {
+		config.SetCACertFile(filepath.Join(dir, "missing.pem"))
+
+		_, err := get()
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("NewTLSConfigWithFiles", func() {
+		It("adds the certificate authorities to the system's", func() {
+			tlsConfig, err := NewTLSConfigWithFiles(nil, false, TLSFiles{CACert: caCertFile})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tlsConfig.RootCAs).NotTo(BeNil())
+			Expect(tlsConfig.Certificates).To(BeEmpty())
+		})
+
+		It("returns an error for files without certificates", func() {
+			notPEM := filepath.Join(dir, "not.pem")
+			Expect(ioutil.WriteFile(notPEM, []byte("not a certificate"), 0600)).To(Succeed())
+
+			_, err := NewTLSConfigWithFiles(nil, false, TLSFiles{CACert: notPEM})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("loads the client certificate and key", func() {
+			tlsConfig, err := NewTLSConfigWithFiles(nil, false, TLSFiles{ClientCert: clientCertFile, ClientKey: clientKeyFile})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tlsConfig.Certificates).To(HaveLen(1))
+		})
+
+		It("returns an error when only the client certificate or its key is given", func() {
+			_, err := NewTLSConfigWithFiles(nil, false, TLSFiles{ClientCert: clientCertFile})
+			Expect(err).To(HaveOccurred())
+
+			_, err = NewTLSConfigWithFiles(nil, false, TLSFiles{ClientKey: clientKeyFile})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -2,6 +2,7 @@ package net
 
 import (
 	"encoding/json"
+	"sync"
 	"time"
 
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
@@ -32,10 +33,14 @@ func NewUAAGateway(config coreconfig.Reader, ui terminal.UI, logger trace.Printe
 		config:          config,
 		PollingThrottle: DefaultPollingThrottle,
 		warnings:        &[]string{},
+		warningsMutex:   &sync.Mutex{},
+		tokenRefresh:    &tokenRefresh{},
 		Clock:           time.Now,
 		ui:              ui,
 		logger:          logger,
 		PollingEnabled:  false,
 		DialTimeout:     dialTimeout(envDialTimeout),
+		PageConcurrency: DefaultPageConcurrency,
+		RetryPolicy:     DefaultRetryPolicy(),
 	}
 }
@@ -0,0 +1,100 @@
+package records
+
+import (
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/models"
+)
+
+// App is printed for each app by `cf apps`.
+type App struct {
+	Name             string   `json:"name"`
+	GUID             string   `json:"guid"`
+	State            string   `json:"state"`
+	Instances        int      `json:"instances"`
+	RunningInstances int      `json:"running_instances"`
+	Memory           int64    `json:"memory"`
+	Disk             int64    `json:"disk"`
+	URLs             []string `json:"urls"`
+}
+
+// AppDetail is printed by `cf app`.
+type AppDetail struct {
+	App
+	Stack           string        `json:"stack"`
+	Buildpack       string        `json:"buildpack"`
+	LastUploaded    *time.Time    `json:"last_uploaded"`
+	InstanceDetails []AppInstance `json:"instance_details"`
+}
+
+// AppInstance is the health of one instance of an app. Usage and quotas are
+// in bytes, cpu is a fraction of one core.
+type AppInstance struct {
+	Index       int       `json:"index"`
+	State       string    `json:"state"`
+	Since       time.Time `json:"since"`
+	CPU         float64   `json:"cpu"`
+	MemoryUsage int64     `json:"memory_usage"`
+	MemoryQuota int64     `json:"memory_quota"`
+	DiskUsage   int64     `json:"disk_usage"`
+	DiskQuota   int64     `json:"disk_quota"`
+	Details     string    `json:"details"`
+}
+
+func NewApp(app models.Application) App {
+	urls := []string{}
+	for _, route := range app.Routes {
+		urls = append(urls, route.URL())
+	}
+
+	return App{
+		Name:             app.Name,
+		GUID:             app.GUID,
+		State:            app.State,
+		Instances:        app.InstanceCount,
+		RunningInstances: app.RunningInstances,
+		Memory:           app.Memory,
+		Disk:             app.DiskQuota,
+		URLs:             urls,
+	}
+}
+
+func NewApps(apps []models.Application) []App {
+	records := []App{}
+	for _, app := range apps {
+		records = append(records, NewApp(app))
+	}
+	return records
+}
+
+// NewAppDetail describes app, with the instances that were found. An unknown
+// stack or buildpack is left empty.
+func NewAppDetail(app models.Application, stack string, instances []models.AppInstanceFields) AppDetail {
+	buildpack := app.Buildpack
+	if buildpack == "" {
+		buildpack = app.DetectedBuildpack
+	}
+
+	details := []AppInstance{}
+	for index, instance := range instances {
+		details = append(details, AppInstance{
+			Index:       index,
+			State:       string(instance.State),
+			Since:       instance.Since,
+			CPU:         instance.CPUUsage,
+			MemoryUsage: instance.MemUsage,
+			MemoryQuota: instance.MemQuota,
+			DiskUsage:   instance.DiskUsage,
+			DiskQuota:   instance.DiskQuota,
+			Details:     instance.Details,
+		})
+	}
+
+	return AppDetail{
+		App:             NewApp(app),
+		Stack:           stack,
+		Buildpack:       buildpack,
+		LastUploaded:    app.PackageUpdatedAt,
+		InstanceDetails: details,
+	}
+}
@@ -0,0 +1,99 @@
+package records
+
+import (
+	"github.com/cloudfoundry/cli/cf/api/resources"
+	"github.com/cloudfoundry/cli/cf/models"
+)
+
+// Org is printed for each org by `cf orgs`.
+type Org struct {
+	Name string `json:"name"`
+	GUID string `json:"guid"`
+}
+
+// OrgDetail is printed by `cf org`.
+type OrgDetail struct {
+	Org
+	Quota       Quota    `json:"quota"`
+	Domains     []string `json:"domains"`
+	Spaces      []string `json:"spaces"`
+	SpaceQuotas []string `json:"space_quotas"`
+}
+
+// Quota is the org or space quota of an org or space. ReservedRoutePorts is
+// 0 when the API does not report it.
+type Quota struct {
+	Name                string `json:"name"`
+	MemoryLimit         int64  `json:"memory_limit"`
+	InstanceMemoryLimit int64  `json:"instance_memory_limit"`
+	Routes              int    `json:"routes"`
+	Services            int    `json:"services"`
+	PaidServices        bool   `json:"paid_services"`
+	AppInstanceLimit    int    `json:"app_instance_limit"`
+	ReservedRoutePorts  int64  `json:"reserved_route_ports"`
+}
+
+func NewOrgs(orgs []models.Organization) []Org {
+	records := []Org{}
+	for _, org := range orgs {
+		records = append(records, Org{Name: org.Name, GUID: org.GUID})
+	}
+	return records
+}
+
+func NewOrgDetail(org models.Organization) OrgDetail {
+	record := OrgDetail{
+		Org:         Org{Name: org.Name, GUID: org.GUID},
+		Quota:       NewOrgQuota(org.QuotaDefinition),
+		Domains:     []string{},
+		Spaces:      []string{},
+		SpaceQuotas: []string{},
+	}
+
+	for _, domain := range org.Domains {
+		record.Domains = append(record.Domains, domain.Name)
+	}
+	for _, space := range org.Spaces {
+		record.Spaces = append(record.Spaces, space.Name)
+	}
+	for _, spaceQuota := range org.SpaceQuotas {
+		record.SpaceQuotas = append(record.SpaceQuotas, spaceQuota.Name)
+	}
+	return record
+}
+
+func NewOrgQuota(quota models.QuotaFields) Quota {
+	reservedRoutePorts, _ := quota.ReservedRoutePorts.Int64()
+	if string(quota.ReservedRoutePorts) == resources.UnlimitedReservedRoutePorts {
+		reservedRoutePorts = Unlimited
+	}
+
+	return Quota{
+		Name:                quota.Name,
+		MemoryLimit:         quota.MemoryLimit,
+		InstanceMemoryLimit: quota.InstanceMemoryLimit,
+		Routes:              quota.RoutesLimit,
+		Services:            quota.ServicesLimit,
+		PaidServices:        quota.NonBasicServicesAllowed,
+		AppInstanceLimit:    quota.AppInstanceLimit,
+		ReservedRoutePorts:  reservedRoutePorts,
+	}
+}
+
+func NewSpaceQuota(quota models.SpaceQuota) Quota {
+	reservedRoutePorts, _ := quota.ReservedRoutePortsLimit.Int64()
+	if string(quota.ReservedRoutePortsLimit) == resources.UnlimitedReservedRoutePorts {
+		reservedRoutePorts = Unlimited
+	}
+
+	return Quota{
+		Name:                quota.Name,
+		MemoryLimit:         quota.MemoryLimit,
+		InstanceMemoryLimit: quota.InstanceMemoryLimit,
+		Routes:              quota.RoutesLimit,
+		Services:            quota.ServicesLimit,
+		PaidServices:        quota.NonBasicServicesAllowed,
+		AppInstanceLimit:    quota.AppInstanceLimit,
+		ReservedRoutePorts:  reservedRoutePorts,
+	}
+}
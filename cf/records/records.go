@@ -0,0 +1,34 @@
+// Package records defines what list and show commands print with
+// --output json, yaml or csv. Records are built from the models the commands
+// already fetch; their field names are part of the CLI's interface and, unlike
+// table headers, are neither translated nor reworded between releases.
+//
+// Sizes are in megabytes unless the field name says otherwise, and a limit of
+// -1 means unlimited.
+//
+// Templates given with --format see the Go field names of these types, as in
+// '{{.Name}} {{.State}}'; JSONPath templates given with --jsonpath see the
+// JSON names, as in '{[*].name}'.
+package records
+
+import "github.com/cloudfoundry/cli/cf/models"
+
+// Unlimited is the value of a limit that is not enforced.
+const Unlimited = -1
+
+var roleNames = map[models.Role]string{
+	models.RoleOrgUser:        "org_user",
+	models.RoleOrgManager:     "org_manager",
+	models.RoleBillingManager: "billing_manager",
+	models.RoleOrgAuditor:     "org_auditor",
+	models.RoleSpaceManager:   "space_manager",
+	models.RoleSpaceDeveloper: "space_developer",
+	models.RoleSpaceAuditor:   "space_auditor",
+}
+
+func names(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,91 @@
+package records_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/models"
+	. "github.com/cloudfoundry/cli/cf/records"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Records", func() {
+	Describe("NewAppDetail", func() {
+		It("describes the app and its instances", func() {
+			app := models.Application{}
+			app.Name = "my-app"
+			app.GUID = "my-app-guid"
+			app.State = "started"
+			app.InstanceCount = 2
+			app.RunningInstances = 1
+			app.Memory = 256
+			app.DiskQuota = 1024
+			app.DetectedBuildpack = "go_buildpack"
+			app.Routes = []models.RouteSummary{
+				{Host: "my-app", Domain: models.DomainFields{Name: "example.com"}},
+			}
+
+			since := time.Date(2016, time.April, 1, 10, 0, 0, 0, time.UTC)
+			instances := []models.AppInstanceFields{
+				{State: models.InstanceRunning, Since: since, CPUUsage: 0.5, MemUsage: 64, MemQuota: 256},
+			}
+
+			jsonBytes, err := json.Marshal(NewAppDetail(app, "cflinuxfs2", instances))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jsonBytes).To(MatchJSON(`{
+				"name": "my-app",
+				"guid": "my-app-guid",
+				"state": "started",
+				"instances": 2,
+				"running_instances": 1,
+				"memory": 256,
+				"disk": 1024,
+				"urls": ["my-app.example.com"],
+				"stack": "cflinuxfs2",
+				"buildpack": "go_buildpack",
+				"last_uploaded": null,
+				"instance_details": [{
+					"index": 0,
+					"state": "running",
+					"since": "2016-04-01T10:00:00Z",
+					"cpu": 0.5,
+					"memory_usage": 64,
+					"memory_quota": 256,
+					"disk_usage": 0,
+					"disk_quota": 0,
+					"details": ""
+				}]
+			}`))
+		})
+	})
+
+	Describe("NewOrgQuota", func() {
+		It("reports unlimited route ports as -1", func() {
+			quota := NewOrgQuota(models.QuotaFields{Name: "q", ReservedRoutePorts: "-1"})
+			Expect(quota.ReservedRoutePorts).To(Equal(int64(Unlimited)))
+		})
+
+		It("reports route ports the API does not report as 0", func() {
+			quota := NewOrgQuota(models.QuotaFields{Name: "q"})
+			Expect(quota.ReservedRoutePorts).To(BeZero())
+		})
+	})
+
+	Describe("NewUser", func() {
+		It("names the roles", func() {
+			user := NewUser(models.UserFields{Username: "alice", GUID: "alice-guid"},
+				[]models.Role{models.RoleOrgManager, models.RoleBillingManager})
+			Expect(user.Roles).To(Equal([]string{"org_manager", "billing_manager"}))
+		})
+	})
+
+	Describe("NewSpaceDetail", func() {
+		It("has no quota when the space has none", func() {
+			space := models.Space{}
+			space.Name = "my-space"
+			Expect(NewSpaceDetail(space, nil).Quota).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,38 @@
+package records
+
+import "github.com/cloudfoundry/cli/cf/models"
+
+// Route is printed for each route by `cf routes`. Port is 0 for HTTP routes,
+// and type is the router group type of the domain, empty for HTTP domains.
+type Route struct {
+	GUID    string   `json:"guid"`
+	URL     string   `json:"url"`
+	Space   string   `json:"space"`
+	Host    string   `json:"host"`
+	Domain  string   `json:"domain"`
+	Port    int      `json:"port"`
+	Path    string   `json:"path"`
+	Type    string   `json:"type"`
+	Apps    []string `json:"apps"`
+	Service string   `json:"service"`
+}
+
+func NewRoute(route models.Route, domain models.DomainFields) Route {
+	apps := []string{}
+	for _, app := range route.Apps {
+		apps = append(apps, app.Name)
+	}
+
+	return Route{
+		GUID:    route.GUID,
+		URL:     route.URL(),
+		Space:   route.Space.Name,
+		Host:    route.Host,
+		Domain:  route.Domain.Name,
+		Port:    route.Port,
+		Path:    route.Path,
+		Type:    domain.RouterGroupType,
+		Apps:    apps,
+		Service: route.ServiceInstance.Name,
+	}
+}
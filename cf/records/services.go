@@ -0,0 +1,71 @@
+package records
+
+import "github.com/cloudfoundry/cli/cf/models"
+
+// ServiceInstance is printed for each service instance by `cf services`.
+// Service and plan are empty for user-provided service instances.
+type ServiceInstance struct {
+	Name          string        `json:"name"`
+	GUID          string        `json:"guid"`
+	Service       string        `json:"service"`
+	Plan          string        `json:"plan"`
+	UserProvided  bool          `json:"user_provided"`
+	BoundApps     []string      `json:"bound_apps"`
+	LastOperation LastOperation `json:"last_operation"`
+}
+
+type LastOperation struct {
+	Type        string `json:"type"`
+	State       string `json:"state"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// ServiceInstanceDetail is printed by `cf service`.
+type ServiceInstanceDetail struct {
+	ServiceInstance
+	Tags             []string `json:"tags"`
+	Description      string   `json:"description"`
+	DocumentationURL string   `json:"documentation_url"`
+	DashboardURL     string   `json:"dashboard_url"`
+}
+
+func NewServiceInstance(instance models.ServiceInstance) ServiceInstance {
+	return ServiceInstance{
+		Name:         instance.Name,
+		GUID:         instance.GUID,
+		Service:      instance.ServiceOffering.Label,
+		Plan:         instance.ServicePlan.Name,
+		UserProvided: instance.IsUserProvided(),
+		BoundApps:    names(instance.ApplicationNames),
+		LastOperation: LastOperation{
+			Type:        instance.LastOperation.Type,
+			State:       instance.LastOperation.State,
+			Description: instance.LastOperation.Description,
+			CreatedAt:   instance.LastOperation.CreatedAt,
+			UpdatedAt:   instance.LastOperation.UpdatedAt,
+		},
+	}
+}
+
+func NewServiceInstances(instances []models.ServiceInstance) []ServiceInstance {
+	records := []ServiceInstance{}
+	for _, instance := range instances {
+		records = append(records, NewServiceInstance(instance))
+	}
+	return records
+}
+
+// NewServiceInstanceDetail describes instance, bound to the named apps.
+func NewServiceInstanceDetail(instance models.ServiceInstance, boundApps []string) ServiceInstanceDetail {
+	record := ServiceInstanceDetail{
+		ServiceInstance:  NewServiceInstance(instance),
+		Tags:             names(instance.Tags),
+		Description:      instance.ServiceOffering.Description,
+		DocumentationURL: instance.ServiceOffering.DocumentationURL,
+		DashboardURL:     instance.DashboardURL,
+	}
+	record.BoundApps = names(boundApps)
+	return record
+}
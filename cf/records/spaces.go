@@ -0,0 +1,64 @@
+package records
+
+import "github.com/cloudfoundry/cli/cf/models"
+
+// Space is printed for each space by `cf spaces`.
+type Space struct {
+	Name string `json:"name"`
+	GUID string `json:"guid"`
+}
+
+// SpaceDetail is printed by `cf space`. Quota is null when the space has no
+// space quota. The rules of each security group are only included with
+// --security-group-rules.
+type SpaceDetail struct {
+	Space
+	Org                string                              `json:"org"`
+	Apps               []string                            `json:"apps"`
+	Domains            []string                            `json:"domains"`
+	Services           []string                            `json:"services"`
+	SecurityGroups     []string                            `json:"security_groups"`
+	Quota              *Quota                              `json:"quota"`
+	SecurityGroupRules map[string][]map[string]interface{} `json:"security_group_rules,omitempty"`
+}
+
+func NewSpace(space models.Space) Space {
+	return Space{Name: space.Name, GUID: space.GUID}
+}
+
+func NewSpaceDetail(space models.Space, quota *models.SpaceQuota) SpaceDetail {
+	record := SpaceDetail{
+		Space:          NewSpace(space),
+		Org:            space.Organization.Name,
+		Apps:           []string{},
+		Domains:        []string{},
+		Services:       []string{},
+		SecurityGroups: []string{},
+	}
+
+	for _, app := range space.Applications {
+		record.Apps = append(record.Apps, app.Name)
+	}
+	for _, domain := range space.Domains {
+		record.Domains = append(record.Domains, domain.Name)
+	}
+	for _, service := range space.ServiceInstances {
+		record.Services = append(record.Services, service.Name)
+	}
+	for _, group := range space.SecurityGroups {
+		record.SecurityGroups = append(record.SecurityGroups, group.Name)
+	}
+	if quota != nil {
+		spaceQuota := NewSpaceQuota(*quota)
+		record.Quota = &spaceQuota
+	}
+	return record
+}
+
+// AddSecurityGroupRules includes the rules of the space's security groups.
+func (r *SpaceDetail) AddSecurityGroupRules(space models.Space) {
+	r.SecurityGroupRules = map[string][]map[string]interface{}{}
+	for _, group := range space.SecurityGroups {
+		r.SecurityGroupRules[group.Name] = group.Rules
+	}
+}
@@ -0,0 +1,26 @@
+package records
+
+import "github.com/cloudfoundry/cli/cf/models"
+
+// User is printed for each user by `cf org-users` and `cf space-users`, with
+// the roles it was listed for: org_user, org_manager, billing_manager,
+// org_auditor, space_manager, space_developer or space_auditor.
+type User struct {
+	Username string   `json:"username"`
+	GUID     string   `json:"guid"`
+	Admin    bool     `json:"admin"`
+	Roles    []string `json:"roles"`
+}
+
+func NewUser(user models.UserFields, roles []models.Role) User {
+	record := User{
+		Username: user.Username,
+		GUID:     user.GUID,
+		Admin:    user.IsAdmin,
+		Roles:    []string{},
+	}
+	for _, role := range roles {
+		record.Roles = append(record.Roles, roleNames[role])
+	}
+	return record
+}
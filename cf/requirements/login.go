@@ -1,9 +1,8 @@
 package requirements
 
 import (
-	"errors"
-
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/terminal"
 )
 
@@ -23,7 +22,7 @@ func (req LoginRequirement) Execute() error {
 	}
 
 	if !req.config.IsLoggedIn() {
-		return errors.New(terminal.NotLoggedInText())
+		return errors.NewNotLoggedInError(terminal.NotLoggedInText())
 	}
 
 	return nil
@@ -3,6 +3,7 @@ package requirements
 import (
 	"strings"
 
+	"github.com/cloudfoundry/cli/cf/errors"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 )
 
@@ -35,3 +36,7 @@ func (e NumberArgumentsError) Error() string {
 		"Arguments": strings.Join(e.ExpectedArgs, ", "),
 	})
 }
+
+func (e NumberArgumentsError) ExitCode() int {
+	return errors.ExitCodeUsage
+}
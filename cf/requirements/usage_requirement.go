@@ -1,9 +1,9 @@
 package requirements
 
 import (
-	"errors"
 	"fmt"
 
+	"github.com/cloudfoundry/cli/cf/errors"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 )
 
@@ -18,7 +18,7 @@ func NewUsageRequirement(cmd Usable, errorMessage string, pred func() bool) Requ
 		if pred() {
 			m := fmt.Sprintf("%s. %s\n\n%s", T("Incorrect Usage"), errorMessage, cmd.Usage())
 
-			return errors.New(m)
+			return errors.NewUsageError(m)
 		}
 
 		return nil
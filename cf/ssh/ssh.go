@@ -446,21 +446,44 @@ func (c *secureShell) getWindowDimensions(terminalFd uintptr) (width int, height
 	return int(winSize.Width), int(winSize.Height)
 }
 
-type secureDialer struct{}
+type secureDialer struct {
+	dial func(network string, address string) (net.Conn, error)
+}
 
 func (d *secureDialer) Dial(network string, address string, config *ssh.ClientConfig) (SecureClient, error) {
-	client, err := ssh.Dial(network, address, config)
+	if d.dial == nil {
+		client, err := ssh.Dial(network, address, config)
+		if err != nil {
+			return nil, err
+		}
+
+		return &secureClient{client: client}, nil
+	}
+
+	conn, err := d.dial(network, address)
 	if err != nil {
 		return nil, err
 	}
 
-	return &secureClient{client: client}, nil
+	clientConn, channels, requests, err := ssh.NewClientConn(conn, address, config)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &secureClient{client: ssh.NewClient(clientConn, channels, requests)}, nil
 }
 
 func DefaultSecureDialer() SecureDialer {
 	return &secureDialer{}
 }
 
+// NewSecureDialer returns a SecureDialer that opens connections with dial,
+// such as through a proxy.
+func NewSecureDialer(dial func(network string, address string) (net.Conn, error)) SecureDialer {
+	return &secureDialer{dial: dial}
+}
+
 type secureClient struct{ client *ssh.Client }
 
 func (sc *secureClient) Close() error   { return sc.client.Close() }
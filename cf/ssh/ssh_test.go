@@ -1222,3 +1222,33 @@ var _ = Describe("SSH", func() {
 		})
 	})
 })
+
+var _ = Describe("SecureDialer", func() {
+	It("opens connections with the dial function it is given", func() {
+		var dialedNetwork, dialedAddress string
+		dialer := sshCmd.NewSecureDialer(func(network string, address string) (net.Conn, error) {
+			dialedNetwork, dialedAddress = network, address
+			return nil, errors.New("proxy refused the connection")
+		})
+
+		_, err := dialer.Dial("tcp", "ssh.example.com:2222", &ssh.ClientConfig{})
+		Expect(err).To(MatchError("proxy refused the connection"))
+		Expect(dialedNetwork).To(Equal("tcp"))
+		Expect(dialedAddress).To(Equal("ssh.example.com:2222"))
+	})
+
+	It("closes connections on which the handshake fails", func() {
+		client, server := net.Pipe()
+		server.Close()
+
+		dialer := sshCmd.NewSecureDialer(func(string, string) (net.Conn, error) {
+			return client, nil
+		})
+
+		_, err := dialer.Dial("tcp", "ssh.example.com:2222", &ssh.ClientConfig{})
+		Expect(err).To(HaveOccurred())
+
+		_, err = client.Write([]byte("closed"))
+		Expect(err).To(HaveOccurred())
+	})
+})
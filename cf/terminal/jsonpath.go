@@ -0,0 +1,444 @@
+package terminal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+// JSONPath is a JSONPath template in the style of kubectl: text outside of
+// braces is printed as it is, and each {expression} prints the values it
+// selects separated by spaces. Expressions are paths such as .name,
+// .urls[0], [*].host or ['last operation'], {range PATH}...{end} loops,
+// and quoted strings such as {"\n"}.
+//
+// Commands that list things print a list of records with no enclosing
+// object, so their fields are selected with [*], as in {[*].host} for
+// cf routes, rather than {.routes[*].host}.
+type JSONPath struct {
+	text  string
+	nodes []jsonPathNode
+}
+
+type jsonPathNode struct {
+	text    string
+	literal bool
+	path    []jsonPathStep
+	isRange bool
+	body    []jsonPathNode
+}
+
+type jsonPathStep struct {
+	name      string
+	wildcard  bool
+	recursive bool
+	index     *int
+	slice     *[2]*int
+}
+
+func ParseJSONPath(text string) (*JSONPath, error) {
+	if !strings.Contains(text, "{") {
+		text = "{" + text + "}"
+	}
+
+	p := &JSONPath{text: text}
+	nodes, _, err := p.parseNodes(text, false)
+	if err != nil {
+		return nil, err
+	}
+	p.nodes = nodes
+	return p, nil
+}
+
+func (p *JSONPath) invalid(message string) error {
+	return errors.New(T("Invalid JSONPath '{{.JSONPath}}': {{.Error}}",
+		map[string]interface{}{"JSONPath": p.text, "Error": message}))
+}
+
+func (p *JSONPath) failed(message string) error {
+	return errors.New(T("Cannot print JSONPath '{{.JSONPath}}': {{.Error}}",
+		map[string]interface{}{"JSONPath": p.text, "Error": message}))
+}
+
+// parseNodes parses text up to the end of input or, inside a range, up to
+// its {end}. It returns the text that follows the {end}.
+func (p *JSONPath) parseNodes(text string, inRange bool) ([]jsonPathNode, string, error) {
+	nodes := []jsonPathNode{}
+	for text != "" {
+		open := strings.Index(text, "{")
+		if open == -1 {
+			nodes = append(nodes, jsonPathNode{text: text, literal: true})
+			text = ""
+			break
+		}
+		if open > 0 {
+			nodes = append(nodes, jsonPathNode{text: text[:open], literal: true})
+		}
+
+		end := closingBrace(text, open)
+		if end == -1 {
+			return nil, "", p.invalid(T("unclosed {"))
+		}
+		expression := strings.TrimSpace(text[open+1 : end])
+		text = text[end+1:]
+
+		switch {
+		case expression == "end":
+			if !inRange {
+				return nil, "", p.invalid(T("{end} without {range}"))
+			}
+			return nodes, text, nil
+		case strings.HasPrefix(expression, "range ") || expression == "range":
+			path, err := p.parsePath(strings.TrimSpace(strings.TrimPrefix(expression, "range")))
+			if err != nil {
+				return nil, "", err
+			}
+
+			var body []jsonPathNode
+			body, text, err = p.parseNodes(text, true)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, jsonPathNode{isRange: true, path: path, body: body})
+		case strings.HasPrefix(expression, `"`):
+			literal, err := strconv.Unquote(expression)
+			if err != nil {
+				return nil, "", p.invalid(err.Error())
+			}
+			nodes = append(nodes, jsonPathNode{text: literal, literal: true})
+		default:
+			path, err := p.parsePath(expression)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, jsonPathNode{path: path})
+		}
+	}
+
+	if inRange {
+		return nil, "", p.invalid(T("{range} without {end}"))
+	}
+	return nodes, "", nil
+}
+
+// closingBrace finds the brace that closes the one at open, skipping
+// quoted strings.
+func closingBrace(text string, open int) int {
+	quoted := false
+	for i := open + 1; i < len(text); i++ {
+		switch {
+		case quoted && text[i] == '\\':
+			i++
+		case text[i] == '"':
+			quoted = !quoted
+		case !quoted && text[i] == '}':
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *JSONPath) parsePath(expression string) ([]jsonPathStep, error) {
+	expression = strings.TrimPrefix(strings.TrimPrefix(expression, "$"), "@")
+
+	steps := []jsonPathStep{}
+	for expression != "" {
+		switch {
+		case strings.HasPrefix(expression, ".."):
+			name, rest := pathName(expression[2:])
+			if name == "" {
+				return nil, p.invalid(T("missing field name after .."))
+			}
+			steps = append(steps, jsonPathStep{name: name, recursive: true})
+			expression = rest
+		case strings.HasPrefix(expression, "."):
+			name, rest := pathName(expression[1:])
+			if name == "*" {
+				steps = append(steps, jsonPathStep{wildcard: true})
+			} else if name != "" {
+				steps = append(steps, jsonPathStep{name: name})
+			}
+			expression = rest
+		case strings.HasPrefix(expression, "["):
+			end := strings.Index(expression, "]")
+			if end == -1 {
+				return nil, p.invalid(T("unclosed ["))
+			}
+
+			step, err := p.parseSubscript(strings.TrimSpace(expression[1:end]))
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+			expression = expression[end+1:]
+		default:
+			name, rest := pathName(expression)
+			if name == "" {
+				return nil, p.invalid(T("unexpected '{{.Text}}'", map[string]interface{}{"Text": expression}))
+			}
+			steps = append(steps, jsonPathStep{name: name})
+			expression = rest
+		}
+	}
+	return steps, nil
+}
+
+func pathName(expression string) (string, string) {
+	end := strings.IndexAny(expression, ".[")
+	if end == -1 {
+		return expression, ""
+	}
+	return expression[:end], expression[end:]
+}
+
+func (p *JSONPath) parseSubscript(subscript string) (jsonPathStep, error) {
+	if subscript == "*" {
+		return jsonPathStep{wildcard: true}, nil
+	}
+
+	if strings.HasPrefix(subscript, "'") && strings.HasSuffix(subscript, "'") && len(subscript) >= 2 {
+		return jsonPathStep{name: subscript[1 : len(subscript)-1]}, nil
+	}
+
+	if strings.Contains(subscript, ":") {
+		bounds := strings.SplitN(subscript, ":", 2)
+		slice := [2]*int{}
+		for i, bound := range bounds {
+			bound = strings.TrimSpace(bound)
+			if bound == "" {
+				continue
+			}
+
+			value, err := strconv.Atoi(bound)
+			if err != nil {
+				return jsonPathStep{}, p.invalid(T("invalid index '{{.Index}}'", map[string]interface{}{"Index": bound}))
+			}
+			slice[i] = &value
+		}
+		return jsonPathStep{slice: &slice}, nil
+	}
+
+	index, err := strconv.Atoi(subscript)
+	if err != nil {
+		return jsonPathStep{}, p.invalid(T("invalid index '{{.Index}}'", map[string]interface{}{"Index": subscript}))
+	}
+	return jsonPathStep{index: &index}, nil
+}
+
+// Execute prints the values data selects. Data is described by its JSON
+// encoding, like records.
+func (p *JSONPath) Execute(w io.Writer, data interface{}) error {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+
+	var value interface{}
+	err = decoder.Decode(&value)
+	if err != nil {
+		return err
+	}
+
+	output := &bytes.Buffer{}
+	err = p.execute(output, p.nodes, value)
+	if err != nil {
+		return err
+	}
+
+	if output.Len() > 0 && !bytes.HasSuffix(output.Bytes(), []byte("\n")) {
+		output.WriteString("\n")
+	}
+	_, err = output.WriteTo(w)
+	return err
+}
+
+func (p *JSONPath) execute(output *bytes.Buffer, nodes []jsonPathNode, current interface{}) error {
+	for _, node := range nodes {
+		if node.literal {
+			output.WriteString(node.text)
+			continue
+		}
+
+		values, err := p.evaluate(node.path, current)
+		if err != nil {
+			return err
+		}
+
+		if node.isRange {
+			if len(values) == 1 {
+				if array, ok := values[0].([]interface{}); ok {
+					values = array
+				}
+			}
+
+			for _, value := range values {
+				err = p.execute(output, node.body, value)
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		for i, value := range values {
+			if i > 0 {
+				output.WriteString(" ")
+			}
+
+			text, err := jsonPathText(value)
+			if err != nil {
+				return err
+			}
+			output.WriteString(text)
+		}
+	}
+	return nil
+}
+
+func (p *JSONPath) evaluate(path []jsonPathStep, current interface{}) ([]interface{}, error) {
+	values := []interface{}{current}
+	for _, step := range path {
+		next := []interface{}{}
+		for _, value := range values {
+			selected, err := p.selectStep(step, value)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, selected...)
+		}
+		values = next
+	}
+	return values, nil
+}
+
+func (p *JSONPath) selectStep(step jsonPathStep, value interface{}) ([]interface{}, error) {
+	switch {
+	case step.recursive:
+		return findRecursive(step.name, value), nil
+	case step.wildcard:
+		switch value := value.(type) {
+		case []interface{}:
+			return value, nil
+		case map[string]interface{}:
+			values := []interface{}{}
+			for _, key := range sortedKeys(value) {
+				values = append(values, value[key])
+			}
+			return values, nil
+		}
+		return nil, nil
+	case step.index != nil:
+		array, ok := value.([]interface{})
+		if !ok {
+			return nil, p.failed(T("{{.Index}} is not an index of a list", map[string]interface{}{"Index": *step.index}))
+		}
+
+		index := *step.index
+		if index < 0 {
+			index += len(array)
+		}
+		if index < 0 || index >= len(array) {
+			return nil, p.failed(T("index {{.Index}} is out of range", map[string]interface{}{"Index": *step.index}))
+		}
+		return []interface{}{array[index]}, nil
+	case step.slice != nil:
+		array, ok := value.([]interface{})
+		if !ok {
+			return nil, p.failed(T("only lists can be sliced"))
+		}
+
+		start, end := 0, len(array)
+		if step.slice[0] != nil {
+			start = sliceBound(*step.slice[0], len(array))
+		}
+		if step.slice[1] != nil {
+			end = sliceBound(*step.slice[1], len(array))
+		}
+		if start >= end {
+			return nil, nil
+		}
+		return array[start:end], nil
+	default:
+		if _, isList := value.([]interface{}); isList {
+			return nil, p.failed(T("{{.Name}} is not found in a list; select the fields of its items with [*], as in {[*].host}",
+				map[string]interface{}{"Name": step.name}))
+		}
+
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, p.failed(T("{{.Name}} is not found", map[string]interface{}{"Name": step.name}))
+		}
+
+		field, found := object[step.name]
+		if !found {
+			return nil, p.failed(T("{{.Name}} is not found", map[string]interface{}{"Name": step.name}))
+		}
+		return []interface{}{field}, nil
+	}
+}
+
+func sliceBound(bound int, length int) int {
+	if bound < 0 {
+		bound += length
+	}
+	if bound < 0 {
+		return 0
+	}
+	if bound > length {
+		return length
+	}
+	return bound
+}
+
+func findRecursive(name string, value interface{}) []interface{} {
+	values := []interface{}{}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if field, found := value[name]; found {
+			values = append(values, field)
+		}
+		for _, key := range sortedKeys(value) {
+			values = append(values, findRecursive(name, value[key])...)
+		}
+	case []interface{}:
+		for _, element := range value {
+			values = append(values, findRecursive(name, element)...)
+		}
+	}
+	return values
+}
+
+// sortedKeys lists the fields of a decoded object in a stable order, since
+// decoding loses the order of the record.
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func jsonPathText(value interface{}) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case json.Number, bool:
+		return fmt.Sprint(value), nil
+	default:
+		jsonBytes, err := json.Marshal(value)
+		return string(jsonBytes), err
+	}
+}
@@ -0,0 +1,86 @@
+package terminal_test
+
+import (
+	"bytes"
+
+	. "github.com/cloudfoundry/cli/cf/terminal"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSONPath", func() {
+	type route struct {
+		Host string   `json:"host"`
+		Port int      `json:"port"`
+		Apps []string `json:"apps"`
+	}
+
+	type app struct {
+		Name   string  `json:"name"`
+		Routes []route `json:"routes"`
+	}
+
+	var (
+		output *bytes.Buffer
+		data   []app
+	)
+
+	BeforeEach(func() {
+		output = &bytes.Buffer{}
+		data = []app{
+			{Name: "app1", Routes: []route{{Host: "www", Apps: []string{"app1"}}, {Host: "api", Port: 8080}}},
+			{Name: "app2", Routes: []route{}},
+		}
+	})
+
+	execute := func(text string) (string, error) {
+		output.Reset()
+		path, err := ParseJSONPath(text)
+		if err != nil {
+			return "", err
+		}
+		err = path.Execute(output, data)
+		return output.String(), err
+	}
+
+	It("prints the selected values separated by spaces", func() {
+		Expect(execute("{[*].name}")).To(Equal("app1 app2\n"))
+		Expect(execute("{.[0].routes[*].host}")).To(Equal("www api\n"))
+	})
+
+	It("accepts an expression without braces", func() {
+		Expect(execute("[1].name")).To(Equal("app2\n"))
+	})
+
+	It("prints numbers, and lists and objects as JSON", func() {
+		Expect(execute(`{[0].routes[1].port} {[0].routes[0].apps} {[1]}`)).To(Equal(`8080 ["app1"] {"name":"app2","routes":[]}` + "\n"))
+	})
+
+	It("supports negative indexes, slices, quoted names and recursive descent", func() {
+		Expect(execute("{[-1].name}")).To(Equal("app2\n"))
+		Expect(execute("{[0:1].name}")).To(Equal("app1\n"))
+		Expect(execute("{[0]['name']}")).To(Equal("app1\n"))
+		Expect(execute("{..host}")).To(Equal("www api\n"))
+	})
+
+	It("loops over values with range", func() {
+		Expect(execute(`{range [*]}{.name}:{range .routes[*]} {.host}{end}{"\n"}{end}`)).To(Equal("app1: www api\napp2:\n"))
+	})
+
+	It("returns an error for missing fields", func() {
+		_, err := execute("{[0].state}")
+		Expect(err).To(MatchError(ContainSubstring("Cannot print JSONPath")))
+	})
+
+	It("explains how to select the fields of lists of records", func() {
+		_, err := execute("{.apps[*].name}")
+		Expect(err).To(MatchError(ContainSubstring("apps is not found in a list; select the fields of its items with [*], as in {[*].host}")))
+	})
+
+	It("returns an error for invalid templates", func() {
+		for _, text := range []string{"{[0].name", "{range [*]}{.name}", "{end}", "{[x]}"} {
+			_, err := ParseJSONPath(text)
+			Expect(err).To(HaveOccurred(), text)
+		}
+	})
+})
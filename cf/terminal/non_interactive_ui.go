@@ -0,0 +1,73 @@
+package terminal
+
+import (
+	"github.com/cloudfoundry/cli/cf/errors"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+// NonInteractiveError is returned for a prompt that cannot be asked because
+// the CLI runs with CF_NON_INTERACTIVE or --non-interactive. Flag names the
+// flag that would have answered the prompt, if there is one.
+type NonInteractiveError struct {
+	Prompt string
+	Flag   string
+}
+
+func NewNonInteractiveError(prompt string, flag string) *NonInteractiveError {
+	return &NonInteractiveError{Prompt: prompt, Flag: flag}
+}
+
+func (err *NonInteractiveError) Error() string {
+	if err.Flag == "" {
+		return T("Cannot prompt for '{{.Prompt}}' in non-interactive mode",
+			map[string]interface{}{"Prompt": Decolorize(err.Prompt)})
+	}
+
+	return T("Cannot prompt for '{{.Prompt}}' in non-interactive mode. Use {{.Flag}} instead.",
+		map[string]interface{}{"Prompt": Decolorize(err.Prompt), "Flag": err.Flag})
+}
+
+func (err *NonInteractiveError) ExitCode() int {
+	return errors.ExitCodeUsage
+}
+
+type nonInteractiveUI struct {
+	UI
+}
+
+// NewNonInteractiveUI returns a UI whose prompts fail instead of reading
+// from stdin. Confirmations name -f, which answers them for every command;
+// commands that can answer other prompts with flags check Interactive
+// before asking.
+func NewNonInteractiveUI(ui UI) UI {
+	return &nonInteractiveUI{UI: ui}
+}
+
+func (ui *nonInteractiveUI) Interactive() bool {
+	return false
+}
+
+func (ui *nonInteractiveUI) Ask(prompt string) string {
+	FailWithCode(ui.UI, NewNonInteractiveError(prompt, ""), errors.ExitCodeFailure)
+	return ""
+}
+
+func (ui *nonInteractiveUI) AskForPassword(prompt string) string {
+	FailWithCode(ui.UI, NewNonInteractiveError(prompt, ""), errors.ExitCodeFailure)
+	return ""
+}
+
+func (ui *nonInteractiveUI) Confirm(message string) bool {
+	FailWithCode(ui.UI, NewNonInteractiveError(message, "-f"), errors.ExitCodeFailure)
+	return false
+}
+
+func (ui *nonInteractiveUI) ConfirmDelete(modelType, modelName string) bool {
+	return ui.Confirm(T("Really delete the {{.ModelType}} {{.ModelName}}?",
+		map[string]interface{}{"ModelType": modelType, "ModelName": modelName}))
+}
+
+func (ui *nonInteractiveUI) ConfirmDeleteWithAssociations(modelType, modelName string) bool {
+	return ui.Confirm(T("Really delete the {{.ModelType}} {{.ModelName}} and everything associated with it?",
+		map[string]interface{}{"ModelType": modelType, "ModelName": modelName}))
+}
@@ -0,0 +1,390 @@
+package terminal
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"gopkg.in/yaml.v2"
+)
+
+// OutputFormat is how commands print what they list or show. It is chosen
+// with the global --output, --format or --jsonpath options, or CF_OUTPUT,
+// CF_FORMAT or CF_JSONPATH; tables are the default.
+type OutputFormat string
+
+const (
+	TableOutput    OutputFormat = "table"
+	JSONOutput     OutputFormat = "json"
+	YAMLOutput     OutputFormat = "yaml"
+	CSVOutput      OutputFormat = "csv"
+	TemplateOutput OutputFormat = "template"
+	JSONPathOutput OutputFormat = "jsonpath"
+)
+
+// OutputOptions are the output format and, for templates and JSONPath, what
+// to print.
+type OutputOptions struct {
+	Format   OutputFormat
+	Template *template.Template
+	JSONPath *JSONPath
+	Table    TableOptions
+}
+
+// NewOutputOptions checks the output format, Go template and JSONPath
+// template given by the user. At most one of them can be given.
+func NewOutputOptions(format string, templateText string, jsonPath string) (OutputOptions, error) {
+	given := 0
+	for _, value := range []string{format, templateText, jsonPath} {
+		if value != "" {
+			given++
+		}
+	}
+	if given > 1 {
+		return OutputOptions{Format: TableOutput}, errors.New(T("Use only one of --output, --format and --jsonpath"))
+	}
+
+	switch {
+	case templateText != "":
+		tmpl, err := template.New("format").Funcs(templateFuncs).Parse(templateText)
+		if err != nil {
+			return OutputOptions{Format: TableOutput}, errors.New(T("Invalid template '{{.Template}}': {{.Error}}",
+				map[string]interface{}{"Template": templateText, "Error": err.Error()}))
+		}
+		return OutputOptions{Format: TemplateOutput, Template: tmpl}, nil
+	case jsonPath != "":
+		path, err := ParseJSONPath(jsonPath)
+		if err != nil {
+			return OutputOptions{Format: TableOutput}, err
+		}
+		return OutputOptions{Format: JSONPathOutput, JSONPath: path}, nil
+	default:
+		outputFormat, err := ParseOutputFormat(format)
+		return OutputOptions{Format: outputFormat}, err
+	}
+}
+
+var templateFuncs = template.FuncMap{
+	"join": func(values []string, separator string) string {
+		return strings.Join(values, separator)
+	},
+	"json": func(value interface{}) (string, error) {
+		jsonBytes, err := json.Marshal(value)
+		return string(jsonBytes), err
+	},
+}
+
+// WriteRecords writes data in the chosen format. A template is executed
+// once for each record, with the record's exported fields; records of
+// tables without a dedicated record type are maps of their field names.
+// A JSONPath template is executed once on all of data. Lists of records are
+// sorted and filtered with the table options first, and for other formats
+// than templates their fields are selected like the columns of a table.
+func (o OutputOptions) WriteRecords(w io.Writer, data interface{}) error {
+	data, err := o.Table.applyToRecords(data, o.Format != TemplateOutput)
+	if err != nil {
+		return err
+	}
+
+	switch o.Format {
+	case TemplateOutput:
+		return writeTemplate(w, o.Template, data)
+	case JSONPathOutput:
+		return o.JSONPath.Execute(w, data)
+	default:
+		return WriteRecords(w, o.Format, data)
+	}
+}
+
+func ParseOutputFormat(value string) (OutputFormat, error) {
+	switch format := OutputFormat(strings.ToLower(value)); format {
+	case "", TableOutput:
+		return TableOutput, nil
+	case JSONOutput, YAMLOutput, CSVOutput:
+		return format, nil
+	default:
+		return TableOutput, errors.New(T("Invalid output format '{{.Format}}'. Use json, yaml or csv.",
+			map[string]interface{}{"Format": value}))
+	}
+}
+
+// IsStructured reports whether commands print records rather than tables.
+func (f OutputFormat) IsStructured() bool {
+	return f != "" && f != TableOutput
+}
+
+// Record is a set of named values kept in order. Tables without a dedicated
+// record type are printed as one Record per row, named after the headers.
+type Record []Field
+
+type Field struct {
+	Name  string
+	Value interface{}
+}
+
+func (r Record) MarshalJSON() ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	buffer.WriteString("{")
+	for i, field := range r {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+
+		name, err := json.Marshal(field.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(name)
+		buffer.WriteString(":")
+		buffer.Write(value)
+	}
+	buffer.WriteString("}")
+	return buffer.Bytes(), nil
+}
+
+// WriteRecords writes data, a record or a slice of records, to w. Records are
+// structs, maps or Records, described by their JSON encoding in every format:
+// field names come from json tags and keep their declared order. CSV has one
+// row per record; values that are not scalars are written as JSON.
+func WriteRecords(w io.Writer, format OutputFormat, data interface{}) error {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case JSONOutput:
+		output := &bytes.Buffer{}
+		err = json.Indent(output, jsonBytes, "", "  ")
+		if err != nil {
+			return err
+		}
+		output.WriteString("\n")
+		_, err = output.WriteTo(w)
+		return err
+	case YAMLOutput:
+		ordered, err := decodeOrdered(jsonBytes)
+		if err != nil {
+			return err
+		}
+
+		yamlBytes, err := yaml.Marshal(ordered)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(yamlBytes)
+		return err
+	case CSVOutput:
+		ordered, err := decodeOrdered(jsonBytes)
+		if err != nil {
+			return err
+		}
+		return writeCSV(w, ordered)
+	default:
+		return fmt.Errorf("Cannot write records as %s", format)
+	}
+}
+
+// decodeOrdered decodes JSON into yaml.MapSlice, []interface{} and scalars
+// so that objects keep the order of their fields.
+func decodeOrdered(jsonBytes []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+	return decodeOrderedValue(decoder)
+}
+
+func decodeOrderedValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		object := yaml.MapSlice{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := decodeOrderedValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, yaml.MapItem{Key: key, Value: value})
+		}
+		_, err = decoder.Token()
+		return object, err
+	case json.Delim('['):
+		array := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrderedValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err = decoder.Token()
+		return array, err
+	}
+
+	if number, ok := token.(json.Number); ok {
+		if i, err := number.Int64(); err == nil {
+			return i, nil
+		}
+		return number.Float64()
+	}
+	return token, nil
+}
+
+func writeTemplate(w io.Writer, tmpl *template.Template, data interface{}) error {
+	records := []interface{}{}
+	switch data := data.(type) {
+	case Record:
+		records = append(records, data.fields())
+	case []Record:
+		for _, record := range data {
+			records = append(records, record.fields())
+		}
+	default:
+		value := reflect.ValueOf(data)
+		if value.Kind() == reflect.Slice {
+			for i := 0; i < value.Len(); i++ {
+				records = append(records, value.Index(i).Interface())
+			}
+		} else {
+			records = append(records, data)
+		}
+	}
+
+	for _, record := range records {
+		output := &bytes.Buffer{}
+		err := tmpl.Execute(output, record)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.HasSuffix(output.Bytes(), []byte("\n")) {
+			output.WriteString("\n")
+		}
+		_, err = output.WriteTo(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, data interface{}) error {
+	records, ok := data.([]interface{})
+	if !ok {
+		records = []interface{}{data}
+	}
+
+	columns := []string{}
+	seen := map[string]bool{}
+	for _, record := range records {
+		object, ok := record.(yaml.MapSlice)
+		if !ok {
+			return errors.New(T("CSV output needs a list of records"))
+		}
+
+		for _, item := range object {
+			name := item.Key.(string)
+			if !seen[name] {
+				seen[name] = true
+				columns = append(columns, name)
+			}
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	err := writer.Write(columns)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		values := map[string]interface{}{}
+		for _, item := range record.(yaml.MapSlice) {
+			values[item.Key.(string)] = item.Value
+		}
+
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i], err = csvValue(values[column])
+			if err != nil {
+				return err
+			}
+		}
+
+		err = writer.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func csvValue(value interface{}) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case yaml.MapSlice, []interface{}:
+		jsonBytes, err := json.Marshal(plain(value))
+		return string(jsonBytes), err
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
+
+// plain turns decoded values back into ones encoding/json can marshal in order.
+func plain(value interface{}) interface{} {
+	switch value := value.(type) {
+	case yaml.MapSlice:
+		record := Record{}
+		for _, item := range value {
+			record = append(record, Field{Name: fmt.Sprint(item.Key), Value: plain(item.Value)})
+		}
+		return record
+	case []interface{}:
+		values := make([]interface{}, len(value))
+		for i := range value {
+			values[i] = plain(value[i])
+		}
+		return values
+	default:
+		return value
+	}
+}
+
+func (r Record) fields() map[string]interface{} {
+	fields := map[string]interface{}{}
+	for _, field := range r {
+		fields[field.Name] = field.Value
+	}
+	return fields
+}
+
+// recordFieldName turns a table header into a record field name.
+func recordFieldName(header string) string {
+	header = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(Decolorize(header)), ":"))
+	return strings.Replace(strings.ToLower(header), " ", "_", -1)
+}
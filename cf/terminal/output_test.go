@@ -0,0 +1,172 @@
+package terminal_test
+
+import (
+	"bytes"
+
+	. "github.com/cloudfoundry/cli/cf/terminal"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Output", func() {
+	Describe("ParseOutputFormat", func() {
+		It("defaults to tables", func() {
+			format, err := ParseOutputFormat("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(format).To(Equal(TableOutput))
+			Expect(format.IsStructured()).To(BeFalse())
+		})
+
+		It("accepts json, yaml and csv in any case", func() {
+			for value, expected := range map[string]OutputFormat{
+				"json": JSONOutput,
+				"YAML": YAMLOutput,
+				"Csv":  CSVOutput,
+			} {
+				format, err := ParseOutputFormat(value)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(format).To(Equal(expected))
+				Expect(format.IsStructured()).To(BeTrue())
+			}
+		})
+
+		It("returns an error for other formats", func() {
+			_, err := ParseOutputFormat("xml")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Invalid output format"))
+		})
+	})
+
+	Describe("NewOutputOptions", func() {
+		It("accepts one of a format, a template and a JSONPath template", func() {
+			options, err := NewOutputOptions("", "{{.Name}}", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(options.Format).To(Equal(TemplateOutput))
+			Expect(options.Format.IsStructured()).To(BeTrue())
+
+			options, err = NewOutputOptions("", "", "{.name}")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(options.Format).To(Equal(JSONPathOutput))
+		})
+
+		It("returns an error when more than one is given", func() {
+			_, err := NewOutputOptions("json", "{{.Name}}", "")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns an error for invalid templates", func() {
+			_, err := NewOutputOptions("", "{{.Name", "")
+			Expect(err).To(MatchError(ContainSubstring("Invalid template")))
+		})
+
+		Describe("WriteRecords with a template", func() {
+			type app struct {
+				Name  string   `json:"name"`
+				State string   `json:"state"`
+				URLs  []string `json:"urls"`
+			}
+
+			var output *bytes.Buffer
+
+			BeforeEach(func() {
+				output = &bytes.Buffer{}
+			})
+
+			It("executes the template for each record", func() {
+				options, err := NewOutputOptions("", `{{.Name}} {{.State}} {{join .URLs ","}}`, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = options.WriteRecords(output, []app{
+					{Name: "app1", State: "started", URLs: []string{"a.example.com", "b.example.com"}},
+					{Name: "app2", State: "stopped"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output.String()).To(Equal("app1 started a.example.com,b.example.com\napp2 stopped \n"))
+			})
+
+			It("executes the template once for a single record", func() {
+				options, err := NewOutputOptions("", `{{json .URLs}}`, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = options.WriteRecords(output, app{URLs: []string{"a.example.com"}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output.String()).To(Equal(`["a.example.com"]` + "\n"))
+			})
+
+			It("gives the fields of table records by name", func() {
+				options, err := NewOutputOptions("", `{{.name}}`, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = options.WriteRecords(output, []Record{{{Name: "name", Value: "my-org"}}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output.String()).To(Equal("my-org\n"))
+			})
+		})
+	})
+
+	Describe("WriteRecords", func() {
+		type route struct {
+			Host string   `json:"host"`
+			Port int      `json:"port"`
+			Apps []string `json:"apps"`
+		}
+
+		var (
+			output *bytes.Buffer
+			routes []route
+		)
+
+		BeforeEach(func() {
+			output = &bytes.Buffer{}
+			routes = []route{
+				{Host: "www", Port: 0, Apps: []string{"app1", "app2"}},
+				{Host: "tcp", Port: 1024, Apps: []string{}},
+			}
+		})
+
+		It("writes indented JSON", func() {
+			err := WriteRecords(output, JSONOutput, routes[:1])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output.String()).To(Equal(`[
+  {
+    "host": "www",
+    "port": 0,
+    "apps": [
+      "app1",
+      "app2"
+    ]
+  }
+]
+`))
+		})
+
+		It("writes YAML keeping the order of the fields", func() {
+			err := WriteRecords(output, YAMLOutput, routes[1])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output.String()).To(Equal("host: tcp\nport: 1024\napps: []\n"))
+		})
+
+		It("writes CSV with a row per record and lists as JSON", func() {
+			err := WriteRecords(output, CSVOutput, routes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output.String()).To(Equal(`host,port,apps
+www,0,"[""app1"",""app2""]"
+tcp,1024,[]
+`))
+		})
+
+		It("writes an empty CSV header for an empty list", func() {
+			err := WriteRecords(output, CSVOutput, []route{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output.String()).To(Equal("\n"))
+		})
+
+		It("writes Records in the order of their fields", func() {
+			record := Record{{Name: "zebra", Value: "z"}, {Name: "aardvark", Value: 1}}
+			err := WriteRecords(output, JSONOutput, record)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output.String()).To(MatchJSON(`{"zebra": "z", "aardvark": 1}`))
+			Expect(output.String()).To(MatchRegexp(`(?s)zebra.*aardvark`))
+		})
+	})
+})
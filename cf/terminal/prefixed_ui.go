@@ -0,0 +1,84 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+type prefixedUI struct {
+	UI
+	prefix string
+	lock   *sync.Mutex
+}
+
+// NewPrefixedUI returns a UI that starts every line it prints with prefix.
+// UIs that share lock print whole messages at a time, so that commands
+// running concurrently, such as pushes of several apps, can share the
+// terminal without mixing up their lines.
+func NewPrefixedUI(ui UI, prefix string, lock *sync.Mutex) UI {
+	return &prefixedUI{UI: ui, prefix: prefix, lock: lock}
+}
+
+func (ui *prefixedUI) Say(message string, args ...interface{}) {
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+
+	lines := strings.Split(message, "\n")
+	for i, line := range lines {
+		lines[i] = ui.prefix + line
+	}
+
+	ui.lock.Lock()
+	defer ui.lock.Unlock()
+	ui.UI.Say("%s", strings.Join(lines, "\n"))
+}
+
+// PrintCapturingNoOutput prints a line, since partial lines of concurrent
+// commands would run into each other.
+func (ui *prefixedUI) PrintCapturingNoOutput(message string, args ...interface{}) {
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+	ui.Say(strings.TrimSuffix(message, "\n"))
+}
+
+func (ui *prefixedUI) PrintPaginator(rows []string, err error) {
+	if err != nil {
+		ui.Failed("%s", err.Error())
+		return
+	}
+
+	for _, row := range rows {
+		ui.Say(row)
+	}
+}
+
+func (ui *prefixedUI) Warn(message string, args ...interface{}) {
+	ui.Say(WarningColor(fmt.Sprintf(message, args...)))
+}
+
+func (ui *prefixedUI) Ok() {
+	ui.Say(SuccessColor(T("OK")))
+}
+
+func (ui *prefixedUI) Failed(message string, args ...interface{}) {
+	ui.Say(FailureColor(T("FAILED")))
+	ui.Say(fmt.Sprintf(message, args...))
+	ui.PanicQuietly()
+}
+
+// LoadingIndication prints nothing, since its dots would run into the
+// lines of concurrent commands.
+func (ui *prefixedUI) LoadingIndication() {
+}
+
+func (ui *prefixedUI) Table(headers []string) *UITable {
+	return &UITable{
+		UI:    ui,
+		Table: NewTable(headers),
+	}
+}
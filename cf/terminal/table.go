@@ -104,6 +104,94 @@ func (t *Table) PrintTo(result io.Writer) {
 	t.rows = [][]string{}
 }
 
+// Apply sorts, filters and selects the columns of the table. Tables without
+// headers list properties rather than things, and are left as they are.
+func (t *Table) Apply(options TableOptions) error {
+	hasHeaders := false
+	for _, header := range t.headers {
+		hasHeaders = hasHeaders || strings.TrimSpace(Decolorize(header)) != ""
+	}
+	if options.IsEmpty() || !hasHeaders {
+		return nil
+	}
+
+	columns, indexes, err := options.apply(t.headers, t.rows)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{}
+	transformers := []Transformer{}
+	for _, column := range columns {
+		headers = append(headers, t.headers[column])
+		transformers = append(transformers, t.transformer[column])
+	}
+
+	rows := [][]string{}
+	for _, index := range indexes {
+		row := []string{}
+		for _, column := range columns {
+			row = append(row, cellValueRaw(t.rows[index], column))
+		}
+		rows = append(rows, row)
+	}
+
+	t.headers = headers
+	t.transformer = transformers
+	t.columnWidth = make([]int, len(headers))
+	t.rows = rows
+	return nil
+}
+
+func cellValueRaw(row []string, index int) string {
+	if index >= len(row) {
+		return ""
+	}
+	return row[index]
+}
+
+// Records returns the rows of the table as records named after the
+// headers, for structured output. A table of two columns without headers
+// lists properties of a single thing, and becomes a single record. Like
+// printing, this clears the table.
+func (t *Table) Records() interface{} {
+	defer func() {
+		t.rows = [][]string{}
+	}()
+
+	if len(t.headers) == 2 && t.headers[0] == "" && t.headers[1] == "" {
+		record := Record{}
+		for _, row := range t.rows {
+			if len(row) < 2 || recordFieldName(row[0]) == "" {
+				continue
+			}
+			record = append(record, Field{Name: recordFieldName(row[0]), Value: Decolorize(row[1])})
+		}
+		return record
+	}
+
+	names := make([]string, len(t.headers))
+	for i, header := range t.headers {
+		names[i] = recordFieldName(header)
+		if names[i] == "" {
+			names[i] = fmt.Sprintf("column_%d", i+1)
+		}
+	}
+
+	records := []Record{}
+	for _, row := range t.rows {
+		record := Record{}
+		for i, value := range row {
+			if i >= len(names) {
+				break
+			}
+			record = append(record, Field{Name: names[i], Value: Decolorize(value)})
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
 // calculateMaxSize iterates over the collected rows of the specified
 // table, and their strings, determining the height of each row (in
 // lines), and the width of each column (in characters). The results
@@ -0,0 +1,294 @@
+package terminal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"gopkg.in/yaml.v2"
+)
+
+// TableOptions sort and filter the rows of tables, and choose which of their
+// columns are printed. They are given with the global --sort-by, --filter
+// and --columns options, or CF_SORT_BY, CF_FILTER and CF_COLUMNS. Columns
+// are named by their header or by their field name in records, in any case.
+type TableOptions struct {
+	SortBy     string
+	Descending bool
+	Filters    []ColumnFilter
+	Columns    []string
+}
+
+// ColumnFilter keeps the rows whose value in the column matches the glob
+// pattern, as in path.Match.
+type ColumnFilter struct {
+	Column  string
+	Pattern string
+}
+
+// NewTableOptions parses the options given by the user. sortBy names a
+// column, prefixed with - to sort in descending order; filter is a comma
+// separated list of COLUMN=PATTERN; columns is a comma separated list of
+// columns.
+func NewTableOptions(sortBy string, filter string, columns string) (TableOptions, error) {
+	options := TableOptions{
+		SortBy:     strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(sortBy), "-")),
+		Descending: strings.HasPrefix(strings.TrimSpace(sortBy), "-"),
+	}
+
+	for _, value := range splitOptionList(filter) {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return TableOptions{}, errors.New(T("Invalid filter '{{.Filter}}'. Use COLUMN=PATTERN.",
+				map[string]interface{}{"Filter": value}))
+		}
+
+		_, err := path.Match(parts[1], "")
+		if err != nil {
+			return TableOptions{}, errors.New(T("Invalid filter pattern '{{.Pattern}}'",
+				map[string]interface{}{"Pattern": parts[1]}))
+		}
+
+		options.Filters = append(options.Filters, ColumnFilter{
+			Column:  strings.TrimSpace(parts[0]),
+			Pattern: parts[1],
+		})
+	}
+
+	options.Columns = splitOptionList(columns)
+	return options, nil
+}
+
+func splitOptionList(value string) []string {
+	values := []string{}
+	for _, part := range strings.Split(value, ",") {
+		if strings.TrimSpace(part) != "" {
+			values = append(values, strings.TrimSpace(part))
+		}
+	}
+	return values
+}
+
+func (o TableOptions) IsEmpty() bool {
+	return o.SortBy == "" && len(o.Filters) == 0 && len(o.Columns) == 0
+}
+
+// apply sorts, filters and selects the columns of rows whose columns are
+// named by names. It returns the indexes of the selected columns and of the
+// remaining rows, in order.
+func (o TableOptions) apply(names []string, rows [][]string) ([]int, []int, error) {
+	columnIndex := func(column string) (int, error) {
+		for i, name := range names {
+			if columnNameMatches(name, column) {
+				return i, nil
+			}
+		}
+
+		known := []string{}
+		for _, name := range names {
+			if name = strings.TrimSpace(Decolorize(name)); name != "" {
+				known = append(known, name)
+			}
+		}
+		return 0, errors.New(T("Unknown column '{{.Column}}'. Columns are: {{.Columns}}",
+			map[string]interface{}{"Column": column, "Columns": strings.Join(known, ", ")}))
+	}
+
+	filtered := []int{}
+	filterIndexes := make([]int, len(o.Filters))
+	for i, filter := range o.Filters {
+		index, err := columnIndex(filter.Column)
+		if err != nil {
+			return nil, nil, err
+		}
+		filterIndexes[i] = index
+	}
+
+	for rowIndex, row := range rows {
+		keep := true
+		for i, filter := range o.Filters {
+			matched, _ := path.Match(filter.Pattern, cellValue(row, filterIndexes[i]))
+			keep = keep && matched
+		}
+		if keep {
+			filtered = append(filtered, rowIndex)
+		}
+	}
+
+	if o.SortBy != "" {
+		index, err := columnIndex(o.SortBy)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		sort.Stable(rowsByColumn{indexes: filtered, rows: rows, column: index, descending: o.Descending})
+	}
+
+	selected := []int{}
+	if len(o.Columns) == 0 {
+		for i := range names {
+			selected = append(selected, i)
+		}
+	}
+	for _, column := range o.Columns {
+		index, err := columnIndex(column)
+		if err != nil {
+			return nil, nil, err
+		}
+		selected = append(selected, index)
+	}
+
+	return selected, filtered, nil
+}
+
+// rowsByColumn sorts the indexes of rows by the value of a column.
+type rowsByColumn struct {
+	indexes    []int
+	rows       [][]string
+	column     int
+	descending bool
+}
+
+func (r rowsByColumn) Len() int      { return len(r.indexes) }
+func (r rowsByColumn) Swap(i, j int) { r.indexes[i], r.indexes[j] = r.indexes[j], r.indexes[i] }
+func (r rowsByColumn) Less(i, j int) bool {
+	if r.descending {
+		i, j = j, i
+	}
+	return lessValue(cellValue(r.rows[r.indexes[i]], r.column), cellValue(r.rows[r.indexes[j]], r.column))
+}
+
+func columnNameMatches(name string, column string) bool {
+	name = strings.TrimSpace(Decolorize(name))
+	return name != "" && (strings.EqualFold(name, column) || strings.EqualFold(recordFieldName(name), recordFieldName(column)))
+}
+
+func cellValue(row []string, index int) string {
+	if index >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(Decolorize(row[index]))
+}
+
+var sizePattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)([KMGT])B?$`)
+
+// lessValue compares numbers and sizes such as 512M by their value, and
+// anything else as text. Numbers come before text, so that columns mixing
+// them sort consistently.
+func lessValue(a string, b string) bool {
+	numberA, okA := sortValue(a)
+	numberB, okB := sortValue(b)
+	switch {
+	case okA && okB:
+		return numberA < numberB
+	case okA != okB:
+		return okA
+	}
+	return strings.ToLower(a) < strings.ToLower(b)
+}
+
+func sortValue(value string) (float64, bool) {
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		return number, true
+	}
+
+	parts := sizePattern.FindStringSubmatch(strings.ToUpper(value))
+	if parts == nil {
+		return 0, false
+	}
+
+	number, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	return number * float64(int64(1)<<(10*uint(strings.Index("KMGT", parts[2])+1))), true
+}
+
+// applyToRecords sorts and filters a slice of records like the rows of a
+// table, naming the columns by the records' field names. Unless
+// selectColumns is false, the records are also replaced by Records of the
+// selected fields. Anything else is returned as it is.
+func (o TableOptions) applyToRecords(data interface{}, selectColumns bool) (interface{}, error) {
+	value := reflect.ValueOf(data)
+	if _, isRecord := data.(Record); o.IsEmpty() || isRecord || value.Kind() != reflect.Slice || value.Len() == 0 {
+		return data, nil
+	}
+
+	records := []yaml.MapSlice{}
+	names := []string{}
+	seen := map[string]bool{}
+	for i := 0; i < value.Len(); i++ {
+		jsonBytes, err := json.Marshal(value.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		decoded, err := decodeOrdered(jsonBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		record, ok := decoded.(yaml.MapSlice)
+		if !ok {
+			return data, nil
+		}
+		records = append(records, record)
+
+		for _, item := range record {
+			name := fmt.Sprint(item.Key)
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	rows := make([][]string, len(records))
+	for i, record := range records {
+		values := map[string]interface{}{}
+		for _, item := range record {
+			values[fmt.Sprint(item.Key)] = item.Value
+		}
+
+		rows[i] = make([]string, len(names))
+		for j, name := range names {
+			text, err := csvValue(values[name])
+			if err != nil {
+				return nil, err
+			}
+			rows[i][j] = text
+		}
+	}
+
+	columns, indexes, err := o.apply(names, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []interface{}{}
+	for _, index := range indexes {
+		if !selectColumns {
+			result = append(result, value.Index(index).Interface())
+			continue
+		}
+
+		values := map[string]interface{}{}
+		for _, item := range records[index] {
+			values[fmt.Sprint(item.Key)] = item.Value
+		}
+
+		record := Record{}
+		for _, column := range columns {
+			record = append(record, Field{Name: names[column], Value: plain(values[names[column]])})
+		}
+		result = append(result, record)
+	}
+	return result, nil
+}
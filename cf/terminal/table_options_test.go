@@ -0,0 +1,108 @@
+package terminal_test
+
+import (
+	"bytes"
+
+	. "github.com/cloudfoundry/cli/cf/terminal"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TableOptions", func() {
+	Describe("NewTableOptions", func() {
+		It("is empty when no options are given", func() {
+			options, err := NewTableOptions("", "", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(options.IsEmpty()).To(BeTrue())
+		})
+
+		It("parses the sort column, filters and columns", func() {
+			options, err := NewTableOptions("-memory", "state=start*, name = my-*", "name, state")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(options).To(Equal(TableOptions{
+				SortBy:     "memory",
+				Descending: true,
+				Filters: []ColumnFilter{
+					{Column: "state", Pattern: "start*"},
+					{Column: "name", Pattern: " my-*"},
+				},
+				Columns: []string{"name", "state"},
+			}))
+		})
+
+		It("returns an error for filters without a column", func() {
+			_, err := NewTableOptions("", "=started", "")
+			Expect(err).To(MatchError(ContainSubstring("Invalid filter")))
+
+			_, err = NewTableOptions("", "started", "")
+			Expect(err).To(MatchError(ContainSubstring("Invalid filter")))
+		})
+
+		It("returns an error for invalid patterns", func() {
+			_, err := NewTableOptions("", "name=[a", "")
+			Expect(err).To(MatchError(ContainSubstring("Invalid filter pattern")))
+		})
+	})
+
+	Describe("WriteRecords with table options", func() {
+		type app struct {
+			Name      string `json:"name"`
+			State     string `json:"state"`
+			Instances int    `json:"instances"`
+		}
+
+		var (
+			output *bytes.Buffer
+			apps   []app
+		)
+
+		BeforeEach(func() {
+			output = &bytes.Buffer{}
+			apps = []app{
+				{Name: "web", State: "started", Instances: 10},
+				{Name: "api", State: "started", Instances: 2},
+				{Name: "worker", State: "stopped", Instances: 1},
+			}
+		})
+
+		outputOptions := func(format, template, sortBy, filter, columns string) OutputOptions {
+			options, err := NewOutputOptions(format, template, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			options.Table, err = NewTableOptions(sortBy, filter, columns)
+			Expect(err).NotTo(HaveOccurred())
+			return options
+		}
+
+		It("sorts, filters and selects the fields of records", func() {
+			options := outputOptions("csv", "", "instances", "state=started", "name,instances")
+
+			err := options.WriteRecords(output, apps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output.String()).To(Equal("name,instances\napi,2\nweb,10\n"))
+		})
+
+		It("keeps the Go fields of records for templates", func() {
+			options := outputOptions("", "{{.Name}}", "-name", "", "state")
+
+			err := options.WriteRecords(output, apps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output.String()).To(Equal("worker\nweb\napi\n"))
+		})
+
+		It("leaves a single record alone", func() {
+			options := outputOptions("json", "", "", "", "name")
+
+			err := options.WriteRecords(output, apps[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output.String()).To(MatchJSON(`{"name": "web", "state": "started", "instances": 10}`))
+		})
+
+		It("returns an error for unknown columns", func() {
+			options := outputOptions("json", "", "memory", "", "")
+
+			err := options.WriteRecords(output, apps)
+			Expect(err).To(MatchError(ContainSubstring("Unknown column")))
+		})
+	})
+})
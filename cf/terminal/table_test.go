@@ -267,4 +267,109 @@ var _ = Describe("Table", func() {
 			))
 		})
 	})
+
+	Describe("Apply", func() {
+		BeforeEach(func() {
+			table = NewTable([]string{"name", "requested state", "memory"})
+			table.Add("beta", "started", "1G")
+			table.Add("alpha", "stopped", "512M")
+			table.Add("gamma", "started", "64M")
+		})
+
+		It("sorts the rows by a column, comparing sizes by their value", func() {
+			options, err := NewTableOptions("memory", "", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(table.Apply(options)).To(Succeed())
+
+			table.PrintTo(outputs)
+			Expect(strings.Index(outputs.String(), "gamma")).To(BeNumerically("<", strings.Index(outputs.String(), "alpha")))
+			Expect(strings.Index(outputs.String(), "alpha")).To(BeNumerically("<", strings.Index(outputs.String(), "beta")))
+		})
+
+		It("sorts numbers before text in columns that mix them", func() {
+			table = NewTable([]string{"name", "memory"})
+			table.Add("a", "(none)")
+			table.Add("b", "1G")
+			table.Add("c", "10")
+			table.Add("d", "512M")
+			table.Add("e", "2")
+
+			options, err := NewTableOptions("memory", "", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(table.Apply(options)).To(Succeed())
+
+			table.PrintTo(outputs)
+			Expect(outputs.String()).To(MatchRegexp(`(?s)e\s+2\s+c\s+10\s+d\s+512M\s+b\s+1G\s+a\s+\(none\)`))
+		})
+
+		It("sorts in descending order when the column starts with -", func() {
+			options, err := NewTableOptions("-name", "", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(table.Apply(options)).To(Succeed())
+
+			Expect(table.Records()).To(Equal([]Record{
+				{{Name: "name", Value: "gamma"}, {Name: "requested_state", Value: "started"}, {Name: "memory", Value: "64M"}},
+				{{Name: "name", Value: "beta"}, {Name: "requested_state", Value: "started"}, {Name: "memory", Value: "1G"}},
+				{{Name: "name", Value: "alpha"}, {Name: "requested_state", Value: "stopped"}, {Name: "memory", Value: "512M"}},
+			}))
+		})
+
+		It("filters the rows and selects the columns by header or field name", func() {
+			options, err := NewTableOptions("", "requested_state=start*", "Memory,name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(table.Apply(options)).To(Succeed())
+
+			Expect(table.Records()).To(Equal([]Record{
+				{{Name: "memory", Value: "1G"}, {Name: "name", Value: "beta"}},
+				{{Name: "memory", Value: "64M"}, {Name: "name", Value: "gamma"}},
+			}))
+		})
+
+		It("returns an error for unknown columns", func() {
+			options, err := NewTableOptions("disk", "", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(table.Apply(options)).To(MatchError(ContainSubstring("Unknown column")))
+		})
+
+		It("leaves tables of properties alone", func() {
+			table = NewTable([]string{"", ""})
+			table.Add("Memory Limit:", "1G")
+
+			options, err := NewTableOptions("disk", "", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(table.Apply(options)).To(Succeed())
+			Expect(table.Records()).To(Equal(Record{{Name: "memory_limit", Value: "1G"}}))
+		})
+	})
+
+	Describe("Records", func() {
+		It("names the values of each row after the headers", func() {
+			table = NewTable([]string{"name", "requested state", ""})
+			table.Add("app1", HeaderColor("started"), "extra")
+
+			Expect(table.Records()).To(Equal([]Record{
+				{
+					{Name: "name", Value: "app1"},
+					{Name: "requested_state", Value: "started"},
+					{Name: "column_3", Value: "extra"},
+				},
+			}))
+		})
+
+		It("turns a table of properties into a single record", func() {
+			table = NewTable([]string{"", ""})
+			table.Add("Memory Limit:", "1G")
+			table.Add("", "")
+
+			Expect(table.Records()).To(Equal(Record{
+				{Name: "memory_limit", Value: "1G"},
+			}))
+		})
+
+		It("clears the rows", func() {
+			table.Add("a", "b", "c")
+			table.Records()
+			Expect(table.Records()).To(BeEmpty())
+		})
+	})
 })
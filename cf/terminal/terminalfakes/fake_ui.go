@@ -34,6 +34,12 @@ type FakeUI struct {
 		message string
 		args    []interface{}
 	}
+	InteractiveStub        func() bool
+	interactiveMutex       sync.RWMutex
+	interactiveArgsForCall []struct{}
+	interactiveReturns     struct {
+		result1 bool
+	}
 	AskStub        func(prompt string) (answer string)
 	askMutex       sync.RWMutex
 	askArgsForCall []struct {
@@ -109,6 +115,26 @@ type FakeUI struct {
 	notifyUpdateIfNeededArgsForCall []struct {
 		arg1 coreconfig.Reader
 	}
+	OutputFormatStub        func() terminal.OutputFormat
+	outputFormatMutex       sync.RWMutex
+	outputFormatArgsForCall []struct{}
+	outputFormatReturns     struct {
+		result1 terminal.OutputFormat
+	}
+	OutputStub        func(data interface{}) error
+	outputMutex       sync.RWMutex
+	outputArgsForCall []struct {
+		data interface{}
+	}
+	outputReturns struct {
+		result1 error
+	}
+	TableOptionsStub        func() terminal.TableOptions
+	tableOptionsMutex       sync.RWMutex
+	tableOptionsArgsForCall []struct{}
+	tableOptionsReturns     struct {
+		result1 terminal.TableOptions
+	}
 	WriterStub        func() io.Writer
 	writerMutex       sync.RWMutex
 	writerArgsForCall []struct{}
@@ -218,6 +244,30 @@ func (fake *FakeUI) WarnArgsForCall(i int) (string, []interface{}) {
 	return fake.warnArgsForCall[i].message, fake.warnArgsForCall[i].args
 }
 
+func (fake *FakeUI) Interactive() bool {
+	fake.interactiveMutex.Lock()
+	fake.interactiveArgsForCall = append(fake.interactiveArgsForCall, struct{}{})
+	fake.interactiveMutex.Unlock()
+	if fake.InteractiveStub != nil {
+		return fake.InteractiveStub()
+	} else {
+		return fake.interactiveReturns.result1
+	}
+}
+
+func (fake *FakeUI) InteractiveCallCount() int {
+	fake.interactiveMutex.RLock()
+	defer fake.interactiveMutex.RUnlock()
+	return len(fake.interactiveArgsForCall)
+}
+
+func (fake *FakeUI) InteractiveReturns(result1 bool) {
+	fake.InteractiveStub = nil
+	fake.interactiveReturns = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeUI) Ask(prompt string) (answer string) {
 	fake.askMutex.Lock()
 	fake.askArgsForCall = append(fake.askArgsForCall, struct {
@@ -532,6 +582,86 @@ func (fake *FakeUI) NotifyUpdateIfNeededArgsForCall(i int) coreconfig.Reader {
 	return fake.notifyUpdateIfNeededArgsForCall[i].arg1
 }
 
+func (fake *FakeUI) OutputFormat() terminal.OutputFormat {
+	fake.outputFormatMutex.Lock()
+	fake.outputFormatArgsForCall = append(fake.outputFormatArgsForCall, struct{}{})
+	fake.outputFormatMutex.Unlock()
+	if fake.OutputFormatStub != nil {
+		return fake.OutputFormatStub()
+	} else {
+		return fake.outputFormatReturns.result1
+	}
+}
+
+func (fake *FakeUI) OutputFormatCallCount() int {
+	fake.outputFormatMutex.RLock()
+	defer fake.outputFormatMutex.RUnlock()
+	return len(fake.outputFormatArgsForCall)
+}
+
+func (fake *FakeUI) OutputFormatReturns(result1 terminal.OutputFormat) {
+	fake.OutputFormatStub = nil
+	fake.outputFormatReturns = struct {
+		result1 terminal.OutputFormat
+	}{result1}
+}
+
+func (fake *FakeUI) Output(data interface{}) error {
+	fake.outputMutex.Lock()
+	fake.outputArgsForCall = append(fake.outputArgsForCall, struct {
+		data interface{}
+	}{data})
+	fake.outputMutex.Unlock()
+	if fake.OutputStub != nil {
+		return fake.OutputStub(data)
+	} else {
+		return fake.outputReturns.result1
+	}
+}
+
+func (fake *FakeUI) OutputCallCount() int {
+	fake.outputMutex.RLock()
+	defer fake.outputMutex.RUnlock()
+	return len(fake.outputArgsForCall)
+}
+
+func (fake *FakeUI) OutputArgsForCall(i int) interface{} {
+	fake.outputMutex.RLock()
+	defer fake.outputMutex.RUnlock()
+	return fake.outputArgsForCall[i].data
+}
+
+func (fake *FakeUI) OutputReturns(result1 error) {
+	fake.OutputStub = nil
+	fake.outputReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUI) TableOptions() terminal.TableOptions {
+	fake.tableOptionsMutex.Lock()
+	fake.tableOptionsArgsForCall = append(fake.tableOptionsArgsForCall, struct{}{})
+	fake.tableOptionsMutex.Unlock()
+	if fake.TableOptionsStub != nil {
+		return fake.TableOptionsStub()
+	} else {
+		return fake.tableOptionsReturns.result1
+	}
+}
+
+func (fake *FakeUI) TableOptionsCallCount() int {
+	fake.tableOptionsMutex.RLock()
+	defer fake.tableOptionsMutex.RUnlock()
+	return len(fake.tableOptionsArgsForCall)
+}
+
+func (fake *FakeUI) TableOptionsReturns(result1 terminal.TableOptions) {
+	fake.TableOptionsStub = nil
+	fake.tableOptionsReturns = struct {
+		result1 terminal.TableOptions
+	}{result1}
+}
+
 func (fake *FakeUI) Writer() io.Writer {
 	fake.writerMutex.Lock()
 	fake.writerArgsForCall = append(fake.writerArgsForCall, struct{}{})
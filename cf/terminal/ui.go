@@ -13,11 +13,37 @@ import (
 
 	"github.com/cloudfoundry/cli/cf"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/trace"
 )
 
 const QuietPanic = "This shouldn't print anything"
 
+// exitCode is the code cf exits with after a UI fails quietly. It is set by
+// FailWithCode to the category of the failure before the UI fails.
+var exitCode = errors.ExitCodeFailure
+
+// ExitCode returns the code cf exits with after a UI fails quietly.
+func ExitCode() int {
+	return exitCode
+}
+
+// SetExitCode sets the code cf exits with if a UI fails without going
+// through FailWithCode.
+func SetExitCode(code int) {
+	exitCode = code
+}
+
+// FailWithCode makes ui fail with the message of err. cf then exits with the
+// exit code of the category of err, or with code if err does not have one.
+func FailWithCode(ui UI, err error, code int) {
+	exitCode = errors.ExitCode(err)
+	if exitCode == errors.ExitCodeFailure {
+		exitCode = code
+	}
+	ui.Failed("%s", err.Error())
+}
+
 type ColoringFunction func(value string, row int, col int) string
 
 func NotLoggedInText() string {
@@ -32,6 +58,7 @@ type UI interface {
 	// ProgressReader
 	PrintCapturingNoOutput(message string, args ...interface{})
 	Warn(message string, args ...interface{})
+	Interactive() bool
 	Ask(prompt string) (answer string)
 	AskForPassword(prompt string) (answer string)
 	Confirm(message string) bool
@@ -45,6 +72,10 @@ type UI interface {
 	Table(headers []string) *UITable
 	NotifyUpdateIfNeeded(coreconfig.Reader)
 
+	OutputFormat() OutputFormat
+	Output(data interface{}) error
+	TableOptions() TableOptions
+
 	Writer() io.Writer
 }
 
@@ -59,14 +90,23 @@ type terminalUI struct {
 	stdout  io.Writer
 	printer Printer
 	logger  trace.Printer
+	output  OutputOptions
 }
 
 func NewUI(r io.Reader, w io.Writer, printer Printer, logger trace.Printer) UI {
+	return NewUIWithOutputOptions(r, w, printer, logger, OutputOptions{Format: TableOutput})
+}
+
+// NewUIWithOutputOptions returns a UI that prints records as chosen by
+// output to w. Messages go to printer, which should not share w with the
+// records when the format is structured.
+func NewUIWithOutputOptions(r io.Reader, w io.Writer, printer Printer, logger trace.Printer, output OutputOptions) UI {
 	return &terminalUI{
 		stdin:   r,
 		stdout:  w,
 		printer: printer,
 		logger:  logger,
+		output:  output,
 	}
 }
 
@@ -107,6 +147,10 @@ func (ui *terminalUI) Warn(message string, args ...interface{}) {
 	return
 }
 
+func (ui *terminalUI) Interactive() bool {
+	return true
+}
+
 func (ui *terminalUI) Ask(prompt string) string {
 	fmt.Fprintf(ui.stdout, "\n%s%s ", prompt, PromptColor(">"))
 
@@ -248,6 +292,20 @@ func (ui *terminalUI) LoadingIndication() {
 	_, _ = ui.printer.Print(".")
 }
 
+func (ui *terminalUI) OutputFormat() OutputFormat {
+	return ui.output.Format
+}
+
+func (ui *terminalUI) TableOptions() TableOptions {
+	return ui.output.Table
+}
+
+// Output prints data, a record or a slice of records, in the output format.
+// See OutputOptions.WriteRecords.
+func (ui *terminalUI) Output(data interface{}) error {
+	return ui.output.WriteRecords(ui.stdout, data)
+}
+
 func (ui *terminalUI) Table(headers []string) *UITable {
 	return &UITable{
 		UI:    ui,
@@ -271,6 +329,20 @@ func (u *UITable) Print() {
 	result := &bytes.Buffer{}
 	t := u.Table
 
+	err := t.Apply(u.UI.TableOptions())
+	if err != nil {
+		u.UI.Failed(err.Error())
+		return
+	}
+
+	if u.UI.OutputFormat().IsStructured() {
+		err := u.UI.Output(t.Records())
+		if err != nil {
+			u.UI.Failed(err.Error())
+		}
+		return
+	}
+
 	t.PrintTo(result)
 
 	// DevNote. With the change to printing into a buffer all
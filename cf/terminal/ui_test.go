@@ -4,9 +4,11 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/cloudfoundry/cli/cf"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
 	"github.com/cloudfoundry/cli/cf/trace/tracefakes"
@@ -223,6 +225,92 @@ var _ = Describe("UI", func() {
 		})
 	})
 
+	Describe("Non-interactive mode", func() {
+		It("is interactive by default", func() {
+			Expect(NewUI(os.Stdin, os.Stdout, NewTeePrinter(os.Stdout), fakeLogger).Interactive()).To(BeTrue())
+		})
+
+		It("fails prompts instead of reading from stdin", func() {
+			io_helpers.SimulateStdin("y\n", func(reader io.Reader) {
+				out := io_helpers.CaptureOutput(func() {
+					ui := NewNonInteractiveUI(NewUI(reader, os.Stdout, NewTeePrinter(os.Stdout), fakeLogger))
+					Expect(ui.Interactive()).To(BeFalse())
+					Expect(func() { ui.Ask("Username") }).To(Panic())
+				})
+
+				Expect(out).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Cannot prompt for 'Username' in non-interactive mode"},
+				))
+				Expect(out).NotTo(ContainSubstrings([]string{"Username>"}))
+			})
+		})
+
+		It("names -f when a deletion cannot be confirmed", func() {
+			out := io_helpers.CaptureOutput(func() {
+				ui := NewNonInteractiveUI(NewUI(os.Stdin, os.Stdout, NewTeePrinter(os.Stdout), fakeLogger))
+				Expect(func() { ui.ConfirmDelete("app", "my-app") }).To(Panic())
+			})
+
+			Expect(out).To(ContainSubstrings(
+				[]string{"Cannot prompt for 'Really delete the app my-app?' in non-interactive mode. Use -f instead."},
+			))
+		})
+
+		It("sets the usage exit code when a prompt fails", func() {
+			SetExitCode(errors.ExitCodeFailure)
+			defer SetExitCode(errors.ExitCodeFailure)
+
+			io_helpers.CaptureOutput(func() {
+				ui := NewNonInteractiveUI(NewUI(os.Stdin, os.Stdout, NewTeePrinter(os.Stdout), fakeLogger))
+				Expect(func() { ui.Confirm("Really?") }).To(Panic())
+			})
+
+			Expect(ExitCode()).To(Equal(errors.ExitCodeUsage))
+		})
+	})
+
+	Describe("Prefixed output", func() {
+		It("starts every line with the prefix", func() {
+			out := io_helpers.CaptureOutput(func() {
+				ui := NewPrefixedUI(NewUI(os.Stdin, os.Stdout, NewTeePrinter(os.Stdout), fakeLogger), "[my-app] ", &sync.Mutex{})
+				ui.Say("Creating app %s...\nDone", "my-app")
+				ui.Ok()
+				ui.Warn("%d%% done", 50)
+			})
+
+			Expect(out).To(Equal([]string{
+				"[my-app] Creating app my-app...",
+				"[my-app] Done",
+				"[my-app] OK",
+				"[my-app] 50% done",
+				"",
+			}))
+		})
+
+		It("prefixes tables", func() {
+			out := io_helpers.CaptureOutput(func() {
+				ui := NewPrefixedUI(NewUI(os.Stdin, os.Stdout, NewTeePrinter(os.Stdout), fakeLogger), "[my-app] ", &sync.Mutex{})
+				table := ui.Table([]string{"name", "state"})
+				table.Add("web", "started")
+				table.Print()
+			})
+
+			Expect(out).To(HaveLen(3))
+			Expect(out[0]).To(HavePrefix("[my-app] name"))
+			Expect(out[1]).To(HavePrefix("[my-app] web"))
+		})
+
+		It("prefixes failures and panics quietly", func() {
+			out := io_helpers.CaptureOutput(func() {
+				ui := NewPrefixedUI(NewUI(os.Stdin, os.Stdout, NewTeePrinter(os.Stdout), fakeLogger), "[my-app] ", &sync.Mutex{})
+				Expect(func() { ui.Failed("staging failed") }).To(Panic())
+			})
+
+			Expect(out).To(Equal([]string{"[my-app] FAILED", "[my-app] staging failed", ""}))
+		})
+	})
+
 	Context("when user is not logged in", func() {
 		var config coreconfig.Reader
 
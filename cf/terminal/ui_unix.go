@@ -12,6 +12,7 @@ import (
 	"strings"
 	"syscall"
 
+	"github.com/cloudfoundry/cli/cf/errors"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 )
 
@@ -97,6 +98,6 @@ func catchSignal(fd []uintptr, sig chan os.Signal) {
 	select {
 	case <-sig:
 		echoOn(fd)
-		os.Exit(2)
+		os.Exit(errors.ExitCodeInterrupted)
 	}
 }
@@ -0,0 +1,214 @@
+package trace
+
+import (
+	"bytes"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/errors"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+// DefaultRedactedKeys are the glob patterns of JSON properties whose values
+// Sanitize hides unless CF_TRACE_REDACT adds more.
+var DefaultRedactedKeys = []string{"*_SECRET", "*_KEY", "*_PASSWORD", "*_TOKEN"}
+
+// redactedBlocks are the JSON properties that hold service credentials and
+// user-provided environment variables.
+var redactedBlocks = []string{"credentials", "environment_json"}
+
+var redactedKeys = DefaultRedactedKeys
+
+// SetRedactedKeys makes Sanitize hide the values of JSON properties that
+// match any of the comma-separated glob patterns in envPatterns, such as
+// *_CERT, besides DefaultRedactedKeys. Patterns ignore case.
+func SetRedactedKeys(envPatterns string) error {
+	keys := append([]string{}, DefaultRedactedKeys...)
+
+	for _, pattern := range strings.Split(envPatterns, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.New(T("Invalid CF_TRACE_REDACT pattern {{.Pattern}}",
+				map[string]interface{}{"Pattern": pattern}))
+		}
+		keys = append(keys, pattern)
+	}
+
+	redactedKeys = keys
+	return nil
+}
+
+func isRedactedKey(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, block := range redactedBlocks {
+		if key == block {
+			return true
+		}
+	}
+
+	for _, pattern := range redactedKeys {
+		if matched, _ := path.Match(strings.ToLower(pattern), key); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// redactJSON hides the values of the redacted properties of the JSON in
+// input, which may be surrounded by other text such as HTTP headers. The
+// names of properties nested in redacted objects are kept, so that traces
+// still show the shape of credentials and environment variables.
+func redactJSON(input string) string {
+	var output bytes.Buffer
+
+	for i := 0; i < len(input); {
+		if input[i] != '"' {
+			output.WriteByte(input[i])
+			i++
+			continue
+		}
+
+		end := stringEnd(input, i)
+		if end < 0 {
+			output.WriteString(input[i:])
+			break
+		}
+		output.WriteString(input[i:end])
+
+		colon := skipSpace(input, end)
+		if colon >= len(input) || input[colon] != ':' || !isRedactedKey(unquote(input[i:end])) {
+			i = end
+			continue
+		}
+
+		valueStart := skipSpace(input, colon+1)
+		output.WriteString(input[end:valueStart])
+
+		var redacted string
+		i, redacted = redactValue(input, valueStart)
+		output.WriteString(redacted)
+	}
+
+	return output.String()
+}
+
+// redactValue returns the end of the JSON value that starts at start, and
+// the value with every string, number and boolean in it hidden.
+func redactValue(input string, start int) (int, string) {
+	if start >= len(input) {
+		return start, ""
+	}
+
+	switch input[start] {
+	case '"':
+		end := stringEnd(input, start)
+		if end < 0 {
+			end = len(input)
+		}
+		return end, placeholder()
+	case '{', '[':
+		return redactContainer(input, start)
+	}
+
+	end := literalEnd(input, start)
+	if end == start {
+		return start, ""
+	}
+	if input[start:end] == "null" {
+		return end, "null"
+	}
+	return end, placeholder()
+}
+
+func redactContainer(input string, start int) (int, string) {
+	var output bytes.Buffer
+	output.WriteByte(input[start])
+
+	for i := start + 1; i < len(input); {
+		switch c := input[i]; c {
+		case '}', ']':
+			output.WriteByte(c)
+			return i + 1, output.String()
+		case '"':
+			end := stringEnd(input, i)
+			if end < 0 {
+				output.WriteString(placeholder())
+				return len(input), output.String()
+			}
+
+			next := skipSpace(input, end)
+			if next < len(input) && input[next] == ':' {
+				output.WriteString(input[i:end])
+			} else {
+				output.WriteString(placeholder())
+			}
+			i = end
+		case '{', '[':
+			end, redacted := redactContainer(input, i)
+			output.WriteString(redacted)
+			i = end
+		case ',', ':', ' ', '\t', '\r', '\n':
+			output.WriteByte(c)
+			i++
+		default:
+			end, redacted := redactValue(input, i)
+			if end == i {
+				output.WriteByte(c)
+				end++
+			}
+			output.WriteString(redacted)
+			i = end
+		}
+	}
+
+	// the JSON was cut short, so everything after start is hidden
+	return len(input), output.String()
+}
+
+// stringEnd returns the index after the JSON string that starts at start,
+// or -1 if it is not terminated.
+func stringEnd(input string, start int) int {
+	for i := start + 1; i < len(input); i++ {
+		switch input[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func literalEnd(input string, start int) int {
+	i := start
+	for i < len(input) && !strings.ContainsRune(",:{}[]\" \t\r\n", rune(input[i])) {
+		i++
+	}
+	return i
+}
+
+func skipSpace(input string, start int) int {
+	i := start
+	for i < len(input) && strings.ContainsRune(" \t\r\n", rune(input[i])) {
+		i++
+	}
+	return i
+}
+
+func unquote(quoted string) string {
+	unquoted, err := strconv.Unquote(quoted)
+	if err != nil {
+		return strings.Trim(quoted, `"`)
+	}
+	return unquoted
+}
+
+func placeholder() string {
+	return `"` + PrivateDataPlaceholder() + `"`
+}
@@ -22,7 +22,7 @@ func Sanitize(input string) string {
 	sanitized = sanitizeJSON("password", sanitized)
 	sanitized = sanitizeJSON("oldPassword", sanitized)
 
-	return sanitized
+	return redactJSON(sanitized)
 }
 
 func sanitizeJSON(propertyName string, json string) string {
@@ -0,0 +1,67 @@
+// credential-helper is a cf credential helper that keeps credentials in the
+// JSON file named by CREDENTIAL_HELPER_FILE. It is used to test the helper
+// protocol.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: credential-helper (get | store | erase)")
+		os.Exit(1)
+	}
+
+	request := map[string]string{}
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		if scanner.Text() == "" {
+			break
+		}
+
+		parts := strings.SplitN(scanner.Text(), "=", 2)
+		request[parts[0]] = parts[1]
+	}
+
+	if request["key"] == "broken" {
+		fmt.Fprintln(os.Stderr, "the keychain is locked")
+		os.Exit(1)
+	}
+
+	path := os.Getenv("CREDENTIAL_HELPER_FILE")
+	stored := map[string]map[string]string{}
+	contents, err := ioutil.ReadFile(path)
+	if err == nil {
+		_ = json.Unmarshal(contents, &stored)
+	}
+
+	id := request["config"] + " " + request["key"]
+
+	switch os.Args[1] {
+	case "get":
+		for name, value := range stored[id] {
+			fmt.Printf("%s=%s\n", name, value)
+		}
+		return
+	case "store":
+		stored[id] = map[string]string{
+			"access_token":  request["access_token"],
+			"refresh_token": request["refresh_token"],
+		}
+	case "erase":
+		delete(stored, id)
+	}
+
+	contents, _ = json.Marshal(stored)
+	err = ioutil.WriteFile(path, contents, 0600)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,62 @@
+// persistor-hammer repeatedly saves its own key into a shared config file
+// through a DiskPersistor. It is used to test concurrent access from many
+// processes.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/cloudfoundry/cli/cf/configuration"
+)
+
+type fields map[string]string
+
+func (f *fields) JSONMarshalV3() ([]byte, error) {
+	return json.MarshalIndent(f, "", "  ")
+}
+
+func (f *fields) JSONUnmarshalV3(input []byte) error {
+	data := fields{}
+	err := json.Unmarshal(input, &data)
+	if err != nil {
+		return err
+	}
+
+	*f = data
+	return nil
+}
+
+func main() {
+	if len(os.Args) != 4 {
+		fmt.Fprintln(os.Stderr, "usage: persistor-hammer CONFIG_FILE KEY ITERATIONS")
+		os.Exit(1)
+	}
+
+	iterations, err := strconv.Atoi(os.Args[3])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	persistor := configuration.NewDiskPersistor(os.Args[1])
+	data := &fields{}
+
+	err = persistor.Load(data)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	for i := 1; i <= iterations; i++ {
+		(*data)[os.Args[2]] = strconv.Itoa(i)
+
+		err = persistor.Save(data)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package terminal
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"strings"
@@ -27,8 +28,16 @@ type FakeUI struct {
 	FailedWithUsageCommandName string
 	PanickedQuietly            bool
 	ShowConfigurationCalled    bool
-
-	sayMutex sync.Mutex
+	Format                     term.OutputFormat
+	Template                   string
+	JSONPath                   string
+	SortBy                     string
+	Filter                     string
+	Columns                    string
+	NonInteractive             bool
+
+	structuredOutput []string
+	sayMutex         sync.Mutex
 }
 
 func (ui *FakeUI) Outputs() []string {
@@ -85,9 +94,17 @@ func (ui *FakeUI) Warn(message string, args ...interface{}) {
 	return
 }
 
+func (ui *FakeUI) Interactive() bool {
+	return !ui.NonInteractive
+}
+
 func (ui *FakeUI) Ask(prompt string) string {
 	ui.Prompts = append(ui.Prompts, prompt)
 
+	if ui.NonInteractive {
+		ui.Failed(term.NewNonInteractiveError(prompt, "").Error())
+	}
+
 	if len(ui.Inputs) == 0 {
 		panic("No input provided to Fake UI for prompt: " + prompt)
 	}
@@ -110,6 +127,10 @@ func (ui *FakeUI) ConfirmDeleteWithAssociations(modelType, modelName string) boo
 }
 
 func (ui *FakeUI) Confirm(prompt string) bool {
+	if ui.NonInteractive {
+		ui.Failed(term.NewNonInteractiveError(prompt, "-f").Error())
+	}
+
 	response := ui.Ask(prompt)
 	switch strings.ToLower(response) {
 	case "y", "yes":
@@ -121,6 +142,10 @@ func (ui *FakeUI) Confirm(prompt string) bool {
 func (ui *FakeUI) AskForPassword(prompt string) string {
 	ui.PasswordPrompts = append(ui.PasswordPrompts, prompt)
 
+	if ui.NonInteractive {
+		ui.Failed(term.NewNonInteractiveError(prompt, "").Error())
+	}
+
 	if len(ui.Inputs) == 0 {
 		panic("No input provided to Fake UI for prompt: " + prompt)
 	}
@@ -186,3 +211,52 @@ func (ui *FakeUI) NotifyUpdateIfNeeded(config coreconfig.Reader) {
 		ui.Say("Cloud Foundry API version {{.APIVer}} requires CLI version " + config.MinCLIVersion() + "  You are currently on version {{.CLIVer}}. To upgrade your CLI, please visit: https://github.com/cloudfoundry/cli#downloads")
 	}
 }
+
+func (ui *FakeUI) OutputFormat() term.OutputFormat {
+	switch {
+	case ui.Template != "":
+		return term.TemplateOutput
+	case ui.JSONPath != "":
+		return term.JSONPathOutput
+	case ui.Format == "":
+		return term.TableOutput
+	}
+	return ui.Format
+}
+
+func (ui *FakeUI) TableOptions() term.TableOptions {
+	options, _ := term.NewTableOptions(ui.SortBy, ui.Filter, ui.Columns)
+	return options
+}
+
+func (ui *FakeUI) Output(data interface{}) error {
+	options, err := term.NewOutputOptions(string(ui.Format), ui.Template, ui.JSONPath)
+	if err != nil {
+		return err
+	}
+
+	options.Table, err = term.NewTableOptions(ui.SortBy, ui.Filter, ui.Columns)
+	if err != nil {
+		return err
+	}
+
+	output := &bytes.Buffer{}
+	err = options.WriteRecords(output, data)
+	if err != nil {
+		return err
+	}
+
+	ui.sayMutex.Lock()
+	defer ui.sayMutex.Unlock()
+
+	ui.structuredOutput = append(ui.structuredOutput, output.String())
+	return nil
+}
+
+// StructuredOutput returns everything printed with Output.
+func (ui *FakeUI) StructuredOutput() string {
+	ui.sayMutex.Lock()
+	defer ui.sayMutex.Unlock()
+
+	return strings.Join(ui.structuredOutput, "")
+}